@@ -80,6 +80,15 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == transformConfigCommandName {
+		cmd := newTransformConfigCommand()
+		cmd.SetArgs(os.Args[2:])
+		if err := cmd.Execute(); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	klog.InitFlags(flag.CommandLine)
 
 	metricsAddr := flag.String(
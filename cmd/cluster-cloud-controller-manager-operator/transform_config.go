@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloudconfig"
+)
+
+const transformConfigCommandName = "transform-config"
+
+type transformConfigOptions struct {
+	platform           string
+	cloudConfigFile    string
+	infrastructureFile string
+}
+
+// newTransformConfigCommand returns the command printing the config the operator would sync
+// to the external cloud controller manager for the given cloud config and Infrastructure resource.
+func newTransformConfigCommand() *cobra.Command {
+	opts := &transformConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:   transformConfigCommandName + " [OPTIONS]",
+		Short: "Print the external cloud controller manager config transformed from a cloud config",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.platform, "platform", "", "Platform type of the cluster, e.g. AWS. It is set on the Infrastructure resource if the latter has no platform status.")
+	cmd.Flags().StringVar(&opts.cloudConfigFile, "cloud-config", "", "Location of the cloud config file to transform. An empty config is transformed if not set.")
+	cmd.Flags().StringVar(&opts.infrastructureFile, "infrastructure", "", "Location of the YAML manifest of the cluster Infrastructure resource.")
+	for _, name := range []string{"platform", "infrastructure"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			panic(err)
+		}
+	}
+
+	return cmd
+}
+
+func (opts *transformConfigOptions) run(cmd *cobra.Command) error {
+	infra, err := opts.readInfrastructure()
+	if err != nil {
+		return err
+	}
+
+	var source []byte
+	if opts.cloudConfigFile != "" {
+		if source, err = os.ReadFile(opts.cloudConfigFile); err != nil {
+			return fmt.Errorf("couldn't read cloud config from file: %w", err)
+		}
+	}
+
+	result, err := cloudconfig.Transform(string(source), infra, cloudconfig.Options{})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), result.Config)
+	return err
+}
+
+// readInfrastructure reads the Infrastructure resource and reconciles its platform with the one given as a flag.
+func (opts *transformConfigOptions) readInfrastructure() (*configv1.Infrastructure, error) {
+	rawData, err := os.ReadFile(opts.infrastructureFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read infrastructure from file: %w", err)
+	}
+
+	infra := &configv1.Infrastructure{}
+	if err := yaml.UnmarshalStrict(rawData, infra); err != nil {
+		return nil, fmt.Errorf("couldn't decode infrastructure: %w", err)
+	}
+
+	platform := configv1.PlatformType(opts.platform)
+	if infra.Status.PlatformStatus == nil {
+		infra.Status.PlatformStatus = &configv1.PlatformStatus{Type: platform}
+	} else if infra.Status.PlatformStatus.Type != platform {
+		return nil, fmt.Errorf("platform %q does not match the platform %q of the infrastructure", platform, infra.Status.PlatformStatus.Type)
+	}
+	return infra, nil
+}
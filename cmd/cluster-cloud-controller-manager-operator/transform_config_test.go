@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const awsInfrastructure = `apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  name: cluster
+status:
+  infrastructureName: my-cluster-abcde
+  platformStatus:
+    type: AWS
+    aws:
+      region: us-east-1
+`
+
+func TestTransformConfigCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	awsInfraFile := writeFile("aws-infrastructure.yaml", awsInfrastructure)
+	noStatusInfraFile := writeFile("no-status-infrastructure.yaml", `apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  name: cluster
+status:
+  infrastructureName: my-cluster-abcde
+`)
+	cloudConfigFile := writeFile("cloud.conf", `[Global]
+DisableSecurityGroupIngress = true
+`)
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		errMsg   string
+	}{
+		{
+			name: "AWS with a cloud config",
+			args: []string{"--platform", "AWS", "--infrastructure", awsInfraFile, "--cloud-config", cloudConfigFile},
+			expected: `[Global]
+Region                                          = us-east-1
+KubernetesClusterID                             = my-cluster-abcde
+DisableSecurityGroupIngress                     = true
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+		},
+		{
+			name: "Platform set on an infrastructure without platform status",
+			args: []string{"--platform", "AWS", "--infrastructure", noStatusInfraFile},
+			expected: `[Global]
+KubernetesClusterID                             = my-cluster-abcde
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+		},
+		{
+			name:   "Platform mismatch",
+			args:   []string{"--platform", "GCP", "--infrastructure", awsInfraFile},
+			errMsg: `platform "GCP" does not match the platform "AWS" of the infrastructure`,
+		},
+		{
+			name:   "Missing infrastructure",
+			args:   []string{"--platform", "AWS"},
+			errMsg: `required flag(s) "infrastructure" not set`,
+		},
+		{
+			name:   "Missing cloud config file",
+			args:   []string{"--platform", "AWS", "--infrastructure", awsInfraFile, "--cloud-config", filepath.Join(tmpDir, "missing.conf")},
+			errMsg: "couldn't read cloud config from file: open " + filepath.Join(tmpDir, "missing.conf") + ": no such file or directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTransformConfigCommand()
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tc.args)
+
+			err := cmd.Execute()
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
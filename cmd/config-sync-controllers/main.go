@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -37,6 +38,7 @@ import (
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
@@ -85,6 +87,78 @@ func main() {
 		"The namespace for managed objects, target cloud-conf in particular.",
 	)
 
+	cloudConfigNamespace := flag.String(
+		"cloud-config-namespace",
+		controllers.OpenshiftConfigNamespace,
+		"The namespace of the user cloud config ConfigMap to sync from.",
+	)
+
+	cloudConfigName := flag.String(
+		"cloud-config-name",
+		"",
+		"The name of the user cloud config ConfigMap to sync from. Defaults to the one referenced by the cluster Infrastructure resource.",
+	)
+
+	additionalCABundles := flag.String(
+		"additional-ca-bundle-configmaps",
+		"",
+		"Comma separated list of ConfigMaps, as namespace/name or name in openshift-config, whose ca-bundle.crt key is merged into the trusted CA bundle.",
+	)
+
+	caBundleSecrets := flag.String(
+		"ca-bundle-secrets",
+		"",
+		"Comma separated list of Secrets, as namespace/name or name in openshift-config, whose ca-bundle.crt key is merged into the trusted CA bundle.",
+	)
+
+	mergeImageRegistryCA := flag.Bool(
+		"merge-image-registry-ca",
+		false,
+		"Merge the ConfigMap referenced by the additionalTrustedCA of the cluster image config into the trusted CA bundle, e.g. when the cloud APIs are served behind the same internal CA as the mirror registry.",
+	)
+
+	disabledCABundleSources := flag.String(
+		"disable-ca-bundle-sources",
+		"",
+		"Comma separated list of the sources to leave out of the merged trusted CA bundle, among system, proxy and cloud-config, e.g. to debug trust issues.",
+	)
+
+	publishTrustedCASecret := flag.Bool(
+		"publish-trusted-ca-secret",
+		false,
+		"Mirror the merged trusted CA bundle into a Secret in the managed namespace, for components which can only mount Secrets.",
+	)
+
+	trustedCABundleTargetNamespaces := flag.String(
+		"trusted-ca-bundle-target-namespaces",
+		"",
+		"Comma separated list of namespaces, besides the managed one, the merged trusted CA bundle is synced to, e.g. a hosted control plane namespace.",
+	)
+
+	systemTrustBundlePath := flag.String(
+		"system-ca-bundle-path",
+		controllers.GetSystemTrustBundlePath(),
+		"Path of the system trust bundle the trusted CA bundle sources are merged with. Defaults to the SYSTEM_TRUST_BUNDLE_PATH environment variable if set.",
+	)
+
+	certificateExpiryWarningWindow := flag.Duration(
+		"certificate-expiry-warning-window",
+		controllers.DefaultCertificateExpiryWarningWindow,
+		"How long before a certificate of the merged trusted CA bundle expires a warning event is recorded. Zero disables the warning.",
+	)
+
+	excludedCertificates := pflag.StringArray(
+		"exclude-ca-certificate",
+		nil,
+		"SHA-256 fingerprint or RFC 2253 subject of a certificate to drop from the merged trusted CA bundle, e.g. a compromised root. May be repeated.",
+	)
+
+	metricsAddr := flag.String(
+		"metrics-bind-address",
+		"127.0.0.1:9261",
+		"Address for hosting metrics, e.g. the trusted CA bundle expiry",
+	)
+
 	recorderName := "cloud-controller-manager-operator-cloud-config-sync-controller"
 	missingVersion := "0.0.1-snapshot"
 	desiredVersion := controllers.GetReleaseVersion()
@@ -107,6 +181,31 @@ func main() {
 		LeaseDuration: leaderElectionConfig.LeaseDuration,
 	})
 
+	additionalCABundleSources, err := controllers.ParseCABundleSources(*additionalCABundles)
+	if err != nil {
+		setupLog.Error(err, "invalid additional CA bundle ConfigMaps")
+		os.Exit(1)
+	}
+
+	caBundleSecretSources, err := controllers.ParseCABundleSources(*caBundleSecrets)
+	if err != nil {
+		setupLog.Error(err, "invalid CA bundle Secrets")
+		os.Exit(1)
+	}
+
+	disabledCABundleSourceNames, err := controllers.ParseDisabledCABundleSources(*disabledCABundleSources)
+	if err != nil {
+		setupLog.Error(err, "invalid disabled CA bundle sources")
+		os.Exit(1)
+	}
+
+	var targetNamespaces []string
+	for _, namespace := range strings.Split(*trustedCABundleTargetNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			targetNamespaces = append(targetNamespaces, namespace)
+		}
+	}
+
 	syncPeriod := 10 * time.Minute
 
 	cacheOptions := cache.Options{
@@ -116,11 +215,21 @@ func main() {
 			controllers.OpenshiftConfigNamespace:        {},
 			controllers.OpenshiftManagedConfigNamespace: {}},
 	}
+	cacheOptions.DefaultNamespaces[*cloudConfigNamespace] = cache.Config{}
+	for _, source := range additionalCABundleSources {
+		cacheOptions.DefaultNamespaces[source.Namespace] = cache.Config{}
+	}
+	for _, source := range caBundleSecretSources {
+		cacheOptions.DefaultNamespaces[source.Namespace] = cache.Config{}
+	}
+	for _, namespace := range targetNamespaces {
+		cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+	}
 
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
-		Metrics: metricsserver.Options{ // we do not expose any metric at this point
-			BindAddress: "0",
+		Metrics: metricsserver.Options{
+			BindAddress: *metricsAddr,
 		},
 		HealthProbeBindAddress: *healthAddr,
 		MapperProvider: restmapper.NewPartialRestMapperProvider(
@@ -183,6 +292,10 @@ func main() {
 		},
 		Scheme:            mgr.GetScheme(),
 		FeatureGateAccess: featureGateAccessor,
+		SourceConfigMap: client.ObjectKey{
+			Namespace: *cloudConfigNamespace,
+			Name:      *cloudConfigName,
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create cloud-config sync controller", "controller", "ClusterOperator")
 		os.Exit(1)
@@ -196,7 +309,16 @@ func main() {
 			ReleaseVersion:   controllers.GetReleaseVersion(),
 			ManagedNamespace: *managedNamespace,
 		},
-		Scheme: mgr.GetScheme(),
+		Scheme:                         mgr.GetScheme(),
+		AdditionalCABundleSources:      additionalCABundleSources,
+		CABundleSecretSources:          caBundleSecretSources,
+		CertificateExpiryWarningWindow: *certificateExpiryWarningWindow,
+		DisabledCABundleSources:        disabledCABundleSourceNames,
+		ExcludedCertificates:           *excludedCertificates,
+		TargetNamespaces:               targetNamespaces,
+		TrustBundlePath:                *systemTrustBundlePath,
+		PublishTrustedCASecret:         *publishTrustedCASecret,
+		MergeImageRegistryCA:           *mergeImageRegistryCA,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create Trusted CA sync controller", "controller", "ClusterOperator")
 		os.Exit(1)
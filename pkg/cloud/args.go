@@ -0,0 +1,51 @@
+package cloud
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+// argValueRegexp restricts the values of admin-provided flags, as they are expanded by the shell
+// in the cloud-controller-manager container command.
+var argValueRegexp = regexp.MustCompile(`^[A-Za-z0-9._:/,=-]+$`)
+
+// commonAllowedArgs lists the cloud-controller-manager flags admins are allowed to set on every platform.
+// Flags which the operator relies on, such as the leader election or cloud config ones, are intentionally left out.
+var commonAllowedArgs = []string{
+	"concurrent-service-syncs",
+	"node-monitor-period",
+	"node-status-update-frequency",
+	"route-reconciliation-period",
+	"v",
+}
+
+// validateCloudControllerManagerArgs returns an error if any of the admin-provided flags is not allowed
+// on the platform, see common.CloudProvider AllowedArgs, or has a value which is not safe to pass to the cloud-controller-manager command.
+func validateCloudControllerManagerArgs(operatorConfig config.OperatorConfig) error {
+	if len(operatorConfig.CloudControllerManagerArgs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(operatorConfig.CloudControllerManagerArgs))
+	for name := range operatorConfig.CloudControllerManagerArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	platform := operatorConfig.PlatformStatus.Type
+	provider, _ := common.LookupCloudProvider(operatorConfig.PlatformStatus, operatorConfig.ExternalPlatformName)
+	for _, name := range names {
+		if !slices.Contains(commonAllowedArgs, name) && !slices.Contains(provider.AllowedArgs, name) {
+			return fmt.Errorf("cloud-controller-manager argument %q is not allowed on %s platform", name, platform)
+		}
+		if value := operatorConfig.CloudControllerManagerArgs[name]; !argValueRegexp.MatchString(value) {
+			return fmt.Errorf("invalid value %q for cloud-controller-manager argument %q, must match %s", value, name, argValueRegexp)
+		}
+	}
+	return nil
+}
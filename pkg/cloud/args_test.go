@@ -0,0 +1,143 @@
+package cloud
+
+import (
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestValidateCloudControllerManagerArgs(t *testing.T) {
+	tc := []struct {
+		name     string
+		platform configv1.PlatformType
+		args     map[string]string
+		errMsg   string
+	}{{
+		name:     "No arguments",
+		platform: configv1.AWSPlatformType,
+	}, {
+		name:     "Common argument",
+		platform: configv1.AWSPlatformType,
+		args:     map[string]string{"concurrent-service-syncs": "20"},
+	}, {
+		name:     "Platform argument",
+		platform: configv1.GCPPlatformType,
+		args:     map[string]string{"cloud-provider-gce-lb-src-cidrs": "130.211.0.0/22,35.191.0.0/16"},
+	}, {
+		name:     "Argument of another platform",
+		platform: configv1.AWSPlatformType,
+		args:     map[string]string{"cloud-provider-gce-lb-src-cidrs": "130.211.0.0/22"},
+		errMsg:   `cloud-controller-manager argument "cloud-provider-gce-lb-src-cidrs" is not allowed on AWS platform`,
+	}, {
+		name:     "Argument managed by the operator",
+		platform: configv1.GCPPlatformType,
+		args:     map[string]string{"leader-elect": "false"},
+		errMsg:   `cloud-controller-manager argument "leader-elect" is not allowed on GCP platform`,
+	}, {
+		name:     "Unsafe value",
+		platform: configv1.GCPPlatformType,
+		args:     map[string]string{"v": "2; rm -rf /"},
+		errMsg:   `invalid value "2; rm -rf /" for cloud-controller-manager argument "v", must match ^[A-Za-z0-9._:/,=-]+$`,
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCloudControllerManagerArgs(config.OperatorConfig{
+				PlatformStatus:             &configv1.PlatformStatus{Type: tc.platform},
+				CloudControllerManagerArgs: tc.args,
+			})
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetResourcesWithCloudControllerManagerArgs(t *testing.T) {
+	for platformName, platform := range getPlatforms() {
+		t.Run(platformName, func(t *testing.T) {
+			operatorConfig := platform.getOperatorConfig()
+			operatorConfig.CloudControllerManagerArgs = map[string]string{"concurrent-service-syncs": "20"}
+
+			resources, err := GetResources(operatorConfig)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				deployment, ok := resource.(*appsv1.Deployment)
+				if !ok {
+					continue
+				}
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					if container.Name != "cloud-controller-manager" {
+						continue
+					}
+					script := container.Command[len(container.Command)-1]
+					assert.Equal(t, 1, strings.Count(script, "--concurrent-service-syncs=20"), script)
+					assert.NotContains(t, script, "--concurrent-service-syncs=10")
+				}
+			}
+		})
+	}
+}
+
+func TestGetResourcesWithFeatureGates(t *testing.T) {
+	for platformName, platform := range getPlatforms() {
+		t.Run(platformName, func(t *testing.T) {
+			operatorConfig := platform.getOperatorConfig()
+			operatorConfig.FeatureGates = "CloudControllerManagerWebhook=true"
+
+			resources, err := GetResources(operatorConfig)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				deployment, ok := resource.(*appsv1.Deployment)
+				if !ok {
+					continue
+				}
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					if container.Name != "cloud-controller-manager" {
+						continue
+					}
+					script := container.Command[len(container.Command)-1]
+					assert.Equal(t, 1, strings.Count(script, "--feature-gates="), script)
+					assert.Contains(t, script, "--feature-gates=CloudControllerManagerWebhook=true")
+				}
+			}
+		})
+	}
+}
+
+func TestGetResourcesWithOperandLogLevel(t *testing.T) {
+	for platformName, platform := range getPlatforms() {
+		t.Run(platformName, func(t *testing.T) {
+			operatorConfig := platform.getOperatorConfig()
+			operatorConfig.OperandLogLevel = ptr.To(6)
+
+			resources, err := GetResources(operatorConfig)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				deployment, ok := resource.(*appsv1.Deployment)
+				if !ok {
+					continue
+				}
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					if container.Name != "cloud-controller-manager" {
+						continue
+					}
+					script := container.Command[len(container.Command)-1]
+					assert.Equal(t, 1, strings.Count(script, "--v="), script)
+					assert.Contains(t, script, "--v=6")
+				}
+			}
+		})
+	}
+}
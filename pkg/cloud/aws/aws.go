@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,6 +16,9 @@ import (
 
 const providerName = "aws"
 
+// supportedArchitectures lists the node architectures operands can be scheduled on, clusters may mix them.
+var supportedArchitectures = []string{"amd64", "arm64"}
+
 var (
 	//go:embed assets/*
 	assetsFs embed.FS
@@ -26,13 +30,25 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                     providerName,
+		PlatformType:             configv1.AWSPlatformType,
+		NewAssets:                NewProviderAssets,
+		CloudConfigTransformer:   CloudConfigTransformer,
+		CloudConfigSyncedFromCCO: true,
+		CloudConfigValidator:     ValidateCloudConfig,
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
 
 var templateValuesValidationMap = map[string]interface{}{
-	"images":            "required",
-	"cloudproviderName": "required,type(string)",
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
 }
 
 type awsAssets struct {
@@ -44,10 +60,16 @@ func (a *awsAssets) GetRenderedResources() []client.Object {
 	return a.renderedResources
 }
 
+// GetSupportedArchitectures returns the architectures the AWS cloud provider images are built for.
+func (a *awsAssets) GetSupportedArchitectures() []string {
+	return supportedArchitectures
+}
+
 func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
 	values := common.TemplateValues{
-		"images":            images,
-		"cloudproviderName": operatorConfig.GetPlatformNameString(),
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
 	}
 	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
 	if err != nil {
@@ -73,7 +95,7 @@ func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets
 	}
 	templateValues, err := getTemplateValues(images, config)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
 	}
 	assets.renderedResources, err = common.RenderTemplates(objTemplates, templateValues)
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 
@@ -23,6 +25,8 @@ const defaultConfig = `[Global]
 
 // CloudConfigTransformer is used to inject OpenShift configuration defaults into the Cloud Provider config
 // for the AWS Cloud Provider. If an empty source string is provided, a minimal default configuration will be created.
+// The source may use the legacy in-tree cloud provider format, options the external cloud provider does not support
+// are dropped. It returns an error if the resulting configuration would be rejected by the external cloud provider.
 func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
 	cfg, err := readAWSConfig(source)
 	if err != nil {
@@ -30,10 +34,27 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 	}
 
 	setOpenShiftDefaults(cfg, features)
+	setClusterID(cfg, infra)
+	setRegion(cfg, infra)
+	setServiceEndpoints(cfg, infra)
+
+	if err := validateAWSConfig(cfg); err != nil {
+		return "", fmt.Errorf("invalid cloud.conf: %w", err)
+	}
 
 	return marshalAWSConfig(cfg)
 }
 
+// serviceOverride is the type of the ServiceOverride sections of the AWS cloud provider configuration.
+type serviceOverride = struct {
+	Service       string
+	Region        string
+	URL           string
+	SigningRegion string
+	SigningMethod string
+	SigningName   string
+}
+
 // readAWSConfig will parse a source string into a proper *awsconfig.CloudConfig.
 // If an empty source string is provided, a default configuration will be used.
 func readAWSConfig(source string) (*awsconfig.CloudConfig, error) {
@@ -47,13 +68,62 @@ func readAWSConfig(source string) (*awsconfig.CloudConfig, error) {
 	}
 
 	// Use the same method the AWS CCM uses to load configuration.
-	if err := gcfg.FatalOnly(gcfg.ReadStringInto(cfg, source)); err != nil {
-		return nil, fmt.Errorf("failed to parse INI file: %w", err)
+	if err := gcfg.ReadStringInto(cfg, source); err != nil {
+		if fatalErr := gcfg.FatalOnly(err); fatalErr != nil {
+			return nil, fmt.Errorf("failed to parse INI file: %w", fatalErr)
+		}
+		// Only unknown sections and options are reported as non fatal errors, e.g. options of the
+		// legacy in-tree cloud provider such as DisableStrictZoneCheck. They are not written back.
+		klog.Warningf("Dropping options not supported by the external AWS cloud provider: %v", err)
 	}
 
 	return cfg, nil
 }
 
+// ValidateCloudConfig checks the synced cloud config strictly, options unknown to the external AWS cloud provider,
+// e.g. set through overrides, and values of the wrong type are reported along with the checks of validateAWSConfig.
+func ValidateCloudConfig(source string) error {
+	cfg := &awsconfig.CloudConfig{}
+	if err := gcfg.ReadStringInto(cfg, source); err != nil {
+		return fmt.Errorf("failed to parse INI file: %w", err)
+	}
+	return validateAWSConfig(cfg)
+}
+
+// validateAWSConfig runs the checks the external AWS cloud provider runs when loading its configuration,
+// so an invalid configuration is reported on sync instead of crashlooping the cloud controller manager.
+func validateAWSConfig(cfg *awsconfig.CloudConfig) error {
+	if err := cfg.ValidateOverrides(); err != nil {
+		return err
+	}
+	if _, err := cfg.IsNLBSecurityGroupModeManaged(); err != nil {
+		return err
+	}
+
+	switch cfg.Global.ClusterServiceLoadBalancerHealthProbeMode {
+	case "", awsconfig.ClusterServiceLoadBalancerHealthProbeModeShared, awsconfig.ClusterServiceLoadBalancerHealthProbeModeServiceNodePort:
+	default:
+		return fmt.Errorf("invalid ClusterServiceLoadBalancerHealthProbeMode %q, expected %q or %q",
+			cfg.Global.ClusterServiceLoadBalancerHealthProbeMode,
+			awsconfig.ClusterServiceLoadBalancerHealthProbeModeShared,
+			awsconfig.ClusterServiceLoadBalancerHealthProbeModeServiceNodePort)
+	}
+
+	if cfg.Global.KubernetesClusterTag != "" && cfg.Global.KubernetesClusterID != "" &&
+		cfg.Global.KubernetesClusterTag != cfg.Global.KubernetesClusterID {
+		return fmt.Errorf("KubernetesClusterTag %q and KubernetesClusterID %q are mutually exclusive",
+			cfg.Global.KubernetesClusterTag, cfg.Global.KubernetesClusterID)
+	}
+
+	for _, family := range cfg.Global.NodeIPFamilies {
+		if family != "ipv4" && family != "ipv6" {
+			return fmt.Errorf("invalid NodeIPFamilies entry %q, expected \"ipv4\" or \"ipv6\"", family)
+		}
+	}
+
+	return nil
+}
+
 func marshalAWSConfig(cfg *awsconfig.CloudConfig) (string, error) {
 	file := ini.Empty()
 	if err := file.Section("Global").ReflectFrom(&cfg.Global); err != nil {
@@ -139,3 +209,89 @@ func setOpenShiftDefaults(cfg *awsconfig.CloudConfig, features featuregates.Feat
 		}
 	}
 }
+
+// setClusterID sets the cluster id from the infrastructure name, so the cloud provider expects the
+// "kubernetes.io/cluster/<infrastructure name>" tag set by the installer on the cluster resources, and tags the
+// load balancers it creates the same way. An id already set in the config is kept.
+func setClusterID(cfg *awsconfig.CloudConfig, infra *configv1.Infrastructure) {
+	if infra == nil || infra.Status.InfrastructureName == "" {
+		return
+	}
+	if cfg.Global.KubernetesClusterID != "" || cfg.Global.KubernetesClusterTag != "" {
+		return
+	}
+	cfg.Global.KubernetesClusterID = infra.Status.InfrastructureName
+}
+
+// setRegion backfills the region from the infrastructure when the source config does not set it, so the cloud
+// provider does not depend on the instance metadata service to discover it. A region set in the source is kept.
+func setRegion(cfg *awsconfig.CloudConfig, infra *configv1.Infrastructure) {
+	if infra == nil || infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.AWS == nil {
+		return
+	}
+	if cfg.Global.Region != "" {
+		return
+	}
+	cfg.Global.Region = infra.Status.PlatformStatus.AWS.Region
+}
+
+// setServiceEndpoints translates the custom service endpoints from the infrastructure into service overrides,
+// so the cloud provider talks to the same endpoints as the rest of the cluster, e.g. on GovCloud, C2S or clusters
+// using private endpoints. Endpoints from the infrastructure take precedence over existing overrides of the same
+// service in the cluster region, other overrides are kept as they are.
+func setServiceEndpoints(cfg *awsconfig.CloudConfig, infra *configv1.Infrastructure) {
+	if infra == nil || infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.AWS == nil {
+		return
+	}
+	platformStatus := infra.Status.PlatformStatus.AWS
+	if len(platformStatus.ServiceEndpoints) == 0 {
+		return
+	}
+	if platformStatus.Region == "" {
+		klog.Warningf("Ignoring custom service endpoints, the region is not set in the infrastructure")
+		return
+	}
+
+	if cfg.ServiceOverride == nil {
+		cfg.ServiceOverride = map[string]*serviceOverride{}
+	}
+	for _, endpoint := range platformStatus.ServiceEndpoints {
+		service := strings.ToLower(strings.TrimSpace(endpoint.Name))
+		if service == "" || endpoint.URL == "" {
+			continue
+		}
+
+		override := findServiceOverride(cfg, service, platformStatus.Region)
+		if override == nil {
+			override = &serviceOverride{
+				Service: service,
+				Region:  platformStatus.Region,
+			}
+			cfg.ServiceOverride[nextServiceOverrideID(cfg)] = override
+		}
+		override.URL = endpoint.URL
+		if override.SigningRegion == "" {
+			override.SigningRegion = platformStatus.Region
+		}
+	}
+}
+
+func findServiceOverride(cfg *awsconfig.CloudConfig, service, region string) *serviceOverride {
+	for _, override := range cfg.ServiceOverride {
+		if strings.EqualFold(strings.TrimSpace(override.Service), service) && strings.TrimSpace(override.Region) == region {
+			return override
+		}
+	}
+	return nil
+}
+
+// nextServiceOverrideID returns the identifier following the highest numeric identifier of the existing overrides.
+func nextServiceOverrideID(cfg *awsconfig.CloudConfig) string {
+	highest := 0
+	for id := range cfg.ServiceOverride {
+		if n, err := strconv.Atoi(id); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return strconv.Itoa(highest + 1)
+}
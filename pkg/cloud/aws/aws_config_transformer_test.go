@@ -78,6 +78,7 @@ func TestCloudConfigTransformer(t *testing.T) {
 	testCases := []struct {
 		name     string
 		source   string
+		infra    *configv1.Infrastructure
 		expected string
 		features featuregates.FeatureGate
 	}{
@@ -154,6 +155,106 @@ SigningRegion = signing_region
 `, // Ordered based on the order of fields in the AWS CloudConfig struct.
 			features: mockEmptyFeatureGates,
 		},
+		{
+			name:   "with custom service endpoints in the infrastructure",
+			source: "",
+			infra: makeInfrastructureWithServiceEndpoints("us-gov-west-1", []configv1.AWSServiceEndpoint{
+				{Name: "ec2", URL: "https://ec2.us-gov-west-1.amazonaws.com"},
+				{Name: "ELASTICLOADBALANCING", URL: "https://elasticloadbalancing.us-gov-west-1.amazonaws.com"},
+			}),
+			expected: `[Global]
+Region                                          = us-gov-west-1
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+
+[ServiceOverride "1"]
+Service       = ec2
+Region        = us-gov-west-1
+URL           = https://ec2.us-gov-west-1.amazonaws.com
+SigningRegion = us-gov-west-1
+
+[ServiceOverride "2"]
+Service       = elasticloadbalancing
+Region        = us-gov-west-1
+URL           = https://elasticloadbalancing.us-gov-west-1.amazonaws.com
+SigningRegion = us-gov-west-1
+`,
+			features: mockEmptyFeatureGates,
+		},
+		{
+			name: "with custom service endpoints in the infrastructure and existing overrides",
+			source: `[Global]
+
+[ServiceOverride "1"]
+Service         = ec2
+Region          = us-west-2
+URL             = https://ec2.foo.bar
+SigningRegion   = signing_region
+
+[ServiceOverride "2"]
+Service         = s3
+Region          = us-west-1
+URL             = https://s3.foo.bar
+SigningRegion   = us-west-1
+`,
+			infra: makeInfrastructureWithServiceEndpoints("us-west-2", []configv1.AWSServiceEndpoint{
+				{Name: "ec2", URL: "https://vpce-ec2.example.com"},
+				{Name: "sts", URL: "https://vpce-sts.example.com"},
+			}),
+			expected: `[Global]
+Region                                          = us-west-2
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+
+[ServiceOverride "1"]
+Service       = ec2
+Region        = us-west-2
+URL           = https://vpce-ec2.example.com
+SigningRegion = signing_region
+
+[ServiceOverride "2"]
+Service       = s3
+Region        = us-west-1
+URL           = https://s3.foo.bar
+SigningRegion = us-west-1
+
+[ServiceOverride "3"]
+Service       = sts
+Region        = us-west-2
+URL           = https://vpce-sts.example.com
+SigningRegion = us-west-2
+`,
+			features: mockEmptyFeatureGates,
+		},
+		{
+			name: "with region in the infrastructure and the source",
+			source: `[Global]
+Region = us-east-1
+`,
+			infra: makeInfrastructureWithServiceEndpoints("us-west-2", nil),
+			expected: `[Global]
+Region                                          = us-east-1
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			features: mockEmptyFeatureGates,
+		},
+		{
+			name:   "with custom service endpoints in the infrastructure but no region",
+			source: "",
+			infra: makeInfrastructureWithServiceEndpoints("", []configv1.AWSServiceEndpoint{
+				{Name: "ec2", URL: "https://ec2.example.com"},
+			}),
+			expected: `[Global]
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			features: mockEmptyFeatureGates,
+		},
 		{
 			name: "with AWSServiceLBNetworkSecurityGroup feature gate enabled",
 			source: `[Global]
@@ -183,16 +284,207 @@ ClusterServiceSharedLoadBalancerHealthProbePort = 0
 `,
 			features: mockDisabledFeatureGates,
 		},
+		{
+			name:   "with infrastructure name",
+			source: "",
+			infra:  makeInfrastructureWithName("my-cluster-abcde"),
+			expected: `[Global]
+KubernetesClusterID                             = my-cluster-abcde
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			features: mockEmptyFeatureGates,
+		},
+		{
+			name: "with infrastructure name and existing cluster id",
+			source: `[Global]
+KubernetesClusterID = custom-id
+`,
+			infra: makeInfrastructureWithName("my-cluster-abcde"),
+			expected: `[Global]
+KubernetesClusterID                             = custom-id
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			features: mockEmptyFeatureGates,
+		},
+		{
+			name: "with infrastructure name and legacy cluster tag",
+			source: `[Global]
+KubernetesClusterTag = legacy-id
+`,
+			infra: makeInfrastructureWithName("my-cluster-abcde"),
+			expected: `[Global]
+KubernetesClusterTag                            = legacy-id
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			features: mockEmptyFeatureGates,
+		},
+		{
+			name: "with legacy in-tree configuration",
+			source: `[Global]
+Zone                   = Foo
+DisableStrictZoneCheck = true
+NodeIPFamilies         = ipv4
+`,
+			expected: `[Global]
+Zone                                            = Foo
+DisableSecurityGroupIngress                     = false
+NodeIPFamilies                                  = ipv4
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`, // Options unknown to the external cloud provider are dropped.
+			features: mockEmptyFeatureGates,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			gotConfig, err := CloudConfigTransformer(tc.source, nil, nil, tc.features) // No Infra or Network are required for the current functionality.
+			gotConfig, err := CloudConfigTransformer(tc.source, tc.infra, nil, tc.features) // No Network is required for the current functionality.
 			g.Expect(err).ToNot(HaveOccurred())
 
 			g.Expect(gotConfig).To(Equal(tc.expected))
 		})
 	}
 }
+
+func TestCloudConfigTransformerValidation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		errMsg string
+	}{
+		{
+			name: "invalid INI",
+			source: `[Global
+`,
+			errMsg: "failed to read the cloud.conf: failed to parse INI file: ",
+		},
+		{
+			name: "incomplete service override",
+			source: `[Global]
+
+[ServiceOverride "1"]
+Service = ec2
+Region  = us-west-2
+`,
+			errMsg: `invalid cloud.conf: url is missing [URL is ""] in override 1`,
+		},
+		{
+			name: "invalid health probe mode",
+			source: `[Global]
+ClusterServiceLoadBalancerHealthProbeMode = Local
+`,
+			errMsg: `invalid cloud.conf: invalid ClusterServiceLoadBalancerHealthProbeMode "Local", expected "Shared" or "ServiceNodePort"`,
+		},
+		{
+			name: "invalid NLB security group mode",
+			source: `[Global]
+NLBSecurityGroupMode = Unmanaged
+`,
+			errMsg: `invalid cloud.conf: invalid NLB security group mode: "Unmanaged". Expected: "Managed"`,
+		},
+		{
+			name: "invalid node IP family",
+			source: `[Global]
+NodeIPFamilies = ipv5
+`,
+			errMsg: `invalid cloud.conf: invalid NodeIPFamilies entry "ipv5", expected "ipv4" or "ipv6"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := CloudConfigTransformer(tc.source, nil, nil, mockEmptyFeatureGates)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(err.Error()).To(HavePrefix(tc.errMsg))
+		})
+	}
+}
+
+func makeInfrastructureWithServiceEndpoints(region string, endpoints []configv1.AWSServiceEndpoint) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS: &configv1.AWSPlatformStatus{
+					Region:           region,
+					ServiceEndpoints: endpoints,
+				},
+			},
+		},
+	}
+}
+
+func makeInfrastructureWithName(infrastructureName string) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			InfrastructureName: infrastructureName,
+			PlatformStatus: &configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS:  &configv1.AWSPlatformStatus{},
+			},
+		},
+	}
+}
+
+func TestValidateCloudConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		errMsg string
+	}{
+		{
+			name: "valid config",
+			source: `[Global]
+KubernetesClusterID                       = my-cluster
+ClusterServiceLoadBalancerHealthProbeMode = Shared
+`,
+		},
+		{
+			name: "unknown option",
+			source: `[Global]
+DisableStrictZoneCheck = true
+`,
+			errMsg: `failed to parse INI file: warning:
+can't store data at section "Global", variable "DisableStrictZoneCheck"`,
+		},
+		{
+			name: "invalid value type",
+			source: `[Global]
+DisableSecurityGroupIngress = maybe
+`,
+			errMsg: "failed to parse INI file: ",
+		},
+		{
+			name: "mutually exclusive cluster ids",
+			source: `[Global]
+KubernetesClusterTag = legacy-id
+KubernetesClusterID  = my-cluster
+`,
+			errMsg: `KubernetesClusterTag "legacy-id" and KubernetesClusterID "my-cluster" are mutually exclusive`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateCloudConfig(tc.source)
+			if tc.errMsg == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(err.Error()).To(HavePrefix(tc.errMsg))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 
 	configv1 "github.com/openshift/api/config/v1"
 
@@ -22,13 +23,23 @@ func TestResourcesRenderingSmoke(t *testing.T) {
 			config:     config.OperatorConfig{},
 			initErrMsg: "aws: missed images in config: CloudControllerManager: non zero value required",
 		}, {
-			name: "Minimal allowed config",
+			name: "No infra name",
 			config: config.OperatorConfig{
 				ImagesReference: config.ImagesReference{
 					CloudControllerManagerAWS: "CloudControllerManagerAws",
 				},
 				PlatformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
 			},
+			initErrMsg: "can not construct template values for aws assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerAWS: "CloudControllerManagerAws",
+				},
+				PlatformStatus:     &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+				InfrastructureName: "my-cool-cluster-777",
+			},
 		},
 	}
 
@@ -44,6 +55,11 @@ func TestResourcesRenderingSmoke(t *testing.T) {
 
 			resources := assets.GetRenderedResources()
 			assert.Len(t, resources, 3)
+
+			deployment, ok := resources[0].(*appsv1.Deployment)
+			assert.True(t, ok)
+			assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command[len(deployment.Spec.Template.Spec.Containers[0].Command)-1],
+				"--cluster-name="+tc.config.InfrastructureName)
 		})
 	}
 }
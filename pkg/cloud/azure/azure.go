@@ -25,6 +25,18 @@ import (
 
 const providerName = "azure"
 
+const (
+	// CredentialsSecretName is the name of the secret the cloud-credential-operator mints for the cloud controller manager.
+	CredentialsSecretName = "azure-cloud-credentials"
+
+	clientIDSecretKey           = "azure_client_id"
+	tenantIDSecretKey           = "azure_tenant_id"
+	federatedTokenFileSecretKey = "azure_federated_token_file"
+)
+
+// supportedArchitectures lists the node architectures operands can be scheduled on, clusters may mix them.
+var supportedArchitectures = []string{"amd64", "arm64"}
+
 var (
 	//go:embed assets/*
 	assetsFs  embed.FS
@@ -40,6 +52,11 @@ var (
 		{ReferenceObject: &admissionregistrationv1.ValidatingAdmissionPolicyBinding{}, EmbedFsPath: "assets/validating-admission-service-annotation-policy-binding.yaml"},
 		{ReferenceObject: &admissionregistrationv1.ValidatingAdmissionPolicy{}, EmbedFsPath: "assets/validating-admission-service-annotation-policy.yaml"},
 	}
+
+	// windowsTemplates are only rendered when the Windows cloud-node-manager image is provided.
+	windowsTemplates = []common.TemplateSource{
+		{ReferenceObject: &appsv1.DaemonSet{}, EmbedFsPath: "assets/cloud-node-manager-windows-daemonset.yaml"},
+	}
 )
 
 var (
@@ -61,10 +78,25 @@ var (
 	}()
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                     providerName,
+		PlatformType:             configv1.AzurePlatformType,
+		NewAssets:                NewProviderAssets,
+		CloudConfigTransformer:   CloudConfigTransformer,
+		CloudConfigSyncedFromCCO: true,
+		CloudConfigValidator:     ValidateCloudConfig,
+		CloudConfigLinter:        LintCloudConfig,
+		CloudConfigSensitiveKeys: []string{"aadClientSecret", "aadClientCertPassword"},
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager         string `valid:"required"`
 	CloudControllerManagerOperator string `valid:"required"`
 	CloudNodeManager               string `valid:"required"`
+	// CloudNodeManagerWindows is optional, Windows nodes are left to the WMCO when not set.
+	CloudNodeManagerWindows string
 }
 
 var templateValuesValidationMap = map[string]interface{}{
@@ -82,6 +114,11 @@ func (assets *azureAssets) GetRenderedResources() []client.Object {
 	return assets.renderedResources
 }
 
+// GetSupportedArchitectures returns the architectures the Azure cloud provider images are built for.
+func (assets *azureAssets) GetSupportedArchitectures() []string {
+	return supportedArchitectures
+}
+
 func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
 	values := common.TemplateValues{
 		"images":             images,
@@ -100,6 +137,7 @@ func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets
 		CloudControllerManager:         config.ImagesReference.CloudControllerManagerAzure,
 		CloudControllerManagerOperator: config.ImagesReference.CloudControllerManagerOperator,
 		CloudNodeManager:               config.ImagesReference.CloudNodeManagerAzure,
+		CloudNodeManagerWindows:        config.ImagesReference.CloudNodeManagerAzureWindows,
 	}
 	_, err := govalidator.ValidateStruct(images)
 	if err != nil {
@@ -112,6 +150,13 @@ func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets
 	if err != nil {
 		return nil, err
 	}
+	if images.CloudNodeManagerWindows != "" {
+		windowsObjTemplates, err := common.ReadTemplates(assetsFs, windowsTemplates)
+		if err != nil {
+			return nil, err
+		}
+		objTemplates = append(objTemplates, windowsObjTemplates...)
+	}
 	templateValues, err := getTemplateValues(images, config)
 	if err != nil {
 		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
@@ -130,7 +175,7 @@ func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets
 func IsAzure(infra *configv1.Infrastructure) bool {
 	if infra.Status.PlatformStatus != nil {
 		if infra.Status.PlatformStatus.Type == configv1.AzurePlatformType &&
-			(infra.Status.PlatformStatus.Azure.CloudName != configv1.AzureStackCloud) {
+			(infra.Status.PlatformStatus.Azure == nil || infra.Status.PlatformStatus.Azure.CloudName != configv1.AzureStackCloud) {
 			return true
 		}
 	}
@@ -142,6 +187,14 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 		return "", fmt.Errorf("invalid platform, expected CloudName to be %s", configv1.AzurePublicCloud)
 	}
 
+	if isINIConfig(source) {
+		converted, err := convertINIConfig(source)
+		if err != nil {
+			return "", err
+		}
+		source = converted
+	}
+
 	var cfg azureconfig.Config
 	if err := json.Unmarshal([]byte(source), &cfg); err != nil {
 		return "", fmt.Errorf("failed to unmarshal the cloud.conf: %w", err)
@@ -151,29 +204,17 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 	// 1. Ensure that the Cloud is set in the cloud.conf
 	//   i. If it is set, verify that it is valid and does not conflict with the
 	//      infrastructure config. If it conflicts, we want to error
-	//  ii. If it is not set, default to public cloud (configv1.AzurePublicCloud)
+	//  ii. If it is not set, use the cloud from the infrastructure config, or
+	//      default to public cloud (configv1.AzurePublicCloud)
 	//
 	// 2. Verify the cloud name set in the infra config is valid, if it is not
 	// bail with an informative error
-
-	// Verify the cloud name set in the infra config is valid
-	cloud := configv1.AzurePublicCloud
-	if azurePlatform := infra.Status.PlatformStatus.Azure; azurePlatform != nil {
-		if c := azurePlatform.CloudName; c != "" {
-			if _, ok := validAzureCloudNames[c]; !ok {
-				return "", field.NotSupported(field.NewPath("status", "platformStatus", "azure", "cloudName"), c, validAzureCloudNameValues)
-			}
-			cloud = c
-		}
-	}
-
-	// Ensure cloud set in cloud.conf matches infra
-	if cfg.Cloud != "" {
-		if !strings.EqualFold(string(cloud), cfg.Cloud) {
-			return "",
-				fmt.Errorf(`invalid user-provided cloud.conf: \"cloud\" field in user-provided
-				cloud.conf conflicts with infrastructure object`)
-		}
+	//
+	// The cloud name selects the Azure Resource Manager and Active Directory endpoints
+	// the cloud provider talks to, e.g. for Azure Government (MAG) or Azure China (Mooncake).
+	cloud, err := getCloudName(infra.Status.PlatformStatus.Azure, cfg.Cloud)
+	if err != nil {
+		return "", err
 	}
 	cfg.Cloud = string(cloud)
 
@@ -187,9 +228,155 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 	// Ensure we are using the shared health probe
 	cfg.ClusterServiceLoadBalancerHealthProbeMode = azureconsts.ClusterServiceLoadBalancerHealthProbeModeShared
 
-	cfgbytes, err := json.Marshal(cfg)
+	setResourceTags(&cfg, infra.Status.PlatformStatus.Azure)
+	setResourceGroups(&cfg, infra.Status.PlatformStatus.Azure)
+
+	// Options unknown to the vendored config type, e.g. added by a newer cloud provider, are kept.
+	cfgbytes, err := common.MarshalJSONPreservingUnknownFields(source, cfg)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal the cloud.conf: %w", err)
 	}
 	return string(cfgbytes), nil
 }
+
+// ValidateCloudConfig checks the synced cloud config: it has to be JSON whose known fields have the right type,
+// set the cloud, and use supported VM types and load balancer SKUs. Managed and workload identities are mutually
+// exclusive ways to authenticate. Unknown fields are reported by LintCloudConfig, as the cloud provider may be newer
+// than the config type vendored by the operator.
+func ValidateCloudConfig(source string) error {
+	var cfg azureconfig.Config
+	if err := json.Unmarshal([]byte(source), &cfg); err != nil {
+		return fmt.Errorf("failed to unmarshal the cloud.conf: %w", err)
+	}
+
+	if cfg.Cloud == "" {
+		return fmt.Errorf("cloud is required")
+	}
+	switch strings.ToLower(cfg.VMType) {
+	case "", azureconsts.VMTypeStandard, azureconsts.VMTypeVMSS, azureconsts.VMTypeVmssFlex:
+	default:
+		return fmt.Errorf("unsupported vmType %q, expected one of %q, %q or %q", cfg.VMType,
+			azureconsts.VMTypeStandard, azureconsts.VMTypeVMSS, azureconsts.VMTypeVmssFlex)
+	}
+	switch strings.ToLower(cfg.LoadBalancerSKU) {
+	case "", azureconsts.LoadBalancerSKUBasic, azureconsts.LoadBalancerSKUStandard:
+	default:
+		return fmt.Errorf("unsupported loadBalancerSku %q, expected %q or %q", cfg.LoadBalancerSKU,
+			azureconsts.LoadBalancerSKUBasic, azureconsts.LoadBalancerSKUStandard)
+	}
+	if cfg.UseManagedIdentityExtension && cfg.UseFederatedWorkloadIdentityExtension {
+		return fmt.Errorf("useManagedIdentityExtension and useFederatedWorkloadIdentityExtension are mutually exclusive")
+	}
+	return nil
+}
+
+// LintCloudConfig reports the fields of the synced cloud config which are unknown to the config type vendored by
+// the operator. They are kept in the synced config, but are likely typos unless the cloud provider is newer.
+func LintCloudConfig(source string) []string {
+	known := common.JSONFieldNames(azureconfig.Config{})
+	var findings []string
+	for _, field := range common.UnknownJSONFields(source, azureconfig.Config{}) {
+		if closest := common.ClosestOption(field, known); closest != "" {
+			findings = append(findings, fmt.Sprintf("field %q is unknown to the cloud provider, did you mean %q?", field, closest))
+		} else {
+			findings = append(findings, fmt.Sprintf("field %q is unknown to the cloud provider, remove it unless the cloud provider version supports it", field))
+		}
+	}
+	return findings
+}
+
+// SetWorkloadIdentityOptions configures the given cloud.conf for workload identity authentication when the
+// credentials secret is in the workload identity mode, i.e. it holds the path of a federated token file
+// instead of a client secret. The cloud provider then exchanges the short-lived projected service account
+// token for Azure credentials, and managed identity or client secret authentication are turned off.
+// The cloud.conf is returned unchanged for other credentials.
+func SetWorkloadIdentityOptions(source string, credentials map[string][]byte) (string, error) {
+	federatedTokenFile := strings.TrimSpace(string(credentials[federatedTokenFileSecretKey]))
+	if federatedTokenFile == "" {
+		return source, nil
+	}
+	clientID := strings.TrimSpace(string(credentials[clientIDSecretKey]))
+	tenantID := strings.TrimSpace(string(credentials[tenantIDSecretKey]))
+	if clientID == "" || tenantID == "" {
+		return "", fmt.Errorf("%s and %s are required for workload identity authentication", clientIDSecretKey, tenantIDSecretKey)
+	}
+
+	var cfg azureconfig.Config
+	if err := json.Unmarshal([]byte(source), &cfg); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the cloud.conf: %w", err)
+	}
+
+	cfg.AADClientID = clientID
+	cfg.TenantID = tenantID
+	cfg.AADFederatedTokenFile = federatedTokenFile
+	cfg.UseFederatedWorkloadIdentityExtension = true
+	cfg.UseManagedIdentityExtension = false
+	cfg.AADClientSecret = ""
+
+	cfgbytes, err := common.MarshalJSONPreservingUnknownFields(source, cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the cloud.conf: %w", err)
+	}
+	return string(cfgbytes), nil
+}
+
+// setResourceTags adds the user-defined tags from the infrastructure to the tags the cloud provider applies
+// to the resources it creates, such as load balancers and public IPs, so they are tagged the same way as the
+// resources created by the installer. Tags from the infrastructure take precedence over the ones in the
+// user-provided cloud.conf with the same key. The tags map is used as tag values may contain `,` and `=`.
+func setResourceTags(cfg *azureconfig.Config, azurePlatform *configv1.AzurePlatformStatus) {
+	if azurePlatform == nil || len(azurePlatform.ResourceTags) == 0 {
+		return
+	}
+	if cfg.TagsMap == nil {
+		cfg.TagsMap = make(map[string]string, len(azurePlatform.ResourceTags))
+	}
+	for _, tag := range azurePlatform.ResourceTags {
+		cfg.TagsMap[tag.Key] = tag.Value
+	}
+}
+
+// setResourceGroups backfills the resource groups of the cluster and of its virtual network from the infrastructure
+// when the user-provided cloud.conf does not set them, so a minimal cloud.conf still produces a working cloud provider.
+// Resource groups set in the cloud.conf are kept.
+func setResourceGroups(cfg *azureconfig.Config, azurePlatform *configv1.AzurePlatformStatus) {
+	if azurePlatform == nil {
+		return
+	}
+	if cfg.ResourceGroup == "" {
+		cfg.ResourceGroup = azurePlatform.ResourceGroupName
+	}
+	if cfg.VnetResourceGroup == "" {
+		cfg.VnetResourceGroup = azurePlatform.NetworkResourceGroupName
+	}
+}
+
+// getCloudName returns the Azure cloud environment the cluster runs in, in its canonical form.
+// The cloud name from the infrastructure takes precedence, the one from the user-provided cloud.conf
+// is only used when the infrastructure does not report any, e.g. on clusters installed before it did.
+func getCloudName(azurePlatform *configv1.AzurePlatformStatus, sourceCloud string) (configv1.AzureCloudEnvironment, error) {
+	if azurePlatform != nil && azurePlatform.CloudName != "" {
+		cloud := azurePlatform.CloudName
+		if _, ok := validAzureCloudNames[cloud]; !ok {
+			return "", field.NotSupported(field.NewPath("status", "platformStatus", "azure", "cloudName"), cloud, validAzureCloudNameValues)
+		}
+		// Ensure cloud set in cloud.conf matches infra
+		if sourceCloud != "" && !strings.EqualFold(string(cloud), sourceCloud) {
+			return "",
+				fmt.Errorf(`invalid user-provided cloud.conf: \"cloud\" field in user-provided
+				cloud.conf conflicts with infrastructure object`)
+		}
+		return cloud, nil
+	}
+
+	if sourceCloud == "" {
+		return configv1.AzurePublicCloud, nil
+	}
+	for cloud := range validAzureCloudNames {
+		// Azure Stack Hub is handled separately, see IsAzure.
+		if cloud != configv1.AzureStackCloud && strings.EqualFold(string(cloud), sourceCloud) {
+			return cloud, nil
+		}
+	}
+	return "", fmt.Errorf("invalid user-provided cloud.conf: unsupported cloud %q", sourceCloud)
+}
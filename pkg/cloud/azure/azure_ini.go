@@ -0,0 +1,138 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	azureconfig "sigs.k8s.io/cloud-provider-azure/pkg/provider/config"
+)
+
+// configFields maps the lower cased JSON names of the Azure cloud provider config fields to their types,
+// so keys of an ini cloud.conf can be matched regardless of their case and converted to the right type.
+var configFields = collectConfigFields(reflect.TypeOf(azureconfig.Config{}), map[string]reflect.Type{})
+
+// collectConfigFields walks the given struct type and its inlined structs the way encoding/json does,
+// fields of the outer struct take precedence over the ones of inlined structs with the same name.
+func collectConfigFields(t reflect.Type, fields map[string]reflect.Type) map[string]reflect.Type {
+	var inlined []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			inlined = append(inlined, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = field.Type
+		}
+	}
+	for _, inlinedType := range inlined {
+		collectConfigFields(inlinedType, fields)
+	}
+	return fields
+}
+
+// isINIConfig returns true if the given cloud.conf is not in the JSON format the external Azure
+// cloud provider consumes, e.g. on clusters which carry an ini cloud.conf from older releases.
+func isINIConfig(source string) bool {
+	trimmed := strings.TrimSpace(source)
+	return trimmed != "" && !strings.HasPrefix(trimmed, "{")
+}
+
+// convertINIConfig converts an ini cloud.conf into the JSON format consumed by the external Azure cloud
+// provider. Keys are matched to the JSON fields of the provider config regardless of their case and of the
+// section they are set in, and their values are converted to the type of the field. When a user assigned
+// identity is set without enabling the managed identity extension, the extension is enabled, as the identity
+// would otherwise be ignored. It returns an error on unknown keys or values which can not be converted.
+func convertINIConfig(source string) (string, error) {
+	cfg, err := ini.Load([]byte(source))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the ini cloud.conf: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	for _, section := range cfg.Sections() {
+		for _, key := range section.Keys() {
+			lowerName := strings.ToLower(key.Name())
+			fieldType, ok := configFields[lowerName]
+			if !ok {
+				return "", fmt.Errorf("unsupported option %q in the ini cloud.conf", key.Name())
+			}
+			value, err := convertINIValue(key.Value(), fieldType)
+			if err != nil {
+				return "", fmt.Errorf("invalid value for option %q in the ini cloud.conf: %w", key.Name(), err)
+			}
+			// Keys are lower cased so the last one set for a field wins, encoding/json matches them regardless of their case.
+			values[lowerName] = value
+		}
+	}
+
+	if identity, ok := values["userassignedidentityid"]; ok && identity != "" {
+		if _, ok := values["usemanagedidentityextension"]; !ok {
+			values["usemanagedidentityextension"] = true
+		}
+	}
+
+	out, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert the ini cloud.conf: %w", err)
+	}
+	return string(out), nil
+}
+
+// convertINIValue converts the string value of an ini key to the given type of the provider config field.
+func convertINIValue(value string, t reflect.Type) (interface{}, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(value, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, t.Bits())
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.String {
+			break
+		}
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || t.Elem().Kind() != reflect.String {
+			break
+		}
+		items := map[string]string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			k, v, found := strings.Cut(item, "=")
+			if !found {
+				return nil, fmt.Errorf("expected comma separated key=value pairs")
+			}
+			items[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("options of type %s can only be set in a JSON cloud.conf", t)
+}
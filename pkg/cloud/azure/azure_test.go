@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 	"github.com/stretchr/testify/assert"
 
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cloud-provider-azure/pkg/azclient"
 	azureconsts "sigs.k8s.io/cloud-provider-azure/pkg/consts"
@@ -75,6 +76,19 @@ func TestResourcesRenderingSmoke(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name: "With Windows cloud node manager",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerAzure:    "CloudControllerManagerAzure",
+					CloudNodeManagerAzure:          "CloudNodeManagerAzure",
+					CloudNodeManagerAzureWindows:   "CloudNodeManagerAzureWindows",
+					CloudControllerManagerOperator: "CloudControllerManagerOperator",
+				},
+				PlatformStatus:     &configv1.PlatformStatus{Type: configv1.AzurePlatformType},
+				InfrastructureName: "infra",
+			},
 		},
 	}
 
@@ -89,7 +103,19 @@ func TestResourcesRenderingSmoke(t *testing.T) {
 			}
 
 			resources := assets.GetRenderedResources()
-			assert.Len(t, resources, 10)
+			if tc.config.ImagesReference.CloudNodeManagerAzureWindows == "" {
+				assert.Len(t, resources, 10)
+				return
+			}
+			assert.Len(t, resources, 11)
+
+			windowsNodeManager, ok := resources[len(resources)-1].(*appsv1.DaemonSet)
+			assert.True(t, ok)
+			assert.Equal(t, "azure-cloud-node-manager-windows", windowsNodeManager.Name)
+			podSpec := windowsNodeManager.Spec.Template.Spec
+			assert.Equal(t, "windows", podSpec.NodeSelector["kubernetes.io/os"])
+			assert.True(t, *podSpec.SecurityContext.WindowsOptions.HostProcess)
+			assert.Equal(t, "CloudNodeManagerAzureWindows", podSpec.Containers[0].Image)
 		})
 	}
 }
@@ -219,6 +245,40 @@ func TestCloudConfigTransformer(t *testing.T) {
 			expected: makeExpectedConfig(&azconfig.Config{}, configv1.AzurePublicCloud),
 			infra:    makeInfrastructureResource(configv1.AzurePlatformType, ""),
 		},
+		{
+			name:     "Azure keeps the US Gov cloud set in the source when there is not one set in infrastructure",
+			source:   azconfig.Config{AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AZUREUSGOVERNMENTCLOUD"}}},
+			expected: makeExpectedConfig(&azconfig.Config{}, configv1.AzureUSGovernmentCloud),
+			infra:    makeInfrastructureResource(configv1.AzurePlatformType, ""),
+		},
+		{
+			name:     "Azure keeps the China cloud set in the source when the infrastructure has no Azure status",
+			source:   azconfig.Config{AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: string(configv1.AzureChinaCloud)}}},
+			expected: makeExpectedConfig(&azconfig.Config{}, configv1.AzureChinaCloud),
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.AzurePlatformType, "")
+				infra.Status.PlatformStatus.Azure = nil
+				return infra
+			}(),
+		},
+		{
+			name:     "Azure defaults to AzurePublicCloud when neither the source nor the infrastructure set the cloud",
+			source:   azconfig.Config{},
+			expected: makeExpectedConfig(&azconfig.Config{}, configv1.AzurePublicCloud),
+			infra:    makeInfrastructureResource(configv1.AzurePlatformType, ""),
+		},
+		{
+			name:   "Azure returns an error if the source has an invalid cloud and the infrastructure has none",
+			source: azconfig.Config{AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AzureAnotherCloud"}}},
+			infra:  makeInfrastructureResource(configv1.AzurePlatformType, ""),
+			errMsg: "invalid user-provided cloud.conf: unsupported cloud \"AzureAnotherCloud\"",
+		},
+		{
+			name:   "Azure returns an error if the source is set to Azure Stack Hub",
+			source: azconfig.Config{AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: string(configv1.AzureStackCloud)}}},
+			infra:  makeInfrastructureResource(configv1.AzurePlatformType, ""),
+			errMsg: "invalid user-provided cloud.conf: unsupported cloud \"AzureStackCloud\"",
+		},
 		{
 			name:     "Azure sets the cloud to match the infrastructure if an empty string is provided in source",
 			source:   azconfig.Config{AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: ""}}},
@@ -247,6 +307,50 @@ func TestCloudConfigTransformer(t *testing.T) {
 			expected: makeExpectedConfig(&azconfig.Config{}, configv1.AzurePublicCloud),
 			infra:    makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzurePublicCloud),
 		},
+		{
+			name:   "Azure adds the resource tags from the infrastructure",
+			source: azconfig.Config{Tags: "team=ccm", TagsMap: map[string]string{"env": "dev", "owner": "me"}},
+			expected: makeExpectedConfig(&azconfig.Config{
+				Tags:    "team=ccm",
+				TagsMap: map[string]string{"env": "prod", "owner": "me", "cost-center": "a=1,b=2"},
+			}, configv1.AzurePublicCloud),
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzurePublicCloud)
+				infra.Status.PlatformStatus.Azure.ResourceTags = []configv1.AzureResourceTag{
+					{Key: "env", Value: "prod"},
+					{Key: "cost-center", Value: "a=1,b=2"},
+				}
+				return infra
+			}(),
+		},
+		{
+			name:   "Azure backfills the resource groups from the infrastructure",
+			source: azconfig.Config{},
+			expected: makeExpectedConfig(&azconfig.Config{
+				ResourceGroup:     "cluster-rg",
+				VnetResourceGroup: "network-rg",
+			}, configv1.AzurePublicCloud),
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzurePublicCloud)
+				infra.Status.PlatformStatus.Azure.ResourceGroupName = "cluster-rg"
+				infra.Status.PlatformStatus.Azure.NetworkResourceGroupName = "network-rg"
+				return infra
+			}(),
+		},
+		{
+			name:   "Azure keeps the resource groups set in the source",
+			source: azconfig.Config{ResourceGroup: "test-rg", VnetResourceGroup: "test-network-rg"},
+			expected: makeExpectedConfig(&azconfig.Config{
+				ResourceGroup:     "test-rg",
+				VnetResourceGroup: "test-network-rg",
+			}, configv1.AzurePublicCloud),
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzurePublicCloud)
+				infra.Status.PlatformStatus.Azure.ResourceGroupName = "cluster-rg"
+				infra.Status.PlatformStatus.Azure.NetworkResourceGroupName = "network-rg"
+				return infra
+			}(),
+		},
 	}
 
 	format.CharactersAroundMismatchToInclude = 300
@@ -272,3 +376,250 @@ func TestCloudConfigTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudConfigTransformerINI(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		expected func(*azconfig.Config)
+		errMsg   string
+	}{
+		{
+			name: "Azure converts an ini cloud.conf to JSON",
+			source: `[Global]
+TenantID                    = tenant
+subscriptionId              = subscription
+resourceGroup               = rg
+vmType                      = vmss
+cloudProviderBackoffRetries = 6
+cloudProviderBackoff        = true
+tags                        = team=ccm
+`,
+			expected: func(cfg *azconfig.Config) {
+				cfg.TenantID = "tenant"
+				cfg.SubscriptionID = "subscription"
+				cfg.ResourceGroup = "rg"
+				cfg.VMType = "vmss"
+				cfg.AzureClientConfig.CloudProviderBackoffRetries = 6
+				cfg.CloudProviderBackoff = true
+				cfg.Tags = "team=ccm"
+			},
+		},
+		{
+			name:   "Azure enables the managed identity extension for a user assigned identity",
+			source: "userAssignedIdentityID = identity\n",
+			expected: func(cfg *azconfig.Config) {
+				cfg.UserAssignedIdentityID = "identity"
+				cfg.UseManagedIdentityExtension = true
+			},
+		},
+		{
+			name:   "Azure keeps the managed identity extension disabled if explicitly set",
+			source: "userAssignedIdentityID = identity\nuseManagedIdentityExtension = false\n",
+			expected: func(cfg *azconfig.Config) {
+				cfg.UserAssignedIdentityID = "identity"
+			},
+		},
+		{
+			name:   "Azure returns an error on unknown options",
+			source: "[Global]\nzone = 1\n",
+			errMsg: `unsupported option "zone" in the ini cloud.conf`,
+		},
+		{
+			name:   "Azure returns an error on invalid values",
+			source: "[Global]\ncloudProviderBackoff = maybe\n",
+			errMsg: `invalid value for option "cloudProviderBackoff" in the ini cloud.conf: strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			actual, err := CloudConfigTransformer(tc.source, makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzurePublicCloud), nil, featuregates.NewFeatureGate(nil, nil))
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			expected := makeExpectedConfig(&azconfig.Config{}, configv1.AzurePublicCloud)
+			tc.expected(&expected)
+
+			var observed azconfig.Config
+			g.Expect(json.Unmarshal([]byte(actual), &observed)).To(Succeed(), "Unmarshal of observed data should succeed")
+			g.Expect(observed).Should(Equal(expected))
+		})
+	}
+}
+
+func TestCloudConfigTransformerUnknownFields(t *testing.T) {
+	g := NewWithT(t)
+
+	source := `{"cloud":"AzurePublicCloud","vmType":"vmss","newOption":"value","tagsMap":{"team":"openshift"}}`
+	actual, err := CloudConfigTransformer(source, makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzurePublicCloud), nil, featuregates.NewFeatureGate(nil, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	observed := map[string]interface{}{}
+	g.Expect(json.Unmarshal([]byte(actual), &observed)).To(Succeed())
+	g.Expect(observed).To(HaveKeyWithValue("newOption", "value"))
+	g.Expect(observed).To(HaveKeyWithValue("vmType", "vmss"))
+	g.Expect(observed).To(HaveKeyWithValue("clusterServiceLoadBalancerHealthProbeMode", "shared"))
+	g.Expect(observed).To(HaveKeyWithValue("tagsMap", map[string]interface{}{"team": "openshift"}))
+}
+
+func TestLintCloudConfig(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name:   "Known fields",
+			source: `{"cloud":"AzurePublicCloud","vmType":"standard","tagsMap":{"team":"openshift"}}`,
+		},
+		{
+			name:   "Unknown fields",
+			source: `{"cloud":"AzurePublicCloud","vmTpye":"standard","zone":"1"}`,
+			expected: []string{
+				`field "vmTpye" is unknown to the cloud provider, did you mean "vmType"?`,
+				`field "zone" is unknown to the cloud provider, remove it unless the cloud provider version supports it`,
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(LintCloudConfig(tc.source)).Should(Equal(tc.expected))
+		})
+	}
+}
+
+func TestValidateCloudConfig(t *testing.T) {
+	tc := []struct {
+		name   string
+		source string
+		errMsg string
+	}{
+		{
+			name:   "Valid config",
+			source: `{"cloud":"AzurePublicCloud","vmType":"standard","loadBalancerSku":"Standard","useManagedIdentityExtension":true}`,
+		},
+		{
+			name:   "Unknown field",
+			source: `{"cloud":"AzurePublicCloud","zone":"1"}`,
+		},
+		{
+			name:   "Invalid value type",
+			source: `{"cloud":"AzurePublicCloud","cloudProviderBackoff":"yes"}`,
+			errMsg: "failed to unmarshal the cloud.conf: json: cannot unmarshal string into Go struct field Config.cloudProviderBackoff of type bool",
+		},
+		{
+			name:   "Missing cloud",
+			source: `{"vmType":"standard"}`,
+			errMsg: "cloud is required",
+		},
+		{
+			name:   "Unsupported VM type",
+			source: `{"cloud":"AzurePublicCloud","vmType":"vmas"}`,
+			errMsg: `unsupported vmType "vmas", expected one of "standard", "vmss" or "vmssflex"`,
+		},
+		{
+			name:   "Unsupported load balancer SKU",
+			source: `{"cloud":"AzurePublicCloud","loadBalancerSku":"premium"}`,
+			errMsg: `unsupported loadBalancerSku "premium", expected "basic" or "standard"`,
+		},
+		{
+			name:   "Mutually exclusive identities",
+			source: `{"cloud":"AzurePublicCloud","useManagedIdentityExtension":true,"useFederatedWorkloadIdentityExtension":true,"Entries":null,"putVMSSVMBatchSize":0,"enableMigrateToIPBasedBackendPoolAPI":false}`,
+			errMsg: "useManagedIdentityExtension and useFederatedWorkloadIdentityExtension are mutually exclusive",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateCloudConfig(tc.source)
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestSetWorkloadIdentityOptions(t *testing.T) {
+	tc := []struct {
+		name        string
+		source      string
+		credentials map[string][]byte
+		expected    string
+		errMsg      string
+	}{
+		{
+			name:   "Client secret credentials",
+			source: `{"cloud":"AzurePublicCloud","useManagedIdentityExtension":true}`,
+			credentials: map[string][]byte{
+				"azure_client_id":     []byte("client"),
+				"azure_client_secret": []byte("secret"),
+			},
+			expected: `{"cloud":"AzurePublicCloud","useManagedIdentityExtension":true}`,
+		},
+		{
+			name:   "Workload identity credentials",
+			source: `{"cloud":"AzurePublicCloud","useManagedIdentityExtension":true,"aadClientSecret":"secret"}`,
+			credentials: map[string][]byte{
+				"azure_client_id":            []byte("client"),
+				"azure_tenant_id":            []byte("tenant"),
+				"azure_federated_token_file": []byte("/var/run/secrets/openshift/serviceaccount/token\n"),
+			},
+			expected: `{"cloud":"AzurePublicCloud","tenantId":"tenant","aadClientId":"client","aadFederatedTokenFile":"/var/run/secrets/openshift/serviceaccount/token","useFederatedWorkloadIdentityExtension":true,"Entries":null,"putVMSSVMBatchSize":0,"enableMigrateToIPBasedBackendPoolAPI":false}`,
+		},
+		{
+			name:   "Workload identity credentials with unknown fields",
+			source: `{"cloud":"AzurePublicCloud","newOption":{"enabled":true}}`,
+			credentials: map[string][]byte{
+				"azure_client_id":            []byte("client"),
+				"azure_tenant_id":            []byte("tenant"),
+				"azure_federated_token_file": []byte("/var/run/secrets/openshift/serviceaccount/token"),
+			},
+			expected: `{"cloud":"AzurePublicCloud","newOption":{"enabled":true},"tenantId":"tenant","aadClientId":"client","aadFederatedTokenFile":"/var/run/secrets/openshift/serviceaccount/token","useFederatedWorkloadIdentityExtension":true,"Entries":null,"putVMSSVMBatchSize":0,"enableMigrateToIPBasedBackendPoolAPI":false}`,
+		},
+		{
+			name:   "Workload identity credentials without tenant",
+			source: `{"cloud":"AzurePublicCloud"}`,
+			credentials: map[string][]byte{
+				"azure_client_id":            []byte("client"),
+				"azure_federated_token_file": []byte("/var/run/secrets/openshift/serviceaccount/token"),
+			},
+			errMsg: "azure_client_id and azure_tenant_id are required for workload identity authentication",
+		},
+		{
+			name:   "Invalid config",
+			source: `[Global]`,
+			credentials: map[string][]byte{
+				"azure_client_id":            []byte("client"),
+				"azure_tenant_id":            []byte("tenant"),
+				"azure_federated_token_file": []byte("/var/run/secrets/openshift/serviceaccount/token"),
+			},
+			errMsg: "failed to unmarshal the cloud.conf: invalid character 'G' looking for beginning of value",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			actual, err := SetWorkloadIdentityOptions(tc.source, tc.credentials)
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(actual).Should(MatchJSON(tc.expected))
+		})
+	}
+}
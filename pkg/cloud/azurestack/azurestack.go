@@ -4,6 +4,7 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/asaskevich/govalidator"
 	configv1 "github.com/openshift/api/config/v1"
@@ -31,6 +32,19 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         providerName,
+		PlatformType: configv1.AzurePlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool {
+			return IsAzureStackHub(platformStatus)
+		},
+		NewAssets:                NewProviderAssets,
+		CloudConfigTransformer:   CloudConfigTransformer,
+		CloudConfigSyncedFromCCO: true,
+	})
+}
+
 type imagesReference struct {
 	Operator               string `valid:"required"`
 	CloudControllerManager string `valid:"required"`
@@ -103,8 +117,12 @@ func IsAzureStackHub(platformStatus *configv1.PlatformStatus) bool {
 // CloudConfigTransformer implements the cloudConfigTransformer. It takes
 // the user-provided, legacy cloud provider-compatible configuration and
 // modifies it to be compatible with the external cloud provider. It returns
-// an error if the platform is not OpenStackPlatformType or if any errors are
+// an error if the platform is not Azure Stack Hub or if any errors are
 // encountered while attempting to rework the configuration.
+// Azure Stack Hub differs from the public Azure clouds in the cloud environment,
+// which is read from the endpoints file instead of being built into the provider,
+// the Azure Resource Manager endpoint, which is specific to each installation,
+// and the features it lacks, such as scale sets and standard load balancers.
 func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
 	if !IsAzureStackHub(infra.Status.PlatformStatus) {
 		return "", fmt.Errorf("invalid platform, expected CloudName to be %s", configv1.AzureStackCloud)
@@ -115,6 +133,20 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 		return "", fmt.Errorf("failed to unmarshal the cloud.conf: %w", err)
 	}
 
+	// The cloud environment of Azure Stack Hub is loaded from the file set in the
+	// AZURE_ENVIRONMENT_FILEPATH environment variable, which is only done for this cloud name.
+	if cfg.Cloud == "" {
+		cfg.Cloud = azureconsts.AzureStackCloudName
+	} else if !strings.EqualFold(cfg.Cloud, azureconsts.AzureStackCloudName) {
+		return "", fmt.Errorf("invalid cloud %q in the cloud.conf, expected %s", cfg.Cloud, azureconsts.AzureStackCloudName)
+	}
+
+	// The Azure Resource Manager endpoint is set at install time, and takes precedence over
+	// the cloud.conf, so the provider talks to the same endpoint as the rest of the cluster.
+	if armEndpoint := infra.Status.PlatformStatus.Azure.ARMEndpoint; armEndpoint != "" {
+		cfg.ResourceManagerEndpoint = armEndpoint
+	}
+
 	// If the virtual machine type is not set we need to make sure it uses
 	// the "standard" instance type. This is to mitigate an issue in the 1.27
 	// release where the default instance type was changed to VMSS.
@@ -123,7 +155,16 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 		cfg.VMType = azureconsts.VMTypeStandard
 	}
 
-	cfgbytes, err := json.Marshal(cfg)
+	// Azure Stack Hub only provides basic load balancers.
+	if cfg.LoadBalancerSKU == "" {
+		cfg.LoadBalancerSKU = azureconsts.LoadBalancerSKUBasic
+	} else if !strings.EqualFold(cfg.LoadBalancerSKU, azureconsts.LoadBalancerSKUBasic) {
+		return "", fmt.Errorf("invalid loadBalancerSku %q in the cloud.conf, only %s is supported on Azure Stack Hub",
+			cfg.LoadBalancerSKU, azureconsts.LoadBalancerSKUBasic)
+	}
+
+	// Options unknown to the vendored config type, e.g. added by a newer cloud provider, are kept.
+	cfgbytes, err := common.MarshalJSONPreservingUnknownFields(source, cfg)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal the cloud.conf: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	configv1 "github.com/openshift/api/config/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cloud-provider-azure/pkg/azclient"
 	azconfig "sigs.k8s.io/cloud-provider-azure/pkg/provider/config"
 
 	"github.com/stretchr/testify/assert"
@@ -119,16 +120,70 @@ func TestCloudConfigTransformer(t *testing.T) {
 		errMsg   string
 	}{
 		{
-			name:     "Azure Stack Hub sets the vmType to standard",
-			source:   azconfig.Config{},
-			expected: azconfig.Config{VMType: "standard"},
-			infra:    makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+			name:   "Azure Stack Hub sets the vmType to standard",
+			source: azconfig.Config{},
+			expected: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AZURESTACKCLOUD"}},
+				VMType:            "standard",
+				LoadBalancerSKU:   "basic",
+			},
+			infra: makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+		},
+		{
+			name:   "Azure Stack Hub doesn't modify vmType if user set",
+			source: azconfig.Config{VMType: "vmss"},
+			expected: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AZURESTACKCLOUD"}},
+				VMType:            "vmss",
+				LoadBalancerSKU:   "basic",
+			},
+			infra: makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+		},
+		{
+			name: "Azure Stack Hub keeps the user set cloud and load balancer SKU",
+			source: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AzureStackCloud"}},
+				LoadBalancerSKU:   "Basic",
+			},
+			expected: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AzureStackCloud"}},
+				VMType:            "standard",
+				LoadBalancerSKU:   "Basic",
+			},
+			infra: makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+		},
+		{
+			name: "Azure Stack Hub sets the resource manager endpoint from the infrastructure",
+			source: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{ResourceManagerEndpoint: "https://outdated.example.com"}},
+			},
+			expected: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{
+					Cloud:                   "AZURESTACKCLOUD",
+					ResourceManagerEndpoint: "https://management.local.azurestack.external",
+				}},
+				VMType:          "standard",
+				LoadBalancerSKU: "basic",
+			},
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud)
+				infra.Status.PlatformStatus.Azure.ARMEndpoint = "https://management.local.azurestack.external"
+				return infra
+			}(),
+		},
+		{
+			name: "Azure Stack Hub rejects other clouds",
+			source: azconfig.Config{
+				AzureClientConfig: azconfig.AzureClientConfig{ARMClientConfig: azclient.ARMClientConfig{Cloud: "AzurePublicCloud"}},
+			},
+			infra:  makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+			errMsg: "invalid cloud \"AzurePublicCloud\" in the cloud.conf, expected AZURESTACKCLOUD",
 		},
 		{
-			name:     "Azure Stack Hub doesn't modify vmType if user set",
-			source:   azconfig.Config{VMType: "vmss"},
-			expected: azconfig.Config{VMType: "vmss"},
-			infra:    makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+			name:   "Azure Stack Hub rejects standard load balancers",
+			source: azconfig.Config{LoadBalancerSKU: "standard"},
+			infra:  makeInfrastructureResource(configv1.AzurePlatformType, configv1.AzureStackCloud),
+			errMsg: "invalid loadBalancerSku \"standard\" in the cloud.conf, only basic is supported on Azure Stack Hub",
 		},
 		{
 			name:   "Non Azure Stack Hub returns an error",
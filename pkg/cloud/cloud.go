@@ -1,6 +1,8 @@
 package cloud
 
 import (
+	"slices"
+
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -8,67 +10,115 @@ import (
 
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
-	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
-
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/aws"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azure"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azurestack"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/gcp"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/ibm"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/nutanix"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/openstack"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/powervs"
-	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/vsphere"
-)
+	"github.com/openshift/library-go/pkg/cloudprovider"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/external"
 
-// cloudConfigTransformer function transforms the source config map using the input infrastructure.config.openshift.io
-// and network.config.openshift.io objects. Only the data and binaryData field of the output ConfigMap will be respected by
-// consumer of the transformer.
-type cloudConfigTransformer func(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error)
+	// Cloud providers register themselves on import, see common.RegisterCloudProvider.
+	// The equinixmetal, hetzner, kubevirt, linode, oci and scaleway providers are not imported until their
+	// cloud controller manager images are promoted into the release payload.
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/aws"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azure"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azurestack"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/gcp"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/ibm"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/nutanix"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/openstack"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/powervs"
+	_ "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/vsphere"
+)
 
 // GetCloudConfigTransformer returns the function that should be used to transform
-// the cloud configuration config map, and a boolean to indicate if the config should
-// be synced from the CCO namespace before applying the transformation.
-// TODO: the boolean return value to indicate if the config should be synced can be
+// the cloud configuration config map, and a boolean to indicate if the config is expected
+// to be synced from the CCO namespace before applying the transformation.
+// TODO: the boolean return value to indicate if the config is expected to be synced can be
 // removed once we migrate the AWS and Azure logic from the CCO to this operator.
-// See the FIXME comments below, and the TODO comment in the Reconcile function
-// inside cloud_config_sync_controller.go.
-func GetCloudConfigTransformer(platformStatus *configv1.PlatformStatus) (cloudConfigTransformer, bool, error) {
-	switch platformStatus.Type {
-	case configv1.AWSPlatformType:
-		// We intentionally return nil rather than NoOpTransformer since we
-		// want to handle this differently in the caller.
-		// FIXME: We need to implement a transformer for this. Currently we're
-		// relying on CCO to do the heavy lifting for us.
-		return aws.CloudConfigTransformer, true, nil
-	case configv1.AzurePlatformType:
-		// We intentionally return nil rather than NoOpTransformer since we
-		// want to handle this differently in the caller.
-		// Except on Azure Stack Hub, where we need to lookup the cloud config
-		// from the managed namespace and also return a config transformer.
-		// FIXME: We need to implement a transformer for this. Currently we're
-		// relying on CCO to do the heavy lifting for us. The Azure Stack Hub
-		// transformer is only to fix OCPBUGS-20213.
-		if azurestack.IsAzureStackHub(platformStatus) {
-			return azurestack.CloudConfigTransformer, true, nil
-		}
-		return azure.CloudConfigTransformer, true, nil
-	case configv1.GCPPlatformType:
-		return common.NoOpTransformer, false, nil
-	case configv1.IBMCloudPlatformType:
-		return common.NoOpTransformer, false, nil
-	case configv1.OpenStackPlatformType:
-		return openstack.CloudConfigTransformer, false, nil
-	case configv1.PowerVSPlatformType:
-		//Power VS platform uses ibm cloud provider
-		return common.NoOpTransformer, false, nil
-	case configv1.VSpherePlatformType:
-		return vsphere.CloudConfigTransformer, false, nil
-	case configv1.NutanixPlatformType:
-		return common.NoOpTransformer, false, nil
-	default:
+// See the TODO comment in the Reconcile function inside cloud_config_sync_controller.go.
+func GetCloudConfigTransformer(platformStatus *configv1.PlatformStatus, externalPlatformName string) (common.CloudConfigTransformer, bool, error) {
+	provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName)
+	if !found || provider.CloudConfigTransformer == nil {
+		// Providers which do not consume the cloud configuration are reported as not found.
 		return nil, false, newPlatformNotFoundError(platformStatus.Type)
 	}
+	return provider.CloudConfigTransformer, provider.CloudConfigSyncedFromCCO, nil
+}
+
+// IsExternalCloudConfigSyncNeeded returns true if the provider running on the External platform type
+// with the given name consumes the cloud configuration, which thus has to be synced.
+func IsExternalCloudConfigSyncNeeded(externalPlatformName string) bool {
+	_, _, err := GetCloudConfigTransformer(&configv1.PlatformStatus{Type: configv1.ExternalPlatformType}, externalPlatformName)
+	return err == nil
+}
+
+// ValidateCloudConfig checks the synced cloud config with the validator of the provider for the given platform,
+// if it has one, so an invalid config is reported before it is consumed by the cloud controller manager.
+func ValidateCloudConfig(platformStatus *configv1.PlatformStatus, externalPlatformName string, cloudConfig string) error {
+	provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName)
+	if !found || provider.CloudConfigValidator == nil {
+		return nil
+	}
+	return provider.CloudConfigValidator(cloudConfig)
+}
+
+// LintCloudConfig returns the findings of the linter of the provider for the given platform on the cloud config,
+// and nil if the provider does not declare one.
+func LintCloudConfig(platformStatus *configv1.PlatformStatus, externalPlatformName string, cloudConfig string) []string {
+	provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName)
+	if !found || provider.CloudConfigLinter == nil {
+		return nil
+	}
+	return provider.CloudConfigLinter(cloudConfig)
+}
+
+// GetCloudConfigKey returns the key of the synced cloud config ConfigMap the provider for the given platform expects
+// the cloud config in, and an empty string if it does not declare one.
+func GetCloudConfigKey(platformStatus *configv1.PlatformStatus, externalPlatformName string) string {
+	provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName)
+	if !found {
+		return ""
+	}
+	return provider.CloudConfigKey
+}
+
+// GetTrustedCABundle returns the extra root CAs the provider for the given platform ships with its assets, to be
+// merged into the trusted CA bundle, and nil if it ships none.
+func GetTrustedCABundle(platformStatus *configv1.PlatformStatus, externalPlatformName string) []byte {
+	provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName)
+	if !found {
+		return nil
+	}
+	return provider.TrustedCABundle
+}
+
+// StripCloudConfigSecrets removes the keys the provider for the given platform declares as sensitive from
+// the cloud config, so a config sourced from a Secret can be published in the synced ConfigMap.
+func StripCloudConfigSecrets(platformStatus *configv1.PlatformStatus, externalPlatformName string, cloudConfig string) (string, error) {
+	provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName)
+	if !found {
+		return cloudConfig, nil
+	}
+	return common.StripCloudConfigKeys(cloudConfig, provider.CloudConfigSensitiveKeys)
+}
+
+// StripPlaintextCredentials removes the credentials set in clear text in the cloud config, i.e. the keys the
+// provider for the given platform declares as sensitive and the common.CredentialKeys, so they are never published
+// in the synced ConfigMap. It returns the stripped config along with the removed keys, the config is returned
+// unchanged when it holds no credentials.
+func StripPlaintextCredentials(platformStatus *configv1.PlatformStatus, externalPlatformName string, cloudConfig string) (string, []string, error) {
+	keys := common.CredentialKeys
+	if provider, found := common.LookupCloudProvider(platformStatus, externalPlatformName); found {
+		keys = append(slices.Clone(keys), provider.CloudConfigSensitiveKeys...)
+	}
+
+	found, err := common.FindCloudConfigKeys(cloudConfig, keys)
+	if err != nil || len(found) == 0 {
+		return cloudConfig, nil, err
+	}
+	stripped, err := common.StripCloudConfigKeys(cloudConfig, keys)
+	if err != nil {
+		return "", nil, err
+	}
+	return stripped, found, nil
 }
 
 // GetResources selectively returns a list of resources required for
@@ -77,6 +127,14 @@ func GetCloudConfigTransformer(platformStatus *configv1.PlatformStatus) (cloudCo
 // These resources will be actively maintained by the operator, preventing
 // changes in their spec.
 func GetResources(operatorConfig config.OperatorConfig) ([]client.Object, error) {
+	if err := validateCloudControllerManagerArgs(operatorConfig); err != nil {
+		klog.Errorf("invalid cloud-controller-manager arguments: %v", err)
+		return nil, err
+	}
+	if _, err := common.ParseContainerResources(operatorConfig.ContainerResources); err != nil {
+		klog.Errorf("invalid container resources: %v", err)
+		return nil, err
+	}
 	assets, err := getAssets(operatorConfig)
 	if err != nil {
 		if _, isPlatformNotFoundError := err.(*platformNotFoundError); isPlatformNotFoundError {
@@ -87,7 +145,23 @@ func GetResources(operatorConfig config.OperatorConfig) ([]client.Object, error)
 		return nil, err
 	}
 	renderedObjects := assets.GetRenderedResources()
+	if sidecarsProvider, ok := assets.(common.SidecarsProvider); ok {
+		// Sidecars are injected before the common substitutions, so they get the proxy settings as well.
+		renderedObjects = common.InjectSidecars(sidecarsProvider.GetSidecars(), renderedObjects)
+	}
 	substitutedObjects := common.SubstituteCommonPartsFromConfig(operatorConfig, renderedObjects)
+	if probesProvider, ok := assets.(common.ProbesProvider); ok {
+		substitutedObjects = common.SetContainerProbes(probesProvider.GetContainerProbes(), substitutedObjects)
+	}
+	if architecturesProvider, ok := assets.(common.ArchitecturesProvider); ok {
+		substitutedObjects = common.SetArchitectureAffinity(architecturesProvider.GetSupportedArchitectures(), substitutedObjects)
+	}
+	if operatorConfig.IsControlPlaneExternal() {
+		substitutedObjects = common.RemoveCloudControllerManager(substitutedObjects)
+	}
+	if operatorConfig.DisableCloudNodeManager {
+		substitutedObjects = common.RemoveCloudNodeManager(substitutedObjects)
+	}
 	commonResources, err := common.GetCommonResources(operatorConfig)
 	if err != nil {
 		klog.Errorf("can not create common resources %v", err)
@@ -99,39 +173,27 @@ func GetResources(operatorConfig config.OperatorConfig) ([]client.Object, error)
 
 // getAssets internal function which returns fully initialized CloudProviderAssets object.
 func getAssets(operatorConfig config.OperatorConfig) (common.CloudProviderAssets, error) {
-	constructor, err := getAssetsConstructor(operatorConfig.PlatformStatus)
-	if err != nil {
-		return nil, err
+	if operatorConfig.PlatformStatus.Type == configv1.ExternalPlatformType && len(operatorConfig.ExternalManifests) > 0 {
+		// Pass-through mode, the user-supplied manifests take precedence over the assets shipped for the provider.
+		return external.NewProviderAssets(operatorConfig)
+	}
+	provider, found := common.LookupCloudProvider(operatorConfig.PlatformStatus, operatorConfig.ExternalPlatformName)
+	if !found {
+		return nil, newPlatformNotFoundError(operatorConfig.PlatformStatus.Type)
 	}
-	return constructor(operatorConfig)
+	return provider.NewAssets(operatorConfig)
 }
 
-type assetsConstructor func(config config.OperatorConfig) (common.CloudProviderAssets, error)
-
-// getAssetsConstructor internal function which selectively returns CloudProviderAssets constructor function
-// for given PlatformStatus. Intended to be a single place across operator logic where platform dependent choice happen.
-func getAssetsConstructor(platformStatus *configv1.PlatformStatus) (assetsConstructor, error) {
-	switch platformStatus.Type {
-	case configv1.AWSPlatformType:
-		return aws.NewProviderAssets, nil
-	case configv1.AzurePlatformType:
-		if azurestack.IsAzureStackHub(platformStatus) {
-			return azurestack.NewProviderAssets, nil
-		}
-		return azure.NewProviderAssets, nil
-	case configv1.GCPPlatformType:
-		return gcp.NewProviderAssets, nil
-	case configv1.IBMCloudPlatformType:
-		return ibm.NewProviderAssets, nil
-	case configv1.OpenStackPlatformType:
-		return openstack.NewProviderAssets, nil
-	case configv1.PowerVSPlatformType:
-		return powervs.NewProviderAssets, nil
-	case configv1.VSpherePlatformType:
-		return vsphere.NewProviderAssets, nil
-	case configv1.NutanixPlatformType:
-		return nutanix.NewProviderAssets, nil
-	default:
-		return nil, newPlatformNotFoundError(platformStatus.Type)
+// IsExternalPlatformSupported returns true if the cluster runs on the External platform type, expects an external
+// cloud controller manager to be installed, and either the operator ships assets for the provider with the given name,
+// or the user supplied the manifests to deploy (pass-through mode).
+func IsExternalPlatformSupported(platformStatus *configv1.PlatformStatus, externalPlatformName string, hasExternalManifests bool) bool {
+	if external, err := cloudprovider.IsCloudProviderExternal(platformStatus); err != nil || !external {
+		return false
+	}
+	if hasExternalManifests {
+		return true
 	}
+	_, found := common.LookupCloudProvider(&configv1.PlatformStatus{Type: configv1.ExternalPlatformType}, externalPlatformName)
+	return found
 }
@@ -1,9 +1,17 @@
 package cloud
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -11,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
@@ -35,8 +44,18 @@ func getDummyPlatformStatus(platformType configv1.PlatformType, isAzureStack boo
 	return &platformStatus
 }
 
+func getDummyExternalPlatformStatus() *configv1.PlatformStatus {
+	return &configv1.PlatformStatus{
+		Type: configv1.ExternalPlatformType,
+		External: &configv1.ExternalPlatformStatus{
+			CloudControllerManager: configv1.CloudControllerManagerStatus{State: configv1.CloudControllerManagerExternal},
+		},
+	}
+}
+
 type testPlatform struct {
-	platformStatus *configv1.PlatformStatus
+	platformStatus       *configv1.PlatformStatus
+	externalPlatformName string
 }
 
 func (tp *testPlatform) getOperatorConfig() config.OperatorConfig {
@@ -54,8 +73,9 @@ func (tp *testPlatform) getOperatorConfig() config.OperatorConfig {
 			CloudControllerManagerPowerVS:   "quay.io/openshift/origin-powervs-cloud-controller-manager",
 			CloudControllerManagerNutanix:   "quay.io/openshift/origin-nutanix-cloud-controller-manager",
 		},
-		PlatformStatus:     tp.platformStatus,
-		InfrastructureName: "my-cool-cluster-777",
+		PlatformStatus:       tp.platformStatus,
+		ExternalPlatformName: tp.externalPlatformName,
+		InfrastructureName:   "my-cool-cluster-777",
 	}
 }
 
@@ -63,20 +83,21 @@ type testPlatformsMap map[string]testPlatform
 
 func getPlatforms() testPlatformsMap {
 	return testPlatformsMap{
-		string(configv1.AWSPlatformType):       {getDummyPlatformStatus(configv1.AWSPlatformType, false)},
-		string(configv1.AzurePlatformType):     {getDummyPlatformStatus(configv1.AzurePlatformType, false)},
-		"AzureStackHub":                        {getDummyPlatformStatus(configv1.AzurePlatformType, true)},
-		string(configv1.BareMetalPlatformType): {getDummyPlatformStatus(configv1.BareMetalPlatformType, false)},
-		string(configv1.GCPPlatformType):       {getDummyPlatformStatus(configv1.GCPPlatformType, false)},
-		string(configv1.IBMCloudPlatformType):  {getDummyPlatformStatus(configv1.IBMCloudPlatformType, false)},
-		string(configv1.KubevirtPlatformType):  {getDummyPlatformStatus(configv1.KubevirtPlatformType, false)},
-		string(configv1.LibvirtPlatformType):   {getDummyPlatformStatus(configv1.LibvirtPlatformType, false)},
-		string(configv1.NonePlatformType):      {getDummyPlatformStatus(configv1.NonePlatformType, false)},
-		string(configv1.NutanixPlatformType):   {getDummyPlatformStatus(configv1.NutanixPlatformType, false)},
-		string(configv1.OpenStackPlatformType): {getDummyPlatformStatus(configv1.OpenStackPlatformType, false)},
-		string(configv1.OvirtPlatformType):     {getDummyPlatformStatus(configv1.OvirtPlatformType, false)},
-		string(configv1.PowerVSPlatformType):   {getDummyPlatformStatus(configv1.PowerVSPlatformType, false)},
-		string(configv1.VSpherePlatformType):   {getDummyPlatformStatus(configv1.VSpherePlatformType, false)},
+		string(configv1.AWSPlatformType):       {platformStatus: getDummyPlatformStatus(configv1.AWSPlatformType, false)},
+		string(configv1.AzurePlatformType):     {platformStatus: getDummyPlatformStatus(configv1.AzurePlatformType, false)},
+		"AzureStackHub":                        {platformStatus: getDummyPlatformStatus(configv1.AzurePlatformType, true)},
+		string(configv1.BareMetalPlatformType): {platformStatus: getDummyPlatformStatus(configv1.BareMetalPlatformType, false)},
+		string(configv1.GCPPlatformType):       {platformStatus: getDummyPlatformStatus(configv1.GCPPlatformType, false)},
+		string(configv1.IBMCloudPlatformType):  {platformStatus: getDummyPlatformStatus(configv1.IBMCloudPlatformType, false)},
+		string(configv1.KubevirtPlatformType):  {platformStatus: getDummyPlatformStatus(configv1.KubevirtPlatformType, false)},
+		string(configv1.LibvirtPlatformType):   {platformStatus: getDummyPlatformStatus(configv1.LibvirtPlatformType, false)},
+		string(configv1.NonePlatformType):      {platformStatus: getDummyPlatformStatus(configv1.NonePlatformType, false)},
+		string(configv1.NutanixPlatformType):   {platformStatus: getDummyPlatformStatus(configv1.NutanixPlatformType, false)},
+		string(configv1.OpenStackPlatformType): {platformStatus: getDummyPlatformStatus(configv1.OpenStackPlatformType, false)},
+		string(configv1.OvirtPlatformType):     {platformStatus: getDummyPlatformStatus(configv1.OvirtPlatformType, false)},
+		string(configv1.PowerVSPlatformType):   {platformStatus: getDummyPlatformStatus(configv1.PowerVSPlatformType, false)},
+		string(configv1.VSpherePlatformType):   {platformStatus: getDummyPlatformStatus(configv1.VSpherePlatformType, false)},
+		"ExternalUnknown":                      {platformStatus: getDummyExternalPlatformStatus(), externalPlatformName: "Unknown"},
 	}
 }
 
@@ -292,6 +313,9 @@ func TestGetResources(t *testing.T) {
 			"Deployment/powervs-cloud-controller-manager",
 			"Service/powervs-cloud-controller-manager",
 		},
+	}, {
+		name:         "External platform resources are empty for unknown providers",
+		testPlatform: platformsMap["ExternalUnknown"],
 	}, {
 		name:         "Libvirt resources are empty",
 		testPlatform: platformsMap[string(configv1.LibvirtPlatformType)],
@@ -309,7 +333,9 @@ func TestGetResources(t *testing.T) {
 	for _, tc := range tc {
 		t.Run(tc.name, func(t *testing.T) {
 			operatorConfig := tc.testPlatform.getOperatorConfig()
-			operatorConfig.IsSingleReplica = tc.singleReplica
+			if tc.singleReplica {
+				operatorConfig.ControlPlaneTopology = configv1.SingleReplicaTopologyMode
+			}
 			resources, err := GetResources(operatorConfig)
 			assert.NoError(t, err)
 
@@ -765,7 +791,7 @@ func TestReplicas(t *testing.T) {
 
 		t.Run(fmt.Sprintf("%s single node", platformName), func(t *testing.T) {
 			cfg := platform.getOperatorConfig()
-			cfg.IsSingleReplica = true
+			cfg.ControlPlaneTopology = configv1.SingleReplicaTopologyMode
 			resources, err := GetResources(cfg)
 			assert.NoError(t, err)
 
@@ -781,9 +807,465 @@ func TestReplicas(t *testing.T) {
 	}
 }
 
+func TestDisableCloudNodeManager(t *testing.T) {
+	platforms := getPlatforms()
+	for platformName, platform := range platforms {
+		t.Run(platformName, func(t *testing.T) {
+			cfg := platform.getOperatorConfig()
+			enabledResources, err := GetResources(cfg)
+			assert.NoError(t, err)
+
+			cfg.DisableCloudNodeManager = true
+			disabledResources, err := GetResources(cfg)
+			assert.NoError(t, err)
+
+			var nodeManagers int
+			for _, resource := range enabledResources {
+				if _, ok := resource.(*appsv1.DaemonSet); ok {
+					nodeManagers++
+				}
+			}
+			assert.Len(t, disabledResources, len(enabledResources)-nodeManagers)
+
+			hasDeployment := false
+			for _, resource := range disabledResources {
+				switch resource.(type) {
+				case *appsv1.DaemonSet:
+					t.Errorf("cloud-node-manager DaemonSet %s should not be deployed", resource.GetName())
+				case *appsv1.Deployment:
+					hasDeployment = true
+				}
+			}
+			if len(enabledResources) > 0 {
+				assert.True(t, hasDeployment, "cloud-controller-manager Deployment should still be deployed")
+			}
+		})
+	}
+}
+
+func TestGetResourcesWithContainerResources(t *testing.T) {
+	for platformName, platform := range getPlatforms() {
+		t.Run(platformName, func(t *testing.T) {
+			cfg := platform.getOperatorConfig()
+			cfg.ContainerResources = map[string]string{"cloud-controller-manager.requests.memory": "512Mi"}
+			resources, err := GetResources(cfg)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				deployment, ok := resource.(*appsv1.Deployment)
+				if !ok {
+					continue
+				}
+				for _, container := range deployment.Spec.Template.Spec.Containers {
+					if container.Name == "cloud-controller-manager" {
+						assert.Equal(t, "512Mi", container.Resources.Requests.Memory().String())
+					}
+				}
+			}
+
+			cfg.ContainerResources = map[string]string{"cloud-controller-manager.requests.memory": "a lot"}
+			_, err = GetResources(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestContainerProbes(t *testing.T) {
+	platforms := getPlatforms()
+	for platformName, platform := range platforms {
+		t.Run(platformName, func(t *testing.T) {
+			cfg := platform.getOperatorConfig()
+			assets, err := getAssets(cfg)
+			if err != nil {
+				// Unsupported platform, nothing to check for
+				return
+			}
+			probesProvider, ok := assets.(common.ProbesProvider)
+			if !ok {
+				return
+			}
+			probes := probesProvider.GetContainerProbes()
+
+			resources, err := GetResources(cfg)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				var podSpec corev1.PodSpec
+				switch obj := resource.(type) {
+				case *appsv1.Deployment:
+					podSpec = obj.Spec.Template.Spec
+				case *appsv1.DaemonSet:
+					podSpec = obj.Spec.Template.Spec
+				default:
+					continue
+				}
+				for _, container := range podSpec.Containers {
+					containerProbes, ok := probes[container.Name]
+					if !ok {
+						continue
+					}
+					if containerProbes.Liveness != nil {
+						assert.NotNil(t, container.LivenessProbe)
+						assert.Equal(t, containerProbes.Liveness.InitialDelaySeconds, container.LivenessProbe.InitialDelaySeconds)
+						assert.Equal(t, containerProbes.Liveness.TimeoutSeconds, container.LivenessProbe.TimeoutSeconds)
+					}
+					if containerProbes.Startup != nil {
+						assert.NotNil(t, container.StartupProbe)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestArchitectureAffinity(t *testing.T) {
+	platforms := getPlatforms()
+	for platformName, platform := range platforms {
+		t.Run(platformName, func(t *testing.T) {
+			cfg := platform.getOperatorConfig()
+			assets, err := getAssets(cfg)
+			if err != nil {
+				// Unsupported platform, nothing to check for
+				return
+			}
+			architecturesProvider, ok := assets.(common.ArchitecturesProvider)
+			if !ok {
+				return
+			}
+			expectedRequirement := corev1.NodeSelectorRequirement{
+				Key:      corev1.LabelArchStable,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   architecturesProvider.GetSupportedArchitectures(),
+			}
+
+			resources, err := GetResources(cfg)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				var podSpec corev1.PodSpec
+				switch obj := resource.(type) {
+				case *appsv1.Deployment:
+					podSpec = obj.Spec.Template.Spec
+				case *appsv1.DaemonSet:
+					podSpec = obj.Spec.Template.Spec
+				default:
+					continue
+				}
+				assert.NotNil(t, podSpec.Affinity)
+				assert.NotNil(t, podSpec.Affinity.NodeAffinity)
+				for _, term := range podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+					assert.Contains(t, term.MatchExpressions, expectedRequirement)
+				}
+			}
+		})
+	}
+}
+
+// sidecarAssets wraps provider assets to inject a sidecar in their cloud-controller-manager Deployment.
+type sidecarAssets struct {
+	common.CloudProviderAssets
+}
+
+func (a *sidecarAssets) GetSidecars() []common.Sidecar {
+	return []common.Sidecar{{
+		Container: corev1.Container{Name: "token-refresher", Image: "quay.io/example/token-refresher"},
+		Volumes:   []corev1.Volume{{Name: "token"}},
+	}}
+}
+
+func TestGetResourcesWithSidecars(t *testing.T) {
+	const fakePlatformType = configv1.PlatformType("FakeWithSidecars")
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         "fake-with-sidecars",
+		PlatformType: fakePlatformType,
+		NewAssets: func(operatorConfig config.OperatorConfig) (common.CloudProviderAssets, error) {
+			operatorConfig.PlatformStatus = getDummyPlatformStatus(configv1.AWSPlatformType, false)
+			assets, err := getAssets(operatorConfig)
+			if err != nil {
+				return nil, err
+			}
+			return &sidecarAssets{CloudProviderAssets: assets}, nil
+		},
+	})
+
+	cfg := (&testPlatform{platformStatus: getDummyPlatformStatus(fakePlatformType, false)}).getOperatorConfig()
+	cfg.ClusterProxy = &configv1.Proxy{Status: configv1.ProxyStatus{HTTPSProxy: "https://proxy.example.com"}}
+	resources, err := GetResources(cfg)
+	assert.NoError(t, err)
+
+	var deployment *appsv1.Deployment
+	for _, resource := range resources {
+		if obj, ok := resource.(*appsv1.Deployment); ok {
+			deployment = obj
+		}
+	}
+	if !assert.NotNil(t, deployment) {
+		return
+	}
+	podSpec := deployment.Spec.Template.Spec
+	sidecar := podSpec.Containers[len(podSpec.Containers)-1]
+	assert.Equal(t, "token-refresher", sidecar.Name)
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{Name: "token"})
+	// Common substitutions apply to the injected sidecars as well.
+	assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "https://proxy.example.com"})
+}
+
+func TestExternalControlPlaneTopology(t *testing.T) {
+	platforms := getPlatforms()
+	for platformName, platform := range platforms {
+		t.Run(platformName, func(t *testing.T) {
+			cfg := platform.getOperatorConfig()
+			cfg.ControlPlaneTopology = configv1.ExternalTopologyMode
+			resources, err := GetResources(cfg)
+			assert.NoError(t, err)
+
+			for _, resource := range resources {
+				switch resource.(type) {
+				case *appsv1.Deployment, *policyv1.PodDisruptionBudget, *corev1.Service:
+					t.Errorf("%T %s should not be deployed with an external control plane", resource, resource.GetName())
+				}
+			}
+		})
+	}
+}
+
 func derefReplicas(num *int32) int {
 	if num != nil {
 		return int(*num)
 	}
 	return 1
 }
+
+func TestIsExternalPlatformSupported(t *testing.T) {
+	tc := []struct {
+		name                 string
+		platformStatus       *configv1.PlatformStatus
+		externalPlatformName string
+		hasExternalManifests bool
+		expected             bool
+	}{{
+		name:                 "Provider without a promoted image",
+		platformStatus:       getDummyExternalPlatformStatus(),
+		externalPlatformName: "oci",
+	}, {
+		name:                 "Unknown provider",
+		platformStatus:       getDummyExternalPlatformStatus(),
+		externalPlatformName: "Unknown",
+	}, {
+		name:                 "Unknown provider with user-supplied manifests",
+		platformStatus:       getDummyExternalPlatformStatus(),
+		externalPlatformName: "Unknown",
+		hasExternalManifests: true,
+		expected:             true,
+	}, {
+		name:                 "User-supplied manifests without external cloud controller manager",
+		platformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+		externalPlatformName: "Unknown",
+		hasExternalManifests: true,
+	}, {
+		name:           "Not an External platform",
+		platformStatus: getDummyPlatformStatus(configv1.AWSPlatformType, false),
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsExternalPlatformSupported(tc.platformStatus, tc.externalPlatformName, tc.hasExternalManifests))
+		})
+	}
+}
+
+func TestGetResourcesForExternalManifests(t *testing.T) {
+	platform := getPlatforms()["ExternalUnknown"]
+	operatorConfig := platform.getOperatorConfig()
+	operatorConfig.ClusterProxy = &configv1.Proxy{Status: configv1.ProxyStatus{HTTPSProxy: "https://proxy.example.com"}}
+	operatorConfig.ExternalManifests = map[string]string{
+		"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: custom-cloud-controller-manager
+  namespace: {{ .managedNamespace }}
+spec:
+  selector:
+    matchLabels:
+      k8s-app: custom-cloud-controller-manager
+  template:
+    metadata:
+      labels:
+        k8s-app: custom-cloud-controller-manager
+    spec:
+      containers:
+        - name: cloud-controller-manager
+          image: quay.io/example/cloud-controller-manager:latest
+`,
+	}
+
+	// User-supplied manifests are deployed for providers the operator does not ship assets for.
+	resources, err := GetResources(operatorConfig)
+	assert.NoError(t, err)
+
+	kindNames := []string{}
+	for _, resource := range resources {
+		kindNames = append(kindNames, fmt.Sprintf("%s/%s", resource.GetObjectKind().GroupVersionKind().Kind, resource.GetName()))
+	}
+	assert.Equal(t, []string{
+		"Deployment/custom-cloud-controller-manager",
+		"PodDisruptionBudget/external-cloud-controller-manager",
+		"Service/external-cloud-controller-manager",
+	}, kindNames)
+
+	// Common substitutions are applied to the user-supplied manifests as well.
+	deployment := resources[0].(*appsv1.Deployment)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "https://proxy.example.com"})
+	checkTrustedCAMounted(t, deployment.Spec.Template.Spec)
+}
+
+func TestProvidersWithoutPromotedImageAreNotRegistered(t *testing.T) {
+	platformStatus := getDummyExternalPlatformStatus()
+
+	// Neither the assets nor the cloud config transformers of these providers are registered,
+	// so their cloud config is not synced either.
+	for _, name := range []string{"equinixmetal", "hetzner", "linode", "oci", "scaleway"} {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, IsExternalPlatformSupported(platformStatus, name, false))
+			_, _, err := GetCloudConfigTransformer(platformStatus, name)
+			assert.Error(t, err)
+			assert.False(t, IsExternalCloudConfigSyncNeeded(name))
+		})
+	}
+
+	_, _, err := GetCloudConfigTransformer(&configv1.PlatformStatus{Type: configv1.KubevirtPlatformType}, "")
+	assert.Error(t, err)
+}
+
+func TestValidateCloudConfig(t *testing.T) {
+	tc := []struct {
+		name           string
+		platformStatus *configv1.PlatformStatus
+		cloudConfig    string
+		expectErr      bool
+	}{{
+		name:           "Valid AWS config",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+		cloudConfig:    "[Global]\nKubernetesClusterID = my-cluster\n",
+	}, {
+		name:           "Invalid AWS config",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+		cloudConfig:    "[Global]\nUnknownOption = true\n",
+		expectErr:      true,
+	}, {
+		name:           "Provider without validator",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.OpenStackPlatformType},
+		cloudConfig:    "not a config",
+	}, {
+		name:           "Platform without provider",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.NonePlatformType},
+		cloudConfig:    "not a config",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCloudConfig(tc.platformStatus, "", tc.cloudConfig)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetCloudConfigKey(t *testing.T) {
+	tc := []struct {
+		name           string
+		platformStatus *configv1.PlatformStatus
+		expected       string
+	}{{
+		name:           "Provider with a config key",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.NutanixPlatformType},
+		expected:       "nutanix_config.json",
+	}, {
+		name:           "Provider without a config key",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+	}, {
+		name:           "Platform without provider",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.NonePlatformType},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetCloudConfigKey(tc.platformStatus, ""))
+		})
+	}
+}
+
+func TestGetTrustedCABundle(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "regional-endpoints-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	const fakePlatformType = configv1.PlatformType("FakeWithTrustedCA")
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         "fake-with-trusted-ca",
+		PlatformType: fakePlatformType,
+		NewAssets: func(config.OperatorConfig) (common.CloudProviderAssets, error) {
+			return nil, nil
+		},
+		TrustedCABundle: caBundle,
+	})
+
+	assert.Equal(t, caBundle, GetTrustedCABundle(&configv1.PlatformStatus{Type: fakePlatformType}, ""))
+	assert.Nil(t, GetTrustedCABundle(&configv1.PlatformStatus{Type: configv1.AWSPlatformType}, ""))
+	assert.Nil(t, GetTrustedCABundle(&configv1.PlatformStatus{Type: configv1.NonePlatformType}, ""))
+}
+
+func TestStripPlaintextCredentials(t *testing.T) {
+	tc := []struct {
+		name           string
+		platformStatus *configv1.PlatformStatus
+		cloudConfig    string
+		expected       string
+		expectedKeys   []string
+	}{{
+		name:           "No credentials",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.AzurePlatformType},
+		cloudConfig:    `{"cloud":"AzurePublicCloud","tenantId":"id"}`,
+		expected:       `{"cloud":"AzurePublicCloud","tenantId":"id"}`,
+	}, {
+		name:           "Common credential keys",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+		cloudConfig:    "[Global]\nRegion = us-east-1\nSecretAccessKey = secret\n",
+		expected:       "[Global]\nRegion = us-east-1\n",
+		expectedKeys:   []string{"SecretAccessKey"},
+	}, {
+		name:           "Sensitive keys of the provider",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.NutanixPlatformType},
+		cloudConfig:    `{"prismCentral":{"address":"pc.example.com","username":"admin"}}`,
+		expected:       `{"prismCentral":{"address":"pc.example.com"}}`,
+		expectedKeys:   []string{"username"},
+	}, {
+		name:           "Platform without provider",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.NonePlatformType},
+		cloudConfig:    "[Global]\npassword = secret\n",
+		expected:       "[Global]\n",
+		expectedKeys:   []string{"password"},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, keys, err := StripPlaintextCredentials(tc.platformStatus, "", tc.cloudConfig)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.expectedKeys, keys)
+		})
+	}
+}
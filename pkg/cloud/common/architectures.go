@@ -0,0 +1,61 @@
+package common
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ArchitecturesProvider is implemented by CloudProviderAssets whose images are only built for some architectures,
+// e.g. on platforms supporting heterogeneous arm64/amd64 clusters. Operands are then restricted to nodes
+// running one of the returned architectures, as reported by the "kubernetes.io/arch" node label.
+type ArchitecturesProvider interface {
+	GetSupportedArchitectures() []string
+}
+
+// SetArchitectureAffinity adds a required node affinity to the Deployments and DaemonSets in provided objects,
+// so their pods are only scheduled on nodes running one of the given architectures.
+func SetArchitectureAffinity(architectures []string, objects []client.Object) []client.Object {
+	if len(architectures) == 0 {
+		return objects
+	}
+	for _, obj := range objects {
+		switch obj := obj.(type) {
+		case *appsv1.Deployment:
+			obj.Spec.Template.Spec = setArchitectureAffinity(architectures, obj.Spec.Template.Spec)
+		case *appsv1.DaemonSet:
+			obj.Spec.Template.Spec = setArchitectureAffinity(architectures, obj.Spec.Template.Spec)
+		}
+	}
+	return objects
+}
+
+// setArchitectureAffinity adds the architecture requirement to each of the node selector terms of the pod spec,
+// since terms are ORed, or creates a single term if there is none.
+func setArchitectureAffinity(architectures []string, p corev1.PodSpec) corev1.PodSpec {
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   architectures,
+	}
+
+	if p.Affinity == nil {
+		p.Affinity = &corev1.Affinity{}
+	}
+	if p.Affinity.NodeAffinity == nil {
+		p.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := p.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	nodeSelector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range nodeSelector.NodeSelectorTerms {
+		term := &nodeSelector.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, *requirement.DeepCopy())
+	}
+	return p
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetArchitectureAffinity(t *testing.T) {
+	archRequirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"amd64", "arm64"},
+	}
+	zoneRequirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelTopologyZone,
+		Operator: corev1.NodeSelectorOpExists,
+	}
+	podAntiAffinity := &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+			TopologyKey: corev1.LabelHostname,
+		}},
+	}
+
+	tc := []struct {
+		name             string
+		architectures    []string
+		affinity         *corev1.Affinity
+		expectedAffinity *corev1.Affinity
+	}{{
+		name: "No architectures declared",
+		affinity: &corev1.Affinity{
+			PodAntiAffinity: podAntiAffinity,
+		},
+		expectedAffinity: &corev1.Affinity{
+			PodAntiAffinity: podAntiAffinity,
+		},
+	}, {
+		name:          "No affinity",
+		architectures: []string{"amd64", "arm64"},
+		expectedAffinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement},
+					}},
+				},
+			},
+		},
+	}, {
+		name:          "Keep pod anti affinity",
+		architectures: []string{"amd64", "arm64"},
+		affinity: &corev1.Affinity{
+			PodAntiAffinity: podAntiAffinity,
+		},
+		expectedAffinity: &corev1.Affinity{
+			PodAntiAffinity: podAntiAffinity,
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement},
+					}},
+				},
+			},
+		},
+	}, {
+		name:          "Add requirement to every existing node selector term",
+		architectures: []string{"amd64", "arm64"},
+		affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement},
+					}, {
+						MatchFields: []corev1.NodeSelectorRequirement{{
+							Key:      "metadata.name",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"node-1"},
+						}},
+					}},
+				},
+			},
+		},
+		expectedAffinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement, archRequirement},
+					}, {
+						MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement},
+						MatchFields: []corev1.NodeSelectorRequirement{{
+							Key:      "metadata.name",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"node-1"},
+						}},
+					}},
+				},
+			},
+		},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{}
+			deployment.Spec.Template.Spec.Affinity = tc.affinity.DeepCopy()
+			daemonSet := &appsv1.DaemonSet{}
+			daemonSet.Spec.Template.Spec.Affinity = tc.affinity.DeepCopy()
+
+			objects := SetArchitectureAffinity(tc.architectures, []client.Object{deployment, daemonSet})
+
+			assert.Equal(t, tc.expectedAffinity, objects[0].(*appsv1.Deployment).Spec.Template.Spec.Affinity)
+			assert.Equal(t, tc.expectedAffinity, objects[1].(*appsv1.DaemonSet).Spec.Template.Spec.Affinity)
+		})
+	}
+}
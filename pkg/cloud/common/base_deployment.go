@@ -0,0 +1,105 @@
+package common
+
+import (
+	"embed"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	//go:embed assets/*
+	assetsFs embed.FS
+	// baseDeploymentTemplate is the cloud-controller-manager Deployment shared by the providers running a stock
+	// cloud controller manager binary, which only differ by their credentials, cloud config and flags.
+	baseDeploymentTemplate = TemplateSource{ReferenceObject: &appsv1.Deployment{}, EmbedFsPath: "assets/cloud-controller-manager-deployment.yaml"}
+)
+
+// DeploymentOverlay holds the provider specific parts of the base cloud-controller-manager Deployment.
+type DeploymentOverlay struct {
+	// Name of the Deployment, also used as its k8s-app label.
+	Name string
+	// Exec is the command line the container script exec's, e.g. the provider binary, its flags are appended to it.
+	Exec string
+	// Args are the provider flags, passed after the verbosity one, e.g. --cloud-provider or --cloud-config.
+	Args []string
+	// Env holds the provider env variables, e.g. credentials, which are added after the common ones.
+	Env []corev1.EnvVar
+	// VolumeMounts and Volumes hold the provider cloud config and credentials, which are added before the common ones.
+	VolumeMounts []corev1.VolumeMount
+	Volumes      []corev1.Volume
+}
+
+// RenderBaseDeployment renders the base cloud-controller-manager Deployment and applies the provider overlay to it.
+// The values must hold the images, with the CloudControllerManager one, the infrastructureName and the
+// cloudproviderName, as validated by the providers.
+func RenderBaseDeployment(overlay DeploymentOverlay, values TemplateValues) (*appsv1.Deployment, error) {
+	if overlay.Name == "" || overlay.Exec == "" {
+		return nil, fmt.Errorf("the name and exec line of the base cloud-controller-manager Deployment must be set")
+	}
+
+	templates, err := ReadTemplates(assetsFs, []TemplateSource{baseDeploymentTemplate})
+	if err != nil {
+		return nil, err
+	}
+	values = maps.Clone(values)
+	values["name"] = overlay.Name
+	values["exec"] = overlay.Exec
+	values["args"] = overlay.Args
+	objects, err := RenderTemplates(templates, values)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := objects[0].(*appsv1.Deployment)
+	podSpec := &deployment.Spec.Template.Spec
+	container := &podSpec.Containers[0]
+	container.Env = append(container.Env, overlay.Env...)
+	container.VolumeMounts = append(append([]corev1.VolumeMount{}, overlay.VolumeMounts...), container.VolumeMounts...)
+	podSpec.Volumes = append(append([]corev1.Volume{}, overlay.Volumes...), podSpec.Volumes...)
+	return deployment, nil
+}
+
+// CloudConfigVolume returns the volume of the synced cloud config ConfigMap and its read only mount in the given
+// directory, holding the cloud.conf file.
+func CloudConfigVolume(name, mountPath string) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-conf"},
+				Items:                []corev1.KeyToPath{{Key: "cloud.conf", Path: "cloud.conf"}},
+			},
+		},
+	}
+	return volume, corev1.VolumeMount{Name: name, MountPath: mountPath, ReadOnly: true}
+}
+
+// SecretVolume returns the volume of the given Secret key and its read only mount in the given directory, holding
+// a file named after the key.
+func SecretVolume(name, secretName, key, mountPath string) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Items:      []corev1.KeyToPath{{Key: key, Path: key}},
+			},
+		},
+	}
+	return volume, corev1.VolumeMount{Name: name, MountPath: mountPath, ReadOnly: true}
+}
+
+// SecretEnvVar returns an env variable taken from the given Secret key. An optional key may be missing.
+func SecretEnvVar(name, secretName, key string, optional bool) corev1.EnvVar {
+	selector := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Key:                  key,
+	}
+	if optional {
+		selector.Optional = &optional
+	}
+	return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: selector}}
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRenderBaseDeployment(t *testing.T) {
+	values := TemplateValues{
+		"images":             map[string]string{"CloudControllerManager": "ccm-image"},
+		"infrastructureName": "my-cluster",
+		"cloudproviderName":  "foo",
+	}
+	volume, mount := CloudConfigVolume("foo-config", "/etc/foo")
+	overlay := DeploymentOverlay{
+		Name:         "foo-cloud-controller-manager",
+		Exec:         "/bin/foo-cloud-controller-manager",
+		Args:         []string{"--cloud-provider=foo", "--cloud-config=/etc/foo/cloud.conf"},
+		Env:          []corev1.EnvVar{SecretEnvVar("FOO_TOKEN", "foo-secret", "token", true)},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Volumes:      []corev1.Volume{volume},
+	}
+
+	deployment, err := RenderBaseDeployment(overlay, values)
+	assert.NoError(t, err)
+	assert.NotContains(t, values, "name", "values provided by the caller must not be modified")
+
+	assert.Equal(t, "foo-cloud-controller-manager", deployment.Name)
+	assert.Equal(t, "foo-cloud-controller-manager", deployment.Spec.Selector.MatchLabels["k8s-app"])
+
+	podSpec := deployment.Spec.Template.Spec
+	container := podSpec.Containers[0]
+	assert.Equal(t, "ccm-image", container.Image)
+	script := container.Command[len(container.Command)-1]
+	assert.Contains(t, script, "exec /bin/foo-cloud-controller-manager \\\n  --v=3 \\\n  --cloud-provider=foo \\\n  --cloud-config=/etc/foo/cloud.conf \\\n")
+
+	// Provider env variables come after the common ones, provider volumes before them.
+	assert.Equal(t, "FOO_TOKEN", container.Env[len(container.Env)-1].Name)
+	assert.True(t, *container.Env[len(container.Env)-1].ValueFrom.SecretKeyRef.Optional)
+	assert.Equal(t, mount, container.VolumeMounts[0])
+	assert.Equal(t, volume, podSpec.Volumes[0])
+	assert.Len(t, podSpec.Volumes, 3)
+
+	_, err = RenderBaseDeployment(DeploymentOverlay{Name: "foo-cloud-controller-manager"}, values)
+	assert.EqualError(t, err, "the name and exec line of the base cloud-controller-manager Deployment must be set")
+}
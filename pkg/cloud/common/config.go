@@ -1,7 +1,15 @@
 package common
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	configv1 "github.com/openshift/api/config/v1"
+	"gopkg.in/ini.v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 )
@@ -11,3 +19,289 @@ import (
 func NoOpTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
 	return source, nil
 }
+
+type cloudConfigFormat string
+
+const (
+	iniFormat  cloudConfigFormat = "ini"
+	jsonFormat cloudConfigFormat = "JSON"
+	yamlFormat cloudConfigFormat = "YAML"
+)
+
+// detectCloudConfigFormat guesses the format of the given cloud config from its first meaningful line,
+// as providers consume either ini, JSON or YAML configs. It returns an empty format for an empty config.
+func detectCloudConfigFormat(source string) cloudConfigFormat {
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "{"):
+			return jsonFormat
+		case strings.HasPrefix(line, "["):
+			return iniFormat
+		case strings.Contains(line, "=") && !strings.Contains(strings.SplitN(line, "=", 2)[0], ":"):
+			return iniFormat
+		default:
+			return yamlFormat
+		}
+	}
+	return ""
+}
+
+// MergeCloudConfigOverrides deep-merges the given overrides on top of the cloud config, so admins can tune
+// provider options the transformers do not manage. The overrides have to be in the same format as the config:
+// keys of ini sections are set or replaced, JSON and YAML objects are merged recursively, and a null value
+// removes the key from the config. Lists are replaced as a whole.
+func MergeCloudConfigOverrides(source, overrides string) (string, error) {
+	overridesFormat := detectCloudConfigFormat(overrides)
+	if overridesFormat == "" {
+		return source, nil
+	}
+	format := detectCloudConfigFormat(source)
+	if format == "" {
+		format = overridesFormat
+	} else if format != overridesFormat {
+		return "", fmt.Errorf("cloud config overrides are in %s format, expected %s", overridesFormat, format)
+	}
+
+	switch format {
+	case iniFormat:
+		return mergeINIOverrides(source, overrides)
+	case jsonFormat:
+		merged, err := mergeObjectOverrides(source, overrides, json.Unmarshal)
+		if err != nil {
+			return "", err
+		}
+		out, err := json.Marshal(merged)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the merged cloud config: %w", err)
+		}
+		return string(out), nil
+	default:
+		merged, err := mergeObjectOverrides(source, overrides, func(data []byte, v interface{}) error {
+			return yaml.Unmarshal(data, v)
+		})
+		if err != nil {
+			return "", err
+		}
+		out, err := yaml.Marshal(merged)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the merged cloud config: %w", err)
+		}
+		return string(out), nil
+	}
+}
+
+func mergeINIOverrides(source, overrides string) (string, error) {
+	loadOptions := ini.LoadOptions{PreserveSurroundedQuote: true}
+	cfg, err := ini.LoadSources(loadOptions, []byte(source))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud config: %w", err)
+	}
+	overridesCfg, err := ini.LoadSources(loadOptions, []byte(overrides))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud config overrides: %w", err)
+	}
+
+	for _, overridesSection := range overridesCfg.Sections() {
+		section := cfg.Section(overridesSection.Name())
+		for _, key := range overridesSection.Keys() {
+			section.Key(key.Name()).SetValue(key.Value())
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("failed to write the merged cloud config: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func mergeObjectOverrides(source, overrides string, unmarshal func([]byte, interface{}) error) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	if strings.TrimSpace(source) != "" {
+		if err := unmarshal([]byte(source), &merged); err != nil {
+			return nil, fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+	}
+	overridesObj := map[string]interface{}{}
+	if err := unmarshal([]byte(overrides), &overridesObj); err != nil {
+		return nil, fmt.Errorf("failed to read the cloud config overrides: %w", err)
+	}
+	deepMerge(merged, overridesObj)
+	return merged, nil
+}
+
+// deepMerge merges src into dst recursively, nil values in src remove the key from dst.
+func deepMerge(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+		srcObj, srcIsObj := value.(map[string]interface{})
+		dstObj, dstIsObj := dst[key].(map[string]interface{})
+		if srcIsObj && dstIsObj {
+			deepMerge(dstObj, srcObj)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// StripCloudConfigKeys removes the given keys from the cloud config, so configs sourced from Secrets can be
+// published without the secret material they carry. Keys are matched regardless of their case: in every section
+// of ini configs, and at any depth of JSON and YAML configs.
+func StripCloudConfigKeys(source string, keys []string) (string, error) {
+	if len(keys) == 0 {
+		return source, nil
+	}
+	stripped := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		stripped[strings.ToLower(key)] = true
+	}
+
+	switch detectCloudConfigFormat(source) {
+	case "":
+		return source, nil
+	case iniFormat:
+		cfg, err := ini.LoadSources(ini.LoadOptions{PreserveSurroundedQuote: true}, []byte(source))
+		if err != nil {
+			return "", fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+		for _, section := range cfg.Sections() {
+			for _, key := range section.KeyStrings() {
+				if stripped[strings.ToLower(key)] {
+					section.DeleteKey(key)
+				}
+			}
+		}
+		var buf bytes.Buffer
+		if _, err := cfg.WriteTo(&buf); err != nil {
+			return "", fmt.Errorf("failed to write the stripped cloud config: %w", err)
+		}
+		return buf.String(), nil
+	case jsonFormat:
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(source), &obj); err != nil {
+			return "", fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+		stripKeys(obj, stripped)
+		out, err := json.Marshal(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the stripped cloud config: %w", err)
+		}
+		return string(out), nil
+	default:
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(source), &obj); err != nil {
+			return "", fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+		stripKeys(obj, stripped)
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the stripped cloud config: %w", err)
+		}
+		return string(out), nil
+	}
+}
+
+// stripKeys removes the given lower cased keys from obj, and from the objects and lists it holds.
+func stripKeys(obj interface{}, keys map[string]bool) {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if keys[strings.ToLower(key)] {
+				delete(value, key)
+				continue
+			}
+			stripKeys(nested, keys)
+		}
+	case []interface{}:
+		for _, item := range value {
+			stripKeys(item, keys)
+		}
+	}
+}
+
+// CredentialKeys lists the cloud config keys which hold credentials regardless of the provider, e.g. passwords,
+// access keys and client secrets. Providers read them from their credentials secret, not from the cloud config.
+var CredentialKeys = []string{
+	"password",
+	"aadClientSecret",
+	"aadClientCertPassword",
+	"clientSecret",
+	"client-secret",
+	"application-credential-secret",
+	"accessKey",
+	"access-key",
+	"accessKeyID",
+	"secretAccessKey",
+	"secret-access-key",
+	"apiKey",
+	"api-key",
+}
+
+// FindCloudConfigKeys returns the given keys which are set in the cloud config, as they are spelled in it.
+// Keys are matched like StripCloudConfigKeys does, the result is sorted and holds each spelling once.
+func FindCloudConfigKeys(source string, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	searched := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		searched[strings.ToLower(key)] = true
+	}
+
+	found := sets.New[string]()
+	switch detectCloudConfigFormat(source) {
+	case "":
+		return nil, nil
+	case iniFormat:
+		cfg, err := ini.LoadSources(ini.LoadOptions{PreserveSurroundedQuote: true}, []byte(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+		for _, section := range cfg.Sections() {
+			for _, key := range section.KeyStrings() {
+				if searched[strings.ToLower(key)] {
+					found.Insert(key)
+				}
+			}
+		}
+	case jsonFormat:
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(source), &obj); err != nil {
+			return nil, fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+		findKeys(obj, searched, found)
+	default:
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(source), &obj); err != nil {
+			return nil, fmt.Errorf("failed to read the cloud config: %w", err)
+		}
+		findKeys(obj, searched, found)
+	}
+	return sets.List(found), nil
+}
+
+// findKeys inserts the keys of obj, and of the objects and lists it holds, which match the given lower cased keys
+// into found.
+func findKeys(obj interface{}, keys map[string]bool, found sets.Set[string]) {
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if keys[strings.ToLower(key)] {
+				found.Insert(key)
+				continue
+			}
+			findKeys(nested, keys, found)
+		}
+	case []interface{}:
+		for _, item := range value {
+			findKeys(item, keys, found)
+		}
+	}
+}
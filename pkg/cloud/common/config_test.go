@@ -0,0 +1,222 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeCloudConfigOverrides(t *testing.T) {
+	tc := []struct {
+		name      string
+		source    string
+		overrides string
+		expected  string
+		errMsg    string
+	}{{
+		name:     "No overrides",
+		source:   "[Global]\nZone = Foo\n",
+		expected: "[Global]\nZone = Foo\n",
+	}, {
+		name: "ini overrides",
+		source: `[Global]
+Zone   = Foo
+Region = us-east-1
+`,
+		overrides: `[Global]
+Zone = Bar
+
+[LoadBalancer]
+max-shared-lb = 2
+`,
+		expected: `[Global]
+Zone   = Bar
+Region = us-east-1
+
+[LoadBalancer]
+max-shared-lb = 2
+`,
+	}, {
+		name:      "JSON overrides",
+		source:    `{"cloud":"AzurePublicCloud","vmType":"standard","tagsMap":{"env":"prod","team":"ccm"},"excludeMasterFromStandardLB":true}`,
+		overrides: `{"vmType":"vmss","tagsMap":{"team":"cloud"},"excludeMasterFromStandardLB":null}`,
+		expected:  `{"cloud":"AzurePublicCloud","tagsMap":{"env":"prod","team":"cloud"},"vmType":"vmss"}`,
+	}, {
+		name: "YAML overrides",
+		source: `global:
+  insecureFlag: true
+  port: 443
+vcenter:
+  vcenter.example.com:
+    datacenters:
+    - dc1
+`,
+		overrides: `global:
+  insecureFlag: false
+vcenter:
+  vcenter.example.com:
+    datacenters:
+    - dc2
+`,
+		expected: `global:
+  insecureFlag: false
+  port: 443
+vcenter:
+  vcenter.example.com:
+    datacenters:
+    - dc2
+`,
+	}, {
+		name:      "Empty source",
+		source:    "",
+		overrides: `{"vmType":"vmss"}`,
+		expected:  `{"vmType":"vmss"}`,
+	}, {
+		name:      "Mismatching formats",
+		source:    "[Global]\nZone = Foo\n",
+		overrides: `{"vmType":"vmss"}`,
+		errMsg:    "cloud config overrides are in JSON format, expected ini",
+	}, {
+		name:      "Invalid overrides",
+		source:    `{"vmType":"standard"}`,
+		overrides: `{"vmType":`,
+		errMsg:    "failed to read the cloud config overrides: unexpected end of JSON input",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := MergeCloudConfigOverrides(tc.source, tc.overrides)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestStripCloudConfigKeys(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		keys     []string
+		expected string
+		errMsg   string
+	}{{
+		name:     "No keys",
+		source:   "[Global]\npassword = secret\n",
+		expected: "[Global]\npassword = secret\n",
+	}, {
+		name: "ini config",
+		source: `[Global]
+auth-url = https://keystone.example.com
+username = admin
+Password = secret
+
+[LoadBalancer]
+application-credential-secret = secret
+floating-network-id = public
+`,
+		keys: []string{"password", "application-credential-secret"},
+		expected: `[Global]
+auth-url = https://keystone.example.com
+username = admin
+
+[LoadBalancer]
+floating-network-id = public
+`,
+	}, {
+		name:     "JSON config",
+		source:   `{"cloud":"AzurePublicCloud","aadClientId":"id","aadClientSecret":"secret","nested":[{"aadclientsecret":"secret","keep":true}]}`,
+		keys:     []string{"aadClientSecret"},
+		expected: `{"aadClientId":"id","cloud":"AzurePublicCloud","nested":[{"keep":true}]}`,
+	}, {
+		name: "YAML config",
+		source: `prismCentral:
+  address: pc.example.com
+  password: secret
+  username: admin
+`,
+		keys: []string{"username", "password"},
+		expected: `prismCentral:
+  address: pc.example.com
+`,
+	}, {
+		name:   "Invalid config",
+		source: `{"cloud":`,
+		keys:   []string{"password"},
+		errMsg: "failed to read the cloud config: unexpected end of JSON input",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := StripCloudConfigKeys(tc.source, tc.keys)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFindCloudConfigKeys(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		keys     []string
+		expected []string
+		errMsg   string
+	}{{
+		name:   "No keys",
+		source: "[Global]\npassword = secret\n",
+	}, {
+		name:   "Empty config",
+		source: "",
+		keys:   []string{"password"},
+	}, {
+		name: "ini config",
+		source: `[Global]
+username = admin
+Password = secret
+
+[LoadBalancer]
+password = secret
+floating-network-id = public
+`,
+		keys:     []string{"password", "application-credential-secret"},
+		expected: []string{"Password", "password"},
+	}, {
+		name:     "JSON config",
+		source:   `{"cloud":"AzurePublicCloud","aadClientId":"id","nested":[{"aadclientsecret":"secret"}]}`,
+		keys:     []string{"aadClientSecret"},
+		expected: []string{"aadclientsecret"},
+	}, {
+		name: "YAML config",
+		source: `prismCentral:
+  address: pc.example.com
+  username: admin
+`,
+		keys:     []string{"username", "password"},
+		expected: []string{"username"},
+	}, {
+		name:   "Invalid config",
+		source: `{"cloud":`,
+		keys:   []string{"password"},
+		errMsg: "failed to read the cloud config: unexpected end of JSON input",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := FindCloudConfigKeys(tc.source, tc.keys)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	containerResourceRequests = "requests"
+	containerResourceLimits   = "limits"
+)
+
+// ParseContainerResources parses admin-provided resource overrides of the operand containers, keyed by
+// "<container>.<requests|limits>.<cpu|memory>", e.g. "cloud-controller-manager.requests.memory": "200Mi".
+// It returns an error if a key or quantity is invalid, or if a limit is lower than the request of the same resource.
+func ParseContainerResources(overrides map[string]string) (map[string]corev1.ResourceRequirements, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resources := map[string]corev1.ResourceRequirements{}
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid container resource key %q, expected <container>.<requests|limits>.<cpu|memory>", key)
+		}
+		containerName, kind, name := parts[0], parts[1], corev1.ResourceName(parts[2])
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+			return nil, fmt.Errorf("unsupported resource %q in container resource key %q, expected %s or %s", name, key, corev1.ResourceCPU, corev1.ResourceMemory)
+		}
+		quantity, err := resource.ParseQuantity(overrides[key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for container resource %q: %w", overrides[key], key, err)
+		}
+		if quantity.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid quantity %q for container resource %q, must be positive", overrides[key], key)
+		}
+
+		requirements := resources[containerName]
+		switch kind {
+		case containerResourceRequests:
+			if requirements.Requests == nil {
+				requirements.Requests = corev1.ResourceList{}
+			}
+			requirements.Requests[name] = quantity
+		case containerResourceLimits:
+			if requirements.Limits == nil {
+				requirements.Limits = corev1.ResourceList{}
+			}
+			requirements.Limits[name] = quantity
+		default:
+			return nil, fmt.Errorf("invalid container resource key %q, expected %s or %s after the container name", key, containerResourceRequests, containerResourceLimits)
+		}
+		resources[containerName] = requirements
+	}
+
+	for containerName, requirements := range resources {
+		for name, limit := range requirements.Limits {
+			if request, ok := requirements.Requests[name]; ok && limit.Cmp(request) < 0 {
+				return nil, fmt.Errorf("%s limit %s of the %s container is lower than its request %s", name, limit.String(), containerName, request.String())
+			}
+		}
+	}
+	return resources, nil
+}
+
+// setContainerResources overrides the resource requests and limits of the containers in provided pod spec with the
+// given ones, keyed by container name. Resources which are not overridden keep the values set by the assets.
+func setContainerResources(resources map[string]corev1.ResourceRequirements, p corev1.PodSpec) corev1.PodSpec {
+	if len(resources) == 0 {
+		return p
+	}
+	for i := range p.Containers {
+		container := &p.Containers[i]
+		requirements, ok := resources[container.Name]
+		if !ok {
+			continue
+		}
+		container.Resources.Requests = mergeResourceList(container.Resources.Requests, requirements.Requests)
+		container.Resources.Limits = mergeResourceList(container.Resources.Limits, requirements.Limits)
+	}
+	return p
+}
+
+func mergeResourceList(list, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(overrides) == 0 {
+		return list
+	}
+	if list == nil {
+		list = corev1.ResourceList{}
+	}
+	for name, quantity := range overrides {
+		list[name] = quantity
+	}
+	return list
+}
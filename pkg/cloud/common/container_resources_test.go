@@ -0,0 +1,126 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseContainerResources(t *testing.T) {
+	tc := []struct {
+		name      string
+		overrides map[string]string
+		expected  map[string]corev1.ResourceRequirements
+		errMsg    string
+	}{{
+		name: "No overrides",
+	}, {
+		name: "Requests and limits",
+		overrides: map[string]string{
+			"cloud-controller-manager.requests.cpu":    "400m",
+			"cloud-controller-manager.requests.memory": "200Mi",
+			"cloud-controller-manager.limits.memory":   "1Gi",
+			"cloud-node-manager.requests.cpu":          "10m",
+		},
+		expected: map[string]corev1.ResourceRequirements{
+			"cloud-controller-manager": {
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("400m"),
+					corev1.ResourceMemory: resource.MustParse("200Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			"cloud-node-manager": {
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("10m"),
+				},
+			},
+		},
+	}, {
+		name:      "Key without container name",
+		overrides: map[string]string{"requests.cpu": "400m"},
+		errMsg:    `invalid container resource key "requests.cpu", expected <container>.<requests|limits>.<cpu|memory>`,
+	}, {
+		name:      "Unknown requirement kind",
+		overrides: map[string]string{"cloud-controller-manager.claims.cpu": "400m"},
+		errMsg:    `invalid container resource key "cloud-controller-manager.claims.cpu", expected requests or limits after the container name`,
+	}, {
+		name:      "Unsupported resource",
+		overrides: map[string]string{"cloud-controller-manager.requests.ephemeral-storage": "1Gi"},
+		errMsg:    `unsupported resource "ephemeral-storage" in container resource key "cloud-controller-manager.requests.ephemeral-storage", expected cpu or memory`,
+	}, {
+		name:      "Invalid quantity",
+		overrides: map[string]string{"cloud-controller-manager.requests.memory": "a lot"},
+		errMsg:    `invalid quantity "a lot" for container resource "cloud-controller-manager.requests.memory": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+	}, {
+		name:      "Zero quantity",
+		overrides: map[string]string{"cloud-controller-manager.requests.cpu": "0"},
+		errMsg:    `invalid quantity "0" for container resource "cloud-controller-manager.requests.cpu", must be positive`,
+	}, {
+		name: "Limit lower than request",
+		overrides: map[string]string{
+			"cloud-controller-manager.requests.memory": "200Mi",
+			"cloud-controller-manager.limits.memory":   "100Mi",
+		},
+		errMsg: "memory limit 100Mi of the cloud-controller-manager container is lower than its request 200Mi",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, err := ParseContainerResources(tc.overrides)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, resources)
+		})
+	}
+}
+
+func TestSetContainerResources(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name: "cloud-controller-manager",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("200m"),
+					corev1.ResourceMemory: resource.MustParse("50Mi"),
+				},
+			},
+		}, {
+			Name: "sidecar",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("10m"),
+				},
+			},
+		}},
+	}
+
+	spec := setContainerResources(map[string]corev1.ResourceRequirements{
+		"cloud-controller-manager": {
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("200Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}, podSpec)
+
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("200m"),
+			corev1.ResourceMemory: resource.MustParse("200Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}, spec.Containers[0].Resources)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("10m"),
+		},
+	}, spec.Containers[1].Resources, "containers without overrides must be left untouched")
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envFileValueRegexp restricts values put into environment files, as they are expanded by the shell
+// in the cloud-controller-manager container command.
+var envFileValueRegexp = regexp.MustCompile(`^[A-Za-z0-9._:/-]*$`)
+
+// RenderEnvFile renders the given variables into an environment file, one sorted KEY=value pair per line.
+// It is intended for cloud-controller-managers which are configured through environment variables only,
+// and load the file synced into the cloud-conf ConfigMap in their container command.
+func RenderEnvFile(env map[string]string) (string, error) {
+	keys := make([]string, 0, len(env))
+	for key, value := range env {
+		if !envFileValueRegexp.MatchString(value) {
+			return "", fmt.Errorf("invalid value %q for %s, only alphanumeric characters, '.', '_', ':', '/' and '-' are allowed", value, key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&out, "%s=%s\n", key, env[key])
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEnvFile(t *testing.T) {
+	tc := []struct {
+		name        string
+		env         map[string]string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name: "Empty",
+		}, {
+			name: "Keys are sorted",
+			env: map[string]string{
+				"FOO_URL": "https://example.com/v4",
+				"BAR":     "bar-1.2_3",
+			},
+			expected: "BAR=bar-1.2_3\nFOO_URL=https://example.com/v4\n",
+		}, {
+			name:        "Value which is not safe to expand in the shell",
+			env:         map[string]string{"FOO": "$(reboot)"},
+			expectedErr: "invalid value \"$(reboot)\" for FOO, only alphanumeric characters, '.', '_', ':', '/' and '-' are allowed",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := RenderEnvFile(tc.env)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package common
+
+import "strings"
+
+// maxTypoDistance is the maximum number of single-character edits between an unknown option and a known one
+// for the former to be reported as a typo of the latter.
+const maxTypoDistance = 2
+
+// ClosestOption returns the known option the given unknown one is most likely a typo of, i.e. the closest one
+// within maxTypoDistance edits regardless of case, or an empty string if there is none.
+func ClosestOption(name string, options []string) string {
+	closest, closestDistance := "", maxTypoDistance+1
+	for _, option := range options {
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(option)); distance < closestDistance {
+			closest, closestDistance = option, distance
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestOption(t *testing.T) {
+	options := []string{"project-id", "network-name", "network-project-id", "multizone"}
+
+	tc := []struct {
+		name     string
+		option   string
+		options  []string
+		expected string
+	}{{
+		name:     "Missing character",
+		option:   "projct-id",
+		options:  options,
+		expected: "project-id",
+	}, {
+		name:     "Different case",
+		option:   "MultiZone",
+		options:  options,
+		expected: "multizone",
+	}, {
+		name:     "Closest option wins",
+		option:   "network-nam",
+		options:  options,
+		expected: "network-name",
+	}, {
+		name:    "Too far from any option",
+		option:  "subnetwork",
+		options: options,
+	}, {
+		name:   "No options",
+		option: "project-id",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ClosestOption(tc.option, tc.options))
+		})
+	}
+}
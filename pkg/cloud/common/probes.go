@@ -0,0 +1,90 @@
+package common
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProbeParameters holds the timing parameters of a container probe.
+// Zero values keep the ones set in the asset, or the Kubernetes defaults.
+type ProbeParameters struct {
+	InitialDelaySeconds int32
+	PeriodSeconds       int32
+	TimeoutSeconds      int32
+	FailureThreshold    int32
+}
+
+// ContainerProbes holds the parameters of the liveness, readiness and startup probes of a container.
+// A nil entry leaves the corresponding probe untouched.
+type ContainerProbes struct {
+	Liveness  *ProbeParameters
+	Readiness *ProbeParameters
+	Startup   *ProbeParameters
+}
+
+// ProbesProvider is implemented by CloudProviderAssets which declare the probe parameters of their containers,
+// keyed by container name, so the operator can patch them consistently across platforms.
+type ProbesProvider interface {
+	GetContainerProbes() map[string]ContainerProbes
+}
+
+// SetContainerProbes patches the probes of the Deployment and DaemonSet containers in provided objects
+// with the given parameters.
+func SetContainerProbes(probes map[string]ContainerProbes, objects []client.Object) []client.Object {
+	if len(probes) == 0 {
+		return objects
+	}
+	for _, obj := range objects {
+		switch obj := obj.(type) {
+		case *appsv1.Deployment:
+			obj.Spec.Template.Spec = setContainerProbes(probes, obj.Spec.Template.Spec)
+		case *appsv1.DaemonSet:
+			obj.Spec.Template.Spec = setContainerProbes(probes, obj.Spec.Template.Spec)
+		}
+	}
+	return objects
+}
+
+func setContainerProbes(probes map[string]ContainerProbes, p corev1.PodSpec) corev1.PodSpec {
+	for i := range p.Containers {
+		container := &p.Containers[i]
+		containerProbes, ok := probes[container.Name]
+		if !ok {
+			continue
+		}
+		container.LivenessProbe = tuneProbe(container.Name, "liveness", container.LivenessProbe, containerProbes.Liveness, nil)
+		container.ReadinessProbe = tuneProbe(container.Name, "readiness", container.ReadinessProbe, containerProbes.Readiness, container.LivenessProbe)
+		container.StartupProbe = tuneProbe(container.Name, "startup", container.StartupProbe, containerProbes.Startup, container.LivenessProbe)
+	}
+	return p
+}
+
+// tuneProbe applies the given parameters to the probe. If the asset does not define the probe, its handler
+// is taken from the fallback one, which is the liveness probe for readiness and startup probes.
+func tuneProbe(containerName, kind string, probe *corev1.Probe, params *ProbeParameters, fallback *corev1.Probe) *corev1.Probe {
+	if params == nil {
+		return probe
+	}
+	if probe == nil {
+		if fallback == nil {
+			klog.Warningf("can not tune %s probe of the %s container, it has no probe handler", kind, containerName)
+			return nil
+		}
+		probe = &corev1.Probe{ProbeHandler: *fallback.ProbeHandler.DeepCopy()}
+	}
+	if params.InitialDelaySeconds != 0 {
+		probe.InitialDelaySeconds = params.InitialDelaySeconds
+	}
+	if params.PeriodSeconds != 0 {
+		probe.PeriodSeconds = params.PeriodSeconds
+	}
+	if params.TimeoutSeconds != 0 {
+		probe.TimeoutSeconds = params.TimeoutSeconds
+	}
+	if params.FailureThreshold != 0 {
+		probe.FailureThreshold = params.FailureThreshold
+	}
+	return probe
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetContainerProbes(t *testing.T) {
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   "/healthz",
+			Port:   intstr.FromInt(10258),
+			Scheme: corev1.URISchemeHTTPS,
+		},
+	}
+
+	tc := []struct {
+		name               string
+		containers         []corev1.Container
+		probes             map[string]ContainerProbes
+		expectedContainers []corev1.Container
+	}{{
+		name: "No probes declared",
+		containers: []corev1.Container{{
+			Name:          "cloud-controller-manager",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler, TimeoutSeconds: 5},
+		}},
+		expectedContainers: []corev1.Container{{
+			Name:          "cloud-controller-manager",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler, TimeoutSeconds: 5},
+		}},
+	}, {
+		name: "Tune liveness probe, keeping unset parameters",
+		containers: []corev1.Container{{
+			Name:          "cloud-controller-manager",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler, TimeoutSeconds: 5, PeriodSeconds: 20},
+		}},
+		probes: map[string]ContainerProbes{
+			"cloud-controller-manager": {
+				Liveness: &ProbeParameters{InitialDelaySeconds: 300, TimeoutSeconds: 160},
+			},
+		},
+		expectedContainers: []corev1.Container{{
+			Name:          "cloud-controller-manager",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler, InitialDelaySeconds: 300, TimeoutSeconds: 160, PeriodSeconds: 20},
+		}},
+	}, {
+		name: "Add startup probe from liveness probe handler",
+		containers: []corev1.Container{{
+			Name:          "cloud-controller-manager",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler},
+		}},
+		probes: map[string]ContainerProbes{
+			"cloud-controller-manager": {
+				Startup: &ProbeParameters{PeriodSeconds: 10, FailureThreshold: 30},
+			},
+		},
+		expectedContainers: []corev1.Container{{
+			Name:          "cloud-controller-manager",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler},
+			StartupProbe:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, FailureThreshold: 30},
+		}},
+	}, {
+		name: "Skip probes without handler",
+		containers: []corev1.Container{{
+			Name: "cloud-controller-manager",
+		}},
+		probes: map[string]ContainerProbes{
+			"cloud-controller-manager": {
+				Readiness: &ProbeParameters{PeriodSeconds: 10},
+			},
+		},
+		expectedContainers: []corev1.Container{{
+			Name: "cloud-controller-manager",
+		}},
+	}, {
+		name: "Leave other containers untouched",
+		containers: []corev1.Container{{
+			Name:          "config-sync-controllers",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler, TimeoutSeconds: 5},
+		}},
+		probes: map[string]ContainerProbes{
+			"cloud-controller-manager": {
+				Liveness: &ProbeParameters{TimeoutSeconds: 160},
+			},
+		},
+		expectedContainers: []corev1.Container{{
+			Name:          "config-sync-controllers",
+			LivenessProbe: &corev1.Probe{ProbeHandler: handler, TimeoutSeconds: 5},
+		}},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{}
+			deployment.Spec.Template.Spec.Containers = tc.containers
+			daemonSet := &appsv1.DaemonSet{}
+			daemonSet.Spec.Template.Spec.Containers = tc.containers
+
+			objects := SetContainerProbes(tc.probes, []client.Object{deployment.DeepCopy(), daemonSet.DeepCopy()})
+
+			assert.Equal(t, tc.expectedContainers, objects[0].(*appsv1.Deployment).Spec.Template.Spec.Containers)
+			assert.Equal(t, tc.expectedContainers, objects[1].(*appsv1.DaemonSet).Spec.Template.Spec.Containers)
+		})
+	}
+}
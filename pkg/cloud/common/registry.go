@@ -0,0 +1,104 @@
+package common
+
+import (
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/util"
+)
+
+// CloudConfigTransformer transforms the source cloud config using the input infrastructure.config.openshift.io
+// and network.config.openshift.io objects.
+type CloudConfigTransformer func(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error)
+
+// CloudProvider describes a cloud provider the operator deploys the cloud controller manager for.
+// Provider packages register it with RegisterCloudProvider from their init function, so adding a new cloud
+// does not require changes outside of its own package, besides importing it in pkg/cloud.
+type CloudProvider struct {
+	// Name identifies the provider, it has to be unique.
+	Name string
+	// PlatformType is the platform type the provider is deployed on.
+	PlatformType configv1.PlatformType
+	// Matches tells apart the providers registered for the same platform type, e.g. the providers running on
+	// the External platform type. A provider without it is picked when no other provider of its platform type matches,
+	// there can be only one such provider per platform type.
+	Matches func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool
+	// NewAssets constructs the provider assets, it is expected to validate the images it needs.
+	NewAssets func(config config.OperatorConfig) (CloudProviderAssets, error)
+	// CloudConfigTransformer transforms the provider cloud config. It is nil for providers without cloud config.
+	CloudConfigTransformer CloudConfigTransformer
+	// CloudConfigSyncedFromCCO is true if the cloud config is transformed by the CCO, and thus expected to be
+	// synced from the CCO namespace before being transformed. The CCO-managed config is preferred on every platform.
+	// TODO: this can be removed once we migrate the AWS and Azure logic from the CCO to this operator.
+	CloudConfigSyncedFromCCO bool
+	// CloudConfigValidator checks the synced cloud config against the schema the provider expects, e.g. required
+	// fields, value types and mutually exclusive options. It is optional.
+	CloudConfigValidator func(config string) error
+	// CloudConfigLinter reports the issues of the synced cloud config which do not prevent the provider from
+	// starting but are likely mistakes, e.g. deprecated options, typos in option names or conflicting options.
+	// Each finding carries a hint to fix it. It is optional.
+	CloudConfigLinter func(config string) []string
+	// CloudConfigKey is the key of the synced cloud config ConfigMap the cloud config is written to, i.e. the
+	// file name the provider assets mount it as. It defaults to cloud.conf.
+	CloudConfigKey string
+	// CloudConfigSensitiveKeys lists the cloud config keys holding secret material, e.g. passwords. They are
+	// stripped from cloud configs sourced from a Secret before those are published in the synced ConfigMap.
+	CloudConfigSensitiveKeys []string
+	// AllowedArgs lists the provider specific cloud-controller-manager flags admins are allowed to set.
+	AllowedArgs []string
+	// TrustedCABundle holds extra root CAs, PEM encoded, the provider ships with its assets, e.g. the CAs of
+	// regional cloud endpoints missing from the system trust store. They are merged into the trusted CA bundle
+	// of the cloud controller manager when the provider is active. It is optional.
+	TrustedCABundle []byte
+}
+
+var cloudProviders []CloudProvider
+
+// RegisterCloudProvider registers a cloud provider. It is meant to be called from the provider package init
+// function and panics on invalid or conflicting registrations.
+func RegisterCloudProvider(provider CloudProvider) {
+	if provider.Name == "" || provider.PlatformType == "" || provider.NewAssets == nil {
+		panic(fmt.Sprintf("cloud provider %q: name, platform type and assets constructor are required", provider.Name))
+	}
+	if len(provider.TrustedCABundle) > 0 {
+		if err := util.ValidateCertificateData(provider.TrustedCABundle); err != nil {
+			panic(fmt.Sprintf("cloud provider %q: invalid trusted CA bundle: %v", provider.Name, err))
+		}
+	}
+	for _, registered := range cloudProviders {
+		if registered.Name == provider.Name {
+			panic(fmt.Sprintf("cloud provider %q is already registered", provider.Name))
+		}
+		if registered.PlatformType == provider.PlatformType && registered.Matches == nil && provider.Matches == nil {
+			panic(fmt.Sprintf("cloud provider %q: %q is already registered as the default provider for %s platform",
+				provider.Name, registered.Name, provider.PlatformType))
+		}
+	}
+	cloudProviders = append(cloudProviders, provider)
+}
+
+// LookupCloudProvider returns the registered cloud provider for the given platform status and External platform name,
+// and false if there is none.
+func LookupCloudProvider(platformStatus *configv1.PlatformStatus, externalPlatformName string) (CloudProvider, bool) {
+	var fallback *CloudProvider
+	for i := range cloudProviders {
+		provider := &cloudProviders[i]
+		if provider.PlatformType != platformStatus.Type {
+			continue
+		}
+		if provider.Matches == nil {
+			fallback = provider
+			continue
+		}
+		if provider.Matches(platformStatus, externalPlatformName) {
+			return *provider, true
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return CloudProvider{}, false
+}
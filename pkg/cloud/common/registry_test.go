@@ -0,0 +1,95 @@
+package common
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestLookupCloudProvider(t *testing.T) {
+	registered := cloudProviders
+	defer func() { cloudProviders = registered }()
+	cloudProviders = nil
+
+	newAssets := func(config.OperatorConfig) (CloudProviderAssets, error) { return nil, nil }
+	RegisterCloudProvider(CloudProvider{Name: "azure", PlatformType: configv1.AzurePlatformType, NewAssets: newAssets})
+	RegisterCloudProvider(CloudProvider{
+		Name:         "azurestack",
+		PlatformType: configv1.AzurePlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, _ string) bool {
+			return platformStatus.Azure != nil && platformStatus.Azure.CloudName == configv1.AzureStackCloud
+		},
+		NewAssets: newAssets,
+	})
+	RegisterCloudProvider(CloudProvider{
+		Name:         "oci",
+		PlatformType: configv1.ExternalPlatformType,
+		Matches: func(_ *configv1.PlatformStatus, externalPlatformName string) bool {
+			return externalPlatformName == "oci"
+		},
+		NewAssets: newAssets,
+	})
+
+	tc := []struct {
+		name                 string
+		platformStatus       *configv1.PlatformStatus
+		externalPlatformName string
+		expectedProvider     string
+	}{{
+		name:             "Default provider of the platform type",
+		platformStatus:   &configv1.PlatformStatus{Type: configv1.AzurePlatformType},
+		expectedProvider: "azure",
+	}, {
+		name: "Matching provider takes precedence over the default one",
+		platformStatus: &configv1.PlatformStatus{
+			Type:  configv1.AzurePlatformType,
+			Azure: &configv1.AzurePlatformStatus{CloudName: configv1.AzureStackCloud},
+		},
+		expectedProvider: "azurestack",
+	}, {
+		name:                 "External provider matched by name",
+		platformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+		externalPlatformName: "oci",
+		expectedProvider:     "oci",
+	}, {
+		name:                 "Unknown External provider",
+		platformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+		externalPlatformName: "unknown",
+	}, {
+		name:           "Platform type without provider",
+		platformStatus: &configv1.PlatformStatus{Type: configv1.BareMetalPlatformType},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, found := LookupCloudProvider(tc.platformStatus, tc.externalPlatformName)
+			assert.Equal(t, tc.expectedProvider != "", found)
+			assert.Equal(t, tc.expectedProvider, provider.Name)
+		})
+	}
+}
+
+func TestRegisterCloudProviderConflicts(t *testing.T) {
+	registered := cloudProviders
+	defer func() { cloudProviders = registered }()
+	cloudProviders = nil
+
+	newAssets := func(config.OperatorConfig) (CloudProviderAssets, error) { return nil, nil }
+	RegisterCloudProvider(CloudProvider{Name: "aws", PlatformType: configv1.AWSPlatformType, NewAssets: newAssets})
+
+	assert.PanicsWithValue(t, `cloud provider "aws" is already registered`, func() {
+		RegisterCloudProvider(CloudProvider{Name: "aws", PlatformType: configv1.GCPPlatformType, NewAssets: newAssets})
+	})
+	assert.PanicsWithValue(t, `cloud provider "other": "aws" is already registered as the default provider for AWS platform`, func() {
+		RegisterCloudProvider(CloudProvider{Name: "other", PlatformType: configv1.AWSPlatformType, NewAssets: newAssets})
+	})
+	assert.PanicsWithValue(t, `cloud provider "gcp": name, platform type and assets constructor are required`, func() {
+		RegisterCloudProvider(CloudProvider{Name: "gcp", PlatformType: configv1.GCPPlatformType})
+	})
+	assert.PanicsWithValue(t, `cloud provider "gcp": invalid trusted CA bundle: failed to parse certificate PEM`, func() {
+		RegisterCloudProvider(CloudProvider{Name: "gcp", PlatformType: configv1.GCPPlatformType, NewAssets: newAssets, TrustedCABundle: []byte("kekeke")})
+	})
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
@@ -18,9 +20,45 @@ const (
 	CloudNodeManagerCloudProviderLabel  = "infrastructure.openshift.io/cloud-node-manager"
 )
 
+// RemoveCloudNodeManager filters out the cloud-node-manager DaemonSets, identified by the
+// CloudNodeManagerCloudProviderLabel, from provided objects.
+func RemoveCloudNodeManager(objects []client.Object) []client.Object {
+	filtered := make([]client.Object, 0, len(objects))
+	for _, obj := range objects {
+		if _, isDaemonSet := obj.(*appsv1.DaemonSet); isDaemonSet {
+			if _, isNodeManager := obj.GetLabels()[CloudNodeManagerCloudProviderLabel]; isNodeManager {
+				klog.Infof("Cloud node manager is disabled, skipping DaemonSet %s", client.ObjectKeyFromObject(obj))
+				continue
+			}
+		}
+		filtered = append(filtered, obj)
+	}
+	return filtered
+}
+
+// RemoveCloudControllerManager filters out the cloud-controller-manager Deployments, identified by the
+// CloudControllerManagerProviderLabel, from provided objects.
+func RemoveCloudControllerManager(objects []client.Object) []client.Object {
+	filtered := make([]client.Object, 0, len(objects))
+	for _, obj := range objects {
+		if _, isDeployment := obj.(*appsv1.Deployment); isDeployment {
+			if _, isControllerManager := obj.GetLabels()[CloudControllerManagerProviderLabel]; isControllerManager {
+				klog.Infof("Control plane is external, skipping Deployment %s", client.ObjectKeyFromObject(obj))
+				continue
+			}
+		}
+		filtered = append(filtered, obj)
+	}
+	return filtered
+}
+
 func GetCommonResources(config config.OperatorConfig) ([]client.Object, error) {
 	commonResources := []client.Object{}
-	if !config.IsSingleReplica {
+	if config.IsControlPlaneExternal() {
+		// The cloud-controller-manager runs along with the control plane, outside of the cluster.
+		return nil, nil
+	}
+	if !config.IsSingleReplica() {
 		pdb, err := getPDB(config)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,76 @@
+package common
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Sidecar is a container injected next to the cloud-controller-manager one, e.g. a credentials proxy
+// or a token refresher, along with the volumes it mounts.
+type Sidecar struct {
+	Container corev1.Container
+	Volumes   []corev1.Volume
+}
+
+// SidecarsProvider is implemented by CloudProviderAssets which need sidecars in the cloud-controller-manager
+// Deployment, depending on the platform or on the credentials mode found in their operator config.
+type SidecarsProvider interface {
+	GetSidecars() []Sidecar
+}
+
+// InjectSidecars returns copies of provided objects, with the given sidecars injected into the Deployments
+// running the cloud-controller-manager container. Containers and volumes already defined in the asset are kept.
+func InjectSidecars(sidecars []Sidecar, objects []client.Object) []client.Object {
+	if len(sidecars) == 0 {
+		return objects
+	}
+	injected := make([]client.Object, len(objects))
+	for i, obj := range objects {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok || !hasContainer(deployment.Spec.Template.Spec, cloudControllerManagerContainerName) {
+			injected[i] = obj
+			continue
+		}
+		deployment = deployment.DeepCopy()
+		deployment.Spec.Template.Spec = injectSidecars(sidecars, deployment.Spec.Template.Spec)
+		injected[i] = deployment
+	}
+	return injected
+}
+
+func injectSidecars(sidecars []Sidecar, p corev1.PodSpec) corev1.PodSpec {
+	for _, sidecar := range sidecars {
+		if hasContainer(p, sidecar.Container.Name) {
+			klog.Warningf("container %q is already defined, skipping sidecar injection", sidecar.Container.Name)
+			continue
+		}
+		p.Containers = append(p.Containers, *sidecar.Container.DeepCopy())
+		for _, volume := range sidecar.Volumes {
+			if hasVolume(p, volume.Name) {
+				continue
+			}
+			p.Volumes = append(p.Volumes, *volume.DeepCopy())
+		}
+	}
+	return p
+}
+
+func hasContainer(p corev1.PodSpec, name string) bool {
+	for _, container := range p.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(p corev1.PodSpec, name string) bool {
+	for _, volume := range p.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
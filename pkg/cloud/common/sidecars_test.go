@@ -0,0 +1,71 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestInjectSidecars(t *testing.T) {
+	ccmContainer := corev1.Container{Name: cloudControllerManagerContainerName}
+	credentialsVolume := corev1.Volume{Name: "credentials"}
+	tokenProxy := Sidecar{
+		Container: corev1.Container{Name: "token-proxy"},
+		Volumes:   []corev1.Volume{credentialsVolume, {Name: "token"}},
+	}
+
+	tc := []struct {
+		name               string
+		sidecars           []Sidecar
+		podSpec            corev1.PodSpec
+		expectedContainers []corev1.Container
+		expectedVolumes    []corev1.Volume
+	}{{
+		name:               "No sidecars",
+		podSpec:            corev1.PodSpec{Containers: []corev1.Container{ccmContainer}},
+		expectedContainers: []corev1.Container{ccmContainer},
+	}, {
+		name:     "Inject sidecar and its volumes",
+		sidecars: []Sidecar{tokenProxy},
+		podSpec: corev1.PodSpec{
+			Containers: []corev1.Container{ccmContainer},
+			Volumes:    []corev1.Volume{credentialsVolume},
+		},
+		expectedContainers: []corev1.Container{ccmContainer, {Name: "token-proxy"}},
+		expectedVolumes:    []corev1.Volume{credentialsVolume, {Name: "token"}},
+	}, {
+		name:     "Keep containers defined in the asset",
+		sidecars: []Sidecar{tokenProxy},
+		podSpec: corev1.PodSpec{
+			Containers: []corev1.Container{ccmContainer, {Name: "token-proxy", Image: "asset"}},
+		},
+		expectedContainers: []corev1.Container{ccmContainer, {Name: "token-proxy", Image: "asset"}},
+	}, {
+		name:               "Skip Deployments without cloud-controller-manager container",
+		sidecars:           []Sidecar{tokenProxy},
+		podSpec:            corev1.PodSpec{Containers: []corev1.Container{{Name: "cloud-node-manager"}}},
+		expectedContainers: []corev1.Container{{Name: "cloud-node-manager"}},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{}
+			deployment.Spec.Template.Spec = tc.podSpec
+			daemonSet := &appsv1.DaemonSet{}
+			daemonSet.Spec.Template.Spec = *tc.podSpec.DeepCopy()
+			original := deployment.DeepCopy()
+
+			objects := InjectSidecars(tc.sidecars, []client.Object{deployment, daemonSet})
+
+			podSpec := objects[0].(*appsv1.Deployment).Spec.Template.Spec
+			assert.Equal(t, tc.expectedContainers, podSpec.Containers)
+			assert.Equal(t, tc.expectedVolumes, podSpec.Volumes)
+			// Sidecars are only injected in Deployments, and provided objects are left untouched.
+			assert.Equal(t, tc.podSpec, objects[1].(*appsv1.DaemonSet).Spec.Template.Spec)
+			assert.Equal(t, original, deployment)
+		})
+	}
+}
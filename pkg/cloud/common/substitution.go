@@ -1,9 +1,17 @@
 package common
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
 	configv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -11,7 +19,58 @@ import (
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
 )
 
-// setProxySettings substitutes controller containers in provided pod specs with cluster wide proxy settings
+// cloudControllerManagerContainerName is the name of the cloud-controller-manager container in the assets.
+const cloudControllerManagerContainerName = "cloud-controller-manager"
+
+// featureGatesArg is the cloud-controller-manager flag the feature gates of the cluster are passed with.
+const featureGatesArg = "feature-gates"
+
+// verbosityArg is the klog flag setting the log level of the operands.
+const verbosityArg = "v"
+
+const (
+	// clusterCIDRArg is the cloud-controller-manager flag the pod network CIDRs of the cluster are passed with.
+	clusterCIDRArg = "cluster-cidr"
+	// configureCloudRoutesArg and allocateNodeCIDRsArg enable the cloud-controller-manager controllers which need
+	// the cluster CIDR, the route controller and the node IPAM.
+	configureCloudRoutesArg = "configure-cloud-routes"
+	allocateNodeCIDRsArg    = "allocate-node-cidrs"
+)
+
+// highlyAvailableReplicas is the number of replicas of the Deployments on highly available control planes,
+// spread across control plane nodes by the pod anti affinity of the assets.
+const highlyAvailableReplicas = 2
+
+const (
+	// Env variables exposing the identity of the cluster to the operand containers, e.g. for the --cluster-name
+	// flag or the tag filters of the cloud controller managers.
+	infrastructureNameEnvVar = "OCP_INFRASTRUCTURE_NAME"
+	clusterIDEnvVar          = "OCP_CLUSTER_ID"
+	// Env variables exposing the endpoints of the cluster to the operand containers, e.g. for hosted control planes
+	// or setups where the API server is only reachable through its external endpoint.
+	apiServerURLEnvVar         = "OCP_API_SERVER_URL"
+	apiServerInternalURLEnvVar = "OCP_API_SERVER_INTERNAL_URL"
+	baseDomainEnvVar           = "OCP_BASE_DOMAIN"
+	// Labels of the operand pod templates identifying the cluster they manage the cloud resources of.
+	infrastructureNameLabel = "ccm.openshift.io/infrastructure-name"
+	clusterIDLabel          = "ccm.openshift.io/cluster-id"
+)
+
+// getReplicas returns the replicas of a Deployment for the control plane topology. A replica count
+// set in the asset, e.g. in user-supplied manifests, is kept on highly available control planes.
+func getReplicas(config config.OperatorConfig, replicas *int32) *int32 {
+	if config.IsSingleReplica() {
+		return ptr.To[int32](1)
+	}
+	if replicas != nil {
+		return replicas
+	}
+	return ptr.To[int32](highlyAvailableReplicas)
+}
+
+// setProxySettings substitutes controller containers in provided pod specs with cluster wide proxy settings.
+// Init containers get them too, and proxy variables already set by the assets are overridden, so every container
+// reaches the cloud API through the cluster proxy. Changes of the proxy change the pod template, rolling the pods.
 func setProxySettings(config config.OperatorConfig, p corev1.PodSpec) corev1.PodSpec {
 	clusterProxyEnvVars := getProxyArgs(config.ClusterProxy)
 	if len(clusterProxyEnvVars) == 0 {
@@ -19,14 +78,77 @@ func setProxySettings(config config.OperatorConfig, p corev1.PodSpec) corev1.Pod
 	}
 
 	updatedPod := *p.DeepCopy()
-	for i, container := range p.Containers {
-		klog.Infof("Substituting proxy settings for container %q", container.Name)
-		updatedPod.Containers[i].Env = append(updatedPod.Containers[i].Env, clusterProxyEnvVars...)
+	for _, containers := range [][]corev1.Container{updatedPod.InitContainers, updatedPod.Containers} {
+		for i := range containers {
+			klog.Infof("Substituting proxy settings for container %q", containers[i].Name)
+			containers[i].Env = setEnvVars(containers[i].Env, clusterProxyEnvVars)
+		}
 	}
 
 	return updatedPod
 }
 
+// setEnvVars overrides the value of the given env variables in env, or appends the ones it does not set.
+func setEnvVars(env []corev1.EnvVar, vars []corev1.EnvVar) []corev1.EnvVar {
+	for _, envVar := range vars {
+		i := slices.IndexFunc(env, func(existing corev1.EnvVar) bool { return existing.Name == envVar.Name })
+		if i == -1 {
+			env = append(env, envVar)
+			continue
+		}
+		env[i] = envVar
+	}
+	return env
+}
+
+// setClusterIdentity exposes the infrastructure name and the cluster ID to the containers of provided pod template
+// as env variables, so the assets can pass them to flags or configs, and labels the pod template with them.
+// Env variables already set by the assets are kept, as are values which are not valid label values.
+func setClusterIdentity(config config.OperatorConfig, template *corev1.PodTemplateSpec) {
+	identity := []struct {
+		envVar, label, value string
+	}{
+		{infrastructureNameEnvVar, infrastructureNameLabel, config.InfrastructureName},
+		{clusterIDEnvVar, clusterIDLabel, config.ClusterID},
+	}
+	for _, id := range identity {
+		if id.value == "" {
+			continue
+		}
+		setContainersEnv(template, id.envVar, id.value)
+		if len(validation.IsValidLabelValue(id.value)) != 0 {
+			continue
+		}
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[id.label] = id.value
+	}
+}
+
+// setClusterEndpoints exposes the API server endpoints and the base domain of the cluster to the containers of
+// provided pod template as env variables, so the assets can pass them to flags or configs. Env variables already set
+// by the assets are kept.
+func setClusterEndpoints(config config.OperatorConfig, template *corev1.PodTemplateSpec) {
+	setContainersEnv(template, apiServerURLEnvVar, config.APIServerURL)
+	setContainersEnv(template, apiServerInternalURLEnvVar, config.APIServerInternalURL)
+	setContainersEnv(template, baseDomainEnvVar, config.BaseDomain)
+}
+
+// setContainersEnv sets the given env variable on the containers of provided pod template which do not set it yet.
+// Empty values are not set.
+func setContainersEnv(template *corev1.PodTemplateSpec, name, value string) {
+	if value == "" {
+		return
+	}
+	for i := range template.Spec.Containers {
+		container := &template.Spec.Containers[i]
+		if !slices.ContainsFunc(container.Env, func(env corev1.EnvVar) bool { return env.Name == name }) {
+			container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+		}
+	}
+}
+
 // getProxyArg converts a cluster wide proxy configuration into a list of
 // env variable objects for pods.
 func getProxyArgs(proxy *configv1.Proxy) []corev1.EnvVar {
@@ -56,7 +178,206 @@ func getProxyArgs(proxy *configv1.Proxy) []corev1.EnvVar {
 	return envVars
 }
 
+// cloudControllerManagerArgs returns the flags to set on the cloud-controller-manager command: the feature gates of
+// the cluster relevant to cloud providers, so the cloud controller managers stay in sync with the control plane,
+// and the admin-provided flags.
+func cloudControllerManagerArgs(config config.OperatorConfig) map[string]string {
+	if config.FeatureGates == "" {
+		return config.CloudControllerManagerArgs
+	}
+	args := map[string]string{featureGatesArg: config.FeatureGates}
+	maps.Copy(args, config.CloudControllerManagerArgs)
+	return args
+}
+
+// setClusterCIDR sets the pod network CIDRs of the cluster on the cloud-controller-manager container in provided pod
+// spec, if it configures cloud routes or allocates node CIDRs. The shipped assets leave both to the cluster network
+// plugin, so this applies to pass-through manifests enabling them. Flags are set in the container args if it has any,
+// otherwise in the script the container command exec's the binary with, as the assets do.
+func setClusterCIDR(config config.OperatorConfig, p corev1.PodSpec) corev1.PodSpec {
+	if len(config.ClusterCIDRs) == 0 {
+		return p
+	}
+	clusterCIDR := strings.Join(config.ClusterCIDRs, ",")
+	for i := range p.Containers {
+		container := &p.Containers[i]
+		if container.Name != cloudControllerManagerContainerName {
+			continue
+		}
+		flags := slices.Concat(container.Command, container.Args)
+		if !isArgEnabled(flags, configureCloudRoutesArg) && !isArgEnabled(flags, allocateNodeCIDRsArg) {
+			continue
+		}
+
+		if len(container.Args) > 0 {
+			container.Args = overrideArg(container.Args, clusterCIDRArg, clusterCIDR)
+			continue
+		}
+		if len(container.Command) == 0 {
+			continue
+		}
+		script := &container.Command[len(container.Command)-1]
+		overridden, ok := overrideScriptArgs(*script, map[string]string{clusterCIDRArg: clusterCIDR})
+		if !ok {
+			klog.Warningf("can not find the exec line in the %s container command, skipping cluster CIDR", container.Name)
+			continue
+		}
+		*script = overridden
+	}
+	return p
+}
+
+// setLogLevel sets the klog verbosity of the containers in provided pod spec to the operand log level. The
+// cloud-controller-manager container gets the flag added if the assets do not set it, other containers only get
+// an existing one overridden, as sidecars may not support it.
+func setLogLevel(config config.OperatorConfig, p corev1.PodSpec) corev1.PodSpec {
+	if config.OperandLogLevel == nil {
+		return p
+	}
+	level := strconv.Itoa(*config.OperandLogLevel)
+	for i := range p.Containers {
+		container := &p.Containers[i]
+		isCloudControllerManager := container.Name == cloudControllerManagerContainerName
+		switch {
+		case hasArg(container.Args, verbosityArg):
+			container.Args = overrideArg(container.Args, verbosityArg, level)
+		case len(container.Command) > 0 && (hasArg(container.Command, verbosityArg) || isCloudControllerManager && len(container.Args) == 0):
+			script := &container.Command[len(container.Command)-1]
+			overridden, ok := overrideScriptArgs(*script, map[string]string{verbosityArg: level})
+			if !ok {
+				klog.Warningf("can not find the exec line in the %s container command, skipping log level", container.Name)
+				continue
+			}
+			*script = overridden
+		case isCloudControllerManager:
+			container.Args = overrideArg(container.Args, verbosityArg, level)
+		}
+	}
+	return p
+}
+
+// hasArg returns true if the given flag is set with a value in the given command, args or scripts.
+func hasArg(flags []string, name string) bool {
+	for _, flag := range flags {
+		for _, field := range strings.Fields(flag) {
+			if strings.HasPrefix(field, "--"+name+"=") || strings.HasPrefix(field, "-"+name+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isArgEnabled returns true if the given boolean flag is passed in the given command, args or scripts, either bare
+// or set to true. Boolean flags of the cloud controller managers default to false.
+func isArgEnabled(flags []string, name string) bool {
+	for _, flag := range flags {
+		for _, field := range strings.Fields(flag) {
+			field = strings.TrimLeft(field, "-")
+			if field == name || field == name+"=true" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overrideArg overrides the value of the given flag in the container args, or appends it.
+func overrideArg(args []string, name, value string) []string {
+	flag := fmt.Sprintf("--%s=%s", name, value)
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--"+name+"=") || strings.HasPrefix(arg, "-"+name+"=") {
+			args[i] = flag
+			return args
+		}
+	}
+	return append(args, flag)
+}
+
+// setCloudControllerManagerArgs overrides or appends the given flags to the command of the cloud-controller-manager
+// container in provided pod spec.
+func setCloudControllerManagerArgs(args map[string]string, p corev1.PodSpec) corev1.PodSpec {
+	if len(args) == 0 {
+		return p
+	}
+	for i := range p.Containers {
+		container := &p.Containers[i]
+		if container.Name != cloudControllerManagerContainerName || len(container.Command) == 0 {
+			continue
+		}
+		script := &container.Command[len(container.Command)-1]
+		overridden, ok := overrideScriptArgs(*script, args)
+		if !ok {
+			klog.Warningf("can not find the exec line in the %s container command, skipping argument overrides", container.Name)
+			continue
+		}
+		*script = overridden
+	}
+	return p
+}
+
+// overrideScriptArgs takes a container script exec'ing the binary with one flag per continuation line, as the
+// assets do, and overrides the value of the given flags, or appends them after the last one.
+// It returns false if the script has no exec line.
+func overrideScriptArgs(script string, args map[string]string) (string, bool) {
+	lines := strings.Split(script, "\n")
+
+	execLine := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "exec ") {
+			execLine = i
+			break
+		}
+	}
+	if execLine == -1 {
+		return script, false
+	}
+	lastLine := execLine
+	for lastLine+1 < len(lines) && strings.HasSuffix(strings.TrimSpace(lines[lastLine]), "\\") {
+		lastLine++
+	}
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := fmt.Sprintf("--%s=%s", name, args[name])
+		found := false
+		for i := execLine + 1; i <= lastLine; i++ {
+			trimmed := strings.TrimSpace(lines[i])
+			if !strings.HasPrefix(trimmed, "--"+name+"=") && !strings.HasPrefix(trimmed, "-"+name+"=") {
+				continue
+			}
+			indent := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " "))]
+			if strings.HasSuffix(trimmed, "\\") {
+				flag += " \\"
+			}
+			lines[i] = indent + flag
+			found = true
+			break
+		}
+		if found {
+			continue
+		}
+
+		indent := lines[lastLine][:len(lines[lastLine])-len(strings.TrimLeft(lines[lastLine], " "))]
+		lines[lastLine] += " \\"
+		lines = append(lines[:lastLine+1], append([]string{indent + flag}, lines[lastLine+1:]...)...)
+		lastLine++
+	}
+
+	return strings.Join(lines, "\n"), true
+}
+
 func SubstituteCommonPartsFromConfig(config config.OperatorConfig, renderedObjects []client.Object) []client.Object {
+	containerResources, err := ParseContainerResources(config.ContainerResources)
+	if err != nil {
+		klog.Warningf("skipping container resource overrides: %v", err)
+	}
+
 	substitutedObjects := make([]client.Object, len(renderedObjects))
 	for i, objectTemplate := range renderedObjects {
 		templateCopy := objectTemplate.DeepCopyObject().(client.Object)
@@ -64,11 +385,19 @@ func SubstituteCommonPartsFromConfig(config config.OperatorConfig, renderedObjec
 		switch obj := templateCopy.(type) {
 		case *appsv1.Deployment:
 			obj.Spec.Template.Spec = setProxySettings(config, obj.Spec.Template.Spec)
-			if config.IsSingleReplica {
-				obj.Spec.Replicas = ptr.To[int32](1)
-			}
+			obj.Spec.Template.Spec = setLogLevel(config, obj.Spec.Template.Spec)
+			obj.Spec.Template.Spec = setCloudControllerManagerArgs(cloudControllerManagerArgs(config), obj.Spec.Template.Spec)
+			obj.Spec.Template.Spec = setClusterCIDR(config, obj.Spec.Template.Spec)
+			obj.Spec.Template.Spec = setContainerResources(containerResources, obj.Spec.Template.Spec)
+			obj.Spec.Replicas = getReplicas(config, obj.Spec.Replicas)
+			setClusterIdentity(config, &obj.Spec.Template)
+			setClusterEndpoints(config, &obj.Spec.Template)
 		case *appsv1.DaemonSet:
 			obj.Spec.Template.Spec = setProxySettings(config, obj.Spec.Template.Spec)
+			obj.Spec.Template.Spec = setContainerResources(containerResources, obj.Spec.Template.Spec)
+			obj.Spec.Template.Spec = setLogLevel(config, obj.Spec.Template.Spec)
+			setClusterIdentity(config, &obj.Spec.Template)
+			setClusterEndpoints(config, &obj.Spec.Template)
 		}
 		substitutedObjects[i] = templateCopy
 	}
@@ -185,6 +185,52 @@ func TestSetProxySettings(t *testing.T) {
 	}
 }
 
+func TestSetProxySettingsOverridesAllContainers(t *testing.T) {
+	operatorConfig := config.OperatorConfig{
+		ClusterProxy: &configv1.Proxy{
+			Status: configv1.ProxyStatus{
+				HTTPSProxy: "https://squid.corp.acme.com:3128",
+				NoProxy:    "https://internal.acme.com",
+			},
+		},
+	}
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name: "init",
+		}},
+		Containers: []corev1.Container{{
+			Name: "manager",
+			Env: []corev1.EnvVar{{
+				Name:  "HTTPS_PROXY",
+				Value: "https://stale.acme.com:3128",
+			}, {
+				Name:  "SomeVar",
+				Value: "SomeValue",
+			}},
+		}},
+	}
+
+	spec := setProxySettings(operatorConfig, podSpec)
+	assert.Equal(t, []corev1.EnvVar{{
+		Name:  "HTTPS_PROXY",
+		Value: "https://squid.corp.acme.com:3128",
+	}, {
+		Name:  "NO_PROXY",
+		Value: "https://internal.acme.com",
+	}}, spec.InitContainers[0].Env)
+	assert.Equal(t, []corev1.EnvVar{{
+		Name:  "HTTPS_PROXY",
+		Value: "https://squid.corp.acme.com:3128",
+	}, {
+		Name:  "SomeVar",
+		Value: "SomeValue",
+	}, {
+		Name:  "NO_PROXY",
+		Value: "https://internal.acme.com",
+	}}, spec.Containers[0].Env)
+	assert.Equal(t, "https://stale.acme.com:3128", podSpec.Containers[0].Env[0].Value, "the source pod spec must not be mutated")
+}
+
 func TestFillConfigValues(t *testing.T) {
 	testManagementNamespace := "test-namespace"
 
@@ -216,8 +262,59 @@ func TestFillConfigValues(t *testing.T) {
 			},
 		}},
 		config: config.OperatorConfig{
-			ManagedNamespace: testManagementNamespace,
-			IsSingleReplica:  true,
+			ManagedNamespace:     testManagementNamespace,
+			ControlPlaneTopology: configv1.SingleReplicaTopologyMode,
+		},
+	}, {
+		name: "Substitute Highly Available replicas for deployment",
+		objects: []client.Object{&v1.Deployment{
+			Spec: v1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{},
+					},
+				},
+			},
+		}},
+		expectedObjects: []client.Object{&v1.Deployment{
+			Spec: v1.DeploymentSpec{
+				Replicas: ptr.To[int32](2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{},
+					},
+				},
+			},
+		}},
+		config: config.OperatorConfig{
+			ManagedNamespace:     testManagementNamespace,
+			ControlPlaneTopology: configv1.HighlyAvailableTopologyMode,
+		},
+	}, {
+		name: "Keep replicas set in the deployment for Highly Available topology",
+		objects: []client.Object{&v1.Deployment{
+			Spec: v1.DeploymentSpec{
+				Replicas: ptr.To[int32](3),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{},
+					},
+				},
+			},
+		}},
+		expectedObjects: []client.Object{&v1.Deployment{
+			Spec: v1.DeploymentSpec{
+				Replicas: ptr.To[int32](3),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{},
+					},
+				},
+			},
+		}},
+		config: config.OperatorConfig{
+			ManagedNamespace:     testManagementNamespace,
+			ControlPlaneTopology: configv1.HighlyAvailableTopologyMode,
 		},
 	}}
 
@@ -232,3 +329,247 @@ func TestFillConfigValues(t *testing.T) {
 		})
 	}
 }
+
+func TestOverrideScriptArgs(t *testing.T) {
+	script := `#!/bin/bash
+set -o allexport
+if [[ -f /etc/kubernetes/apiserver-url.env ]]; then
+  source /etc/kubernetes/apiserver-url.env
+fi
+exec /bin/gcp-cloud-controller-manager \
+  --v=3 \
+  --concurrent-service-syncs=10 \
+  --secure-port=0
+`
+
+	tc := []struct {
+		name     string
+		script   string
+		args     map[string]string
+		expected string
+		ok       bool
+	}{{
+		name:   "Override and append flags",
+		script: script,
+		args: map[string]string{
+			"concurrent-service-syncs":        "20",
+			"cloud-provider-gce-lb-src-cidrs": "130.211.0.0/22,35.191.0.0/16",
+			"v":                               "4",
+		},
+		expected: `#!/bin/bash
+set -o allexport
+if [[ -f /etc/kubernetes/apiserver-url.env ]]; then
+  source /etc/kubernetes/apiserver-url.env
+fi
+exec /bin/gcp-cloud-controller-manager \
+  --v=4 \
+  --concurrent-service-syncs=20 \
+  --secure-port=0 \
+  --cloud-provider-gce-lb-src-cidrs=130.211.0.0/22,35.191.0.0/16
+`,
+		ok: true,
+	}, {
+		name:   "Override the last flag",
+		script: script,
+		args: map[string]string{
+			"secure-port": "10258",
+		},
+		expected: `#!/bin/bash
+set -o allexport
+if [[ -f /etc/kubernetes/apiserver-url.env ]]; then
+  source /etc/kubernetes/apiserver-url.env
+fi
+exec /bin/gcp-cloud-controller-manager \
+  --v=3 \
+  --concurrent-service-syncs=10 \
+  --secure-port=10258
+`,
+		ok: true,
+	}, {
+		name:     "No exec line",
+		script:   "/bin/ccm --v=2",
+		args:     map[string]string{"v": "4"},
+		expected: "/bin/ccm --v=2",
+		ok:       false,
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := overrideScriptArgs(tc.script, tc.args)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSetClusterCIDR(t *testing.T) {
+	cfg := config.OperatorConfig{ClusterCIDRs: []string{"10.128.0.0/14", "fd01::/48"}}
+	script := func(routes string) string {
+		return "exec /bin/cloud-controller-manager \\\n  --configure-cloud-routes=" + routes + " \\\n  --v=3\n"
+	}
+
+	tc := []struct {
+		name     string
+		config   config.OperatorConfig
+		command  []string
+		args     []string
+		expected corev1.Container
+	}{{
+		name:    "Cloud routes disabled",
+		config:  cfg,
+		command: []string{"/bin/bash", "-c", script("false")},
+		expected: corev1.Container{
+			Command: []string{"/bin/bash", "-c", script("false")},
+		},
+	}, {
+		name:    "Cloud routes enabled in the script",
+		config:  cfg,
+		command: []string{"/bin/bash", "-c", script("true")},
+		expected: corev1.Container{
+			Command: []string{"/bin/bash", "-c", "exec /bin/cloud-controller-manager \\\n  --configure-cloud-routes=true \\\n  --v=3 \\\n  --cluster-cidr=10.128.0.0/14,fd01::/48\n"},
+		},
+	}, {
+		name:   "Node CIDR allocation enabled in the args",
+		config: cfg,
+		args:   []string{"--allocate-node-cidrs", "--cluster-cidr=10.0.0.0/8"},
+		expected: corev1.Container{
+			Args: []string{"--allocate-node-cidrs", "--cluster-cidr=10.128.0.0/14,fd01::/48"},
+		},
+	}, {
+		name: "No cluster network",
+		args: []string{"--allocate-node-cidrs=true"},
+		expected: corev1.Container{
+			Args: []string{"--allocate-node-cidrs=true"},
+		},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := setClusterCIDR(tc.config, corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:    cloudControllerManagerContainerName,
+					Command: tc.command,
+					Args:    tc.args,
+				}},
+			})
+			tc.expected.Name = cloudControllerManagerContainerName
+			assert.Equal(t, tc.expected, spec.Containers[0])
+		})
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	script := "exec /bin/cloud-controller-manager \\\n  --cloud-provider=aws\n"
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:    cloudControllerManagerContainerName,
+			Command: []string{"/bin/bash", "-c", script},
+		}, {
+			Name: "token-refresher",
+			Args: []string{"--v=2", "--interval=5m"},
+		}, {
+			Name: "kube-rbac-proxy",
+			Args: []string{"--secure-listen-address=0.0.0.0:9258"},
+		}},
+	}
+
+	spec := setLogLevel(config.OperatorConfig{}, *podSpec.DeepCopy())
+	assert.Equal(t, podSpec, spec, "the log level of the assets must be kept when not set")
+
+	spec = setLogLevel(config.OperatorConfig{OperandLogLevel: ptr.To(6)}, *podSpec.DeepCopy())
+	assert.Equal(t, []string{"/bin/bash", "-c", "exec /bin/cloud-controller-manager \\\n  --cloud-provider=aws \\\n  --v=6\n"}, spec.Containers[0].Command)
+	assert.Equal(t, []string{"--v=6", "--interval=5m"}, spec.Containers[1].Args)
+	assert.Equal(t, []string{"--secure-listen-address=0.0.0.0:9258"}, spec.Containers[2].Args, "containers without the flag must be left untouched")
+}
+
+func TestSetClusterIdentity(t *testing.T) {
+	tc := []struct {
+		name           string
+		config         config.OperatorConfig
+		env            []corev1.EnvVar
+		expectedEnv    []corev1.EnvVar
+		expectedLabels map[string]string
+	}{{
+		name: "Identity is exposed as env variables and labels",
+		config: config.OperatorConfig{
+			InfrastructureName: "my-cluster-x7k2p",
+			ClusterID:          "0f9e8a3c-6b1d-4c2e-9a57-3d8e1f2b4c6a",
+		},
+		env: []corev1.EnvVar{{Name: "SomeVar", Value: "SomeValue"}},
+		expectedEnv: []corev1.EnvVar{
+			{Name: "SomeVar", Value: "SomeValue"},
+			{Name: infrastructureNameEnvVar, Value: "my-cluster-x7k2p"},
+			{Name: clusterIDEnvVar, Value: "0f9e8a3c-6b1d-4c2e-9a57-3d8e1f2b4c6a"},
+		},
+		expectedLabels: map[string]string{
+			infrastructureNameLabel: "my-cluster-x7k2p",
+			clusterIDLabel:          "0f9e8a3c-6b1d-4c2e-9a57-3d8e1f2b4c6a",
+		},
+	}, {
+		name:   "Env variables set by the assets are kept",
+		config: config.OperatorConfig{InfrastructureName: "my-cluster-x7k2p"},
+		env:    []corev1.EnvVar{{Name: infrastructureNameEnvVar, Value: "from-asset"}},
+		expectedEnv: []corev1.EnvVar{
+			{Name: infrastructureNameEnvVar, Value: "from-asset"},
+		},
+		expectedLabels: map[string]string{infrastructureNameLabel: "my-cluster-x7k2p"},
+	}, {
+		name:   "Values which are not valid label values are not labelled",
+		config: config.OperatorConfig{ClusterID: "not a label value"},
+		expectedEnv: []corev1.EnvVar{
+			{Name: clusterIDEnvVar, Value: "not a label value"},
+		},
+	}, {
+		name: "Unknown identity is not exposed",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment := &v1.Deployment{
+				Spec: v1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ccm", Env: tc.env}}},
+					},
+				},
+			}
+			daemonSet := &v1.DaemonSet{
+				Spec: v1.DaemonSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "cnm", Env: tc.env}}},
+					},
+				},
+			}
+
+			objects := SubstituteCommonPartsFromConfig(tc.config, []client.Object{deployment, daemonSet})
+			for _, template := range []corev1.PodTemplateSpec{
+				objects[0].(*v1.Deployment).Spec.Template,
+				objects[1].(*v1.DaemonSet).Spec.Template,
+			} {
+				assert.Equal(t, tc.expectedEnv, template.Spec.Containers[0].Env)
+				assert.Equal(t, tc.expectedLabels, template.Labels)
+			}
+		})
+	}
+}
+
+func TestSetClusterEndpoints(t *testing.T) {
+	deployment := &v1.Deployment{
+		Spec: v1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "ccm",
+					Env:  []corev1.EnvVar{{Name: baseDomainEnvVar, Value: "from-asset"}},
+				}}},
+			},
+		},
+	}
+
+	objects := SubstituteCommonPartsFromConfig(config.OperatorConfig{
+		APIServerURL: "https://api.my-cluster.example.com:6443",
+		BaseDomain:   "my-cluster.example.com",
+	}, []client.Object{deployment})
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: baseDomainEnvVar, Value: "from-asset"},
+		{Name: apiServerURLEnvVar, Value: "https://api.my-cluster.example.com:6443"},
+	}, objects[0].(*v1.Deployment).Spec.Template.Spec.Containers[0].Env)
+}
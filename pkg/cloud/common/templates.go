@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"text/template"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -16,6 +18,8 @@ type TemplateValues map[string]interface{}
 
 // TemplateSource structure which intended to keep path to template
 // and reference kubernetes object for further unmarshalling (such as Deployment, DaemonSet, ConfigMap, etc)
+// If ReferenceObject is nil, the template is rendered into an unstructured object, which allows
+// to ship kinds that are not registered in the operator scheme (such as ServiceMonitors or provider CRs).
 type TemplateSource struct {
 	ReferenceObject client.Object
 	EmbedFsPath     string
@@ -34,12 +38,20 @@ func (tmpl *ObjectTemplate) render(templateValues TemplateValues) (client.Object
 	if err := tmpl.templateContent.Execute(buf, templateValues); err != nil {
 		return nil, fmt.Errorf("can not render template: %s", err)
 	}
-	object := tmpl.ReferenceObject.DeepCopyObject()
+	var object runtime.Object = &unstructured.Unstructured{}
+	if tmpl.ReferenceObject != nil {
+		object = tmpl.ReferenceObject.DeepCopyObject()
+	}
 
 	if err := yaml.UnmarshalStrict(buf.Bytes(), object); err != nil {
 		klog.Errorf("Cannot decode data from embedded resource %v: %v", tmpl.EmbedFsPath, err)
 		return nil, err
 	}
+
+	if err := ValidateRenderedObject(object.(client.Object), tmpl.ReferenceObject); err != nil {
+		klog.Errorf("Rendered embedded resource %v is not valid: %v", tmpl.EmbedFsPath, err)
+		return nil, fmt.Errorf("%s: %w", tmpl.EmbedFsPath, err)
+	}
 	return object.(client.Object), nil
 }
 
@@ -72,11 +72,25 @@ func TestTemplateRendering(t *testing.T) {
 			templateSource: TemplateSource{ReferenceObject: &v1.ConfigMap{}, EmbedFsPath: "_testdata/assets/deployment.yaml"},
 			templateValues: TemplateValues{"someLabel": "bar", "name": "foo", "images": map[string]string{"Foo": "baz"}},
 			expectedErr:    "error unmarshaling JSON: while decoding JSON: json: unknown field \"spec\"",
+		}, {
+			name:           "template without reference object renders into unstructured",
+			templateSource: TemplateSource{EmbedFsPath: "_testdata/assets/servicemonitor.yaml"},
+			templateValues: TemplateValues{"name": "foo", "someLabel": "bar"},
+		}, {
+			name:           "template without reference object fails if manifest is not a valid yaml",
+			templateSource: TemplateSource{EmbedFsPath: "_testdata/foo"},
+			templateValues: TemplateValues{},
+			expectedErr:    "error unmarshaling JSON: while decoding JSON: json: cannot unmarshal string into Go value of type map[string]interface {}",
 		}, {
 			name:           "render fails if manifest is not a valid yaml",
 			templateSource: TemplateSource{ReferenceObject: &v1.ConfigMap{}, EmbedFsPath: "_testdata/foo"},
 			templateValues: TemplateValues{},
 			expectedErr:    "error unmarshaling JSON: while decoding JSON: json: cannot unmarshal string into Go value of type v1.ConfigMap",
+		}, {
+			name:           "render fails if rendered manifest is not valid",
+			templateSource: TemplateSource{ReferenceObject: &appsv1.Deployment{}, EmbedFsPath: "_testdata/assets/deployment.yaml"},
+			templateValues: TemplateValues{"name": "foo", "someLabel": "bar", "images": map[string]string{"Foo": ""}},
+			expectedErr:    "_testdata/assets/deployment.yaml: invalid Deployment \"sample/foo\": spec.template.spec.containers[0].image: Required value",
 		},
 	}
 
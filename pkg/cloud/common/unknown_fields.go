@@ -0,0 +1,209 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MarshalJSONPreservingUnknownFields marshals cfg, a provider config decoded from the given JSON source config,
+// and adds back the fields of the source cfg has no field for, at any depth. Transformations decoding the config
+// into the vendored provider types thus do not drop the options those types do not know, e.g. options added by a
+// newer cloud provider. The fields cfg knows keep the value cfg holds, even when it omits them.
+// The marshaled cfg is returned as is when the source has no unknown fields.
+func MarshalJSONPreservingUnknownFields(source string, cfg interface{}) ([]byte, error) {
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceObj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(source), &sourceObj); err != nil {
+		// Only configs which could be decoded into cfg are expected, an empty source has no unknown fields.
+		return out, nil
+	}
+	outObj := map[string]interface{}{}
+	if err := json.Unmarshal(out, &outObj); err != nil {
+		return nil, fmt.Errorf("failed to read the marshaled config: %w", err)
+	}
+
+	if !addUnknownFields(outObj, sourceObj, reflect.TypeOf(cfg), "json") {
+		return out, nil
+	}
+	return json.Marshal(outObj)
+}
+
+// PreserveUnknownYAMLFields adds back to the transformed YAML config the fields of the YAML source config which the
+// provider config type of cfg, decoded from the source with yaml tags, has no field for, at any depth.
+// The transformed config is returned as is when the source has no unknown fields.
+func PreserveUnknownYAMLFields(source, transformed string, cfg interface{}) (string, error) {
+	sourceObj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(source), &sourceObj); err != nil {
+		// Only configs which could be decoded into cfg are expected, e.g. an ini config has no YAML fields.
+		return transformed, nil
+	}
+	outObj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(transformed), &outObj); err != nil {
+		return "", fmt.Errorf("failed to read the transformed config: %w", err)
+	}
+
+	if !addUnknownFields(outObj, sourceObj, reflect.TypeOf(cfg), "yaml") {
+		return transformed, nil
+	}
+	out, err := yaml.Marshal(outObj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the transformed config: %w", err)
+	}
+	return string(out), nil
+}
+
+// addUnknownFields adds the fields of src which the struct type t has no field for according to its tagKey tags
+// to dst, and recurses into the objects of both matching a struct field or an entry of a map of structs, e.g. the
+// vCenters by server. An object cfg omitted is added back when its source holds unknown fields. JSON field names
+// are matched regardless of their case, like encoding/json does when decoding. It returns whether dst was changed.
+func addUnknownFields(dst, src map[string]interface{}, t reflect.Type, tagKey string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	changed := false
+	switch t.Kind() {
+	case reflect.Map:
+		for key, value := range src {
+			srcObj, srcIsObj := value.(map[string]interface{})
+			dstObj, dstIsObj := dst[key].(map[string]interface{})
+			if srcIsObj && dstIsObj && addUnknownFields(dstObj, srcObj, t.Elem(), tagKey) {
+				changed = true
+			}
+		}
+	case reflect.Struct:
+		fields := taggedFields(t, tagKey)
+		for key, value := range src {
+			field, known := fields[strings.ToLower(key)]
+			// Unlike encoding/json, YAML decoders match the field names exactly.
+			if !known || (tagKey != "json" && field.name != key) {
+				dst[key] = value
+				changed = true
+				continue
+			}
+			srcObj, srcIsObj := value.(map[string]interface{})
+			if !srcIsObj {
+				continue
+			}
+			dstObj, dstIsObj := dst[field.name].(map[string]interface{})
+			if !dstIsObj {
+				if _, set := dst[field.name]; set {
+					continue
+				}
+				dstObj = map[string]interface{}{}
+			}
+			if addUnknownFields(dstObj, srcObj, field.typ, tagKey) {
+				dst[field.name] = dstObj
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+type taggedField struct {
+	name string
+	typ  reflect.Type
+}
+
+// taggedFields returns the fields the struct type t is (un)marshaled with according to its tagKey tags, e.g. json,
+// by lower cased name, including the fields of its embedded and inlined structs.
+func taggedFields(t reflect.Type, tagKey string) map[string]taggedField {
+	fields := map[string]taggedField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, options, _ := strings.Cut(f.Tag.Get(tagKey), ",")
+		inline := slices.Contains(strings.Split(options, ","), "inline") || (f.Anonymous && name == "")
+
+		if inline {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, field := range taggedFields(embedded, tagKey) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = field
+					}
+				}
+				continue
+			}
+		}
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+			// Unlike encoding/json, YAML encoders default to the lower cased field name.
+			if tagKey == "yaml" {
+				name = strings.ToLower(f.Name)
+			}
+		}
+		fields[strings.ToLower(name)] = taggedField{name: name, typ: f.Type}
+	}
+	return fields
+}
+
+// UnknownJSONFields returns the paths of the fields of the JSON source config which the provider config type of cfg
+// has no field for, e.g. "newOption" or "prismCentral.newOption", sorted. It returns nil for sources which are not
+// JSON objects.
+func UnknownJSONFields(source string, cfg interface{}) []string {
+	sourceObj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(source), &sourceObj); err != nil {
+		return nil
+	}
+	var unknown []string
+	collectUnknownFields(sourceObj, reflect.TypeOf(cfg), "", &unknown)
+	slices.Sort(unknown)
+	return unknown
+}
+
+func collectUnknownFields(src map[string]interface{}, t reflect.Type, prefix string, unknown *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		for key, value := range src {
+			if obj, ok := value.(map[string]interface{}); ok {
+				collectUnknownFields(obj, t.Elem(), prefix+key+".", unknown)
+			}
+		}
+	case reflect.Struct:
+		fields := taggedFields(t, "json")
+		for key, value := range src {
+			field, known := fields[strings.ToLower(key)]
+			if !known {
+				*unknown = append(*unknown, prefix+key)
+				continue
+			}
+			if obj, ok := value.(map[string]interface{}); ok {
+				collectUnknownFields(obj, field.typ, prefix+field.name+".", unknown)
+			}
+		}
+	}
+}
+
+// JSONFieldNames returns the names of the top-level fields of the JSON representation of cfg, sorted.
+func JSONFieldNames(cfg interface{}) []string {
+	t := reflect.TypeOf(cfg)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var names []string
+	for _, field := range taggedFields(t, "json") {
+		names = append(names, field.name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,149 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAuthConfig struct {
+	ClientID     string `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+}
+
+type testNestedConfig struct {
+	Address string `json:"address" yaml:"address"`
+}
+
+type testYAMLConfig struct {
+	testAuthConfig `yaml:",inline"`
+	Cloud          string                       `yaml:"cloud,omitempty"`
+	Nested         *testNestedConfig            `yaml:"nested,omitempty"`
+	Servers        map[string]*testNestedConfig `yaml:"servers,omitempty"`
+	Defaults       testNestedConfig
+}
+
+type testConfig struct {
+	testAuthConfig
+	Cloud    string                      `json:"cloud"`
+	Nested   *testNestedConfig           `json:"nested,omitempty"`
+	Tags     map[string]string           `json:"tags,omitempty"`
+	Servers  map[string]testNestedConfig `json:"servers,omitempty"`
+	Internal string                      `json:"-"`
+}
+
+func TestMarshalJSONPreservingUnknownFields(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		cfg      testConfig
+		expected string
+	}{{
+		name:     "No unknown fields",
+		source:   `{"cloud":"AzurePublicCloud","clientId":"id"}`,
+		cfg:      testConfig{Cloud: "AzurePublicCloud", testAuthConfig: testAuthConfig{ClientID: "id"}},
+		expected: `{"clientId":"id","cloud":"AzurePublicCloud"}`,
+	}, {
+		name:     "Empty source",
+		source:   "",
+		cfg:      testConfig{Cloud: "AzurePublicCloud"},
+		expected: `{"cloud":"AzurePublicCloud"}`,
+	}, {
+		name:     "Unknown fields at any depth",
+		source:   `{"cloud":"AzurePublicCloud","newOption":true,"nested":{"address":"example.com","port":443},"tags":{"a":"b"}}`,
+		cfg:      testConfig{Cloud: "AzureUSGovernmentCloud", Nested: &testNestedConfig{Address: "example.com"}, Tags: map[string]string{"a": "b"}},
+		expected: `{"cloud":"AzureUSGovernmentCloud","nested":{"address":"example.com","port":443},"newOption":true,"tags":{"a":"b"}}`,
+	}, {
+		name:     "Fields omitted by the config are not added back",
+		source:   `{"Cloud":"AzurePublicCloud","clientSecret":"secret","Internal":"value","newOption":1}`,
+		cfg:      testConfig{Cloud: "AzurePublicCloud"},
+		expected: `{"Internal":"value","cloud":"AzurePublicCloud","newOption":1}`,
+	}, {
+		name:     "Unknown fields of omitted objects and map entries",
+		source:   `{"cloud":"AzurePublicCloud","nested":{"port":443},"servers":{"a":{"address":"a.example.com","port":443},"b":{"port":443}}}`,
+		cfg:      testConfig{Cloud: "AzurePublicCloud", Servers: map[string]testNestedConfig{"a": {Address: "a.example.com"}}},
+		expected: `{"cloud":"AzurePublicCloud","nested":{"port":443},"servers":{"a":{"address":"a.example.com","port":443}}}`,
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := MarshalJSONPreservingUnknownFields(tc.source, tc.cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestUnknownJSONFields(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		expected []string
+	}{{
+		name:   "No unknown fields",
+		source: `{"cloud":"AzurePublicCloud","ClientID":"id","nested":{"address":"example.com"}}`,
+	}, {
+		name:     "Unknown fields at any depth",
+		source:   `{"cloud":"AzurePublicCloud","newOption":true,"Nested":{"address":"example.com","port":443},"tags":{"a":"b"}}`,
+		expected: []string{"nested.port", "newOption"},
+	}, {
+		name:     "Unknown fields of map entries",
+		source:   `{"servers":{"a":{"address":"example.com","port":443}}}`,
+		expected: []string{"servers.a.port"},
+	}, {
+		name:   "Not a JSON object",
+		source: "[Global]\n",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, UnknownJSONFields(tc.source, &testConfig{}))
+		})
+	}
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	assert.Equal(t, []string{"clientId", "clientSecret", "cloud", "nested", "servers", "tags"}, JSONFieldNames(testConfig{}))
+}
+
+func TestPreserveUnknownYAMLFields(t *testing.T) {
+	tc := []struct {
+		name        string
+		source      string
+		transformed string
+		expected    string
+	}{{
+		name:        "No unknown fields",
+		source:      "cloud: public\nclientId: id\n",
+		transformed: "clientId: id\ncloud: public\nnested:\n  address: example.com\n",
+		expected:    "clientId: id\ncloud: public\nnested:\n  address: example.com\n",
+	}, {
+		name:        "Unknown fields at any depth",
+		source:      "cloud: public\nnewOption: true\nnested:\n  address: example.com\n  port: 443\n",
+		transformed: "cloud: public\nnested:\n  address: example.com\n",
+		expected:    "cloud: public\nnested:\n  address: example.com\n  port: 443\nnewOption: true\n",
+	}, {
+		name:        "Unknown fields of map entries and untagged fields",
+		source:      "servers:\n  a:\n    port: 443\ndefaults:\n  port: 443\n",
+		transformed: "defaults:\n  address: example.com\nservers:\n  a:\n    address: example.com\n",
+		expected:    "defaults:\n  address: example.com\n  port: 443\nservers:\n  a:\n    address: example.com\n    port: 443\n",
+	}, {
+		name:        "Field names are case sensitive",
+		source:      "Cloud: public\nclientSecret: secret\n",
+		transformed: "cloud: public\n",
+		expected:    "Cloud: public\ncloud: public\n",
+	}, {
+		name:        "ini source",
+		source:      "[Global]\nuser = admin\n",
+		transformed: "cloud: public\n",
+		expected:    "cloud: public\n",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := PreserveUnknownYAMLFields(tc.source, tc.transformed, &testYAMLConfig{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
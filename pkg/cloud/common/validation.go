@@ -0,0 +1,158 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ValidateRenderedObject performs client side checks of a rendered object, so malformed assets fail fast
+// with a clear message instead of being rejected by the API server in the middle of a sync.
+// It is not meant to replace server side validation, only to catch the most common mistakes.
+func ValidateRenderedObject(obj client.Object, reference client.Object) error {
+	allErrs := field.ErrorList{}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Version == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("apiVersion"), ""))
+	}
+	if gvk.Kind == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("kind"), ""))
+	}
+	if reference != nil {
+		allErrs = append(allErrs, validateReferenceKind(gvk, reference)...)
+	}
+
+	allErrs = append(allErrs, validateMetadata(obj)...)
+
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		allErrs = append(allErrs, validatePodTemplate(o.Spec.Selector, &o.Spec.Template, field.NewPath("spec"))...)
+	case *appsv1.DaemonSet:
+		allErrs = append(allErrs, validatePodTemplate(o.Spec.Selector, &o.Spec.Template, field.NewPath("spec"))...)
+	}
+
+	if len(allErrs) > 0 {
+		name := obj.GetName()
+		if obj.GetNamespace() != "" {
+			name = client.ObjectKeyFromObject(obj).String()
+		}
+		return fmt.Errorf("invalid %s %q: %w", gvk.Kind, name, allErrs.ToAggregate())
+	}
+	return nil
+}
+
+// validateReferenceKind ensures the API group and kind declared in a template match the type it is decoded into.
+// Versions are not compared, as typed objects are always submitted with the version registered in the scheme.
+func validateReferenceKind(gvk schema.GroupVersionKind, reference client.Object) field.ErrorList {
+	expected, err := apiutil.GVKForObject(reference, scheme.Scheme)
+	if err != nil {
+		// Unknown reference type, nothing to compare with.
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	if gvk.Version != "" && gvk.Group != expected.Group {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("apiVersion"), gvk.GroupVersion().String(),
+			fmt.Sprintf("expected group %q", expected.Group)))
+	}
+	if gvk.Kind != "" && gvk.Kind != expected.Kind {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("kind"), gvk.Kind, fmt.Sprintf("expected %s", expected.Kind)))
+	}
+	return allErrs
+}
+
+func validateMetadata(obj client.Object) field.ErrorList {
+	requiresNamespace := obj.GetNamespace() != ""
+	nameFn := apivalidation.NameIsDNSSubdomain
+
+	switch obj.(type) {
+	case *unstructured.Unstructured:
+		// The scope of arbitrary kinds is unknown, so whatever the template declares is accepted.
+	case *appsv1.Deployment, *appsv1.DaemonSet, *corev1.ConfigMap, *corev1.Secret, *corev1.Service,
+		*corev1.ServiceAccount, *policyv1.PodDisruptionBudget:
+		requiresNamespace = true
+	case *rbacv1.Role, *rbacv1.RoleBinding:
+		requiresNamespace = true
+		nameFn = isValidPathSegmentName
+	case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding:
+		requiresNamespace = false
+		nameFn = isValidPathSegmentName
+	case *admissionregistrationv1.ValidatingAdmissionPolicy, *admissionregistrationv1.ValidatingAdmissionPolicyBinding:
+		requiresNamespace = false
+	}
+
+	return apivalidation.ValidateObjectMetaAccessor(obj, requiresNamespace, nameFn, field.NewPath("metadata"))
+}
+
+// isValidPathSegmentName mirrors the name validation of RBAC objects, which allows any name
+// that can be used as a path segment, e.g. "system:cloud-controller-manager".
+func isValidPathSegmentName(name string, prefix bool) []string {
+	if name == "." || name == ".." {
+		return []string{fmt.Sprintf("may not be '%s'", name)}
+	}
+	errs := []string{}
+	for _, illegal := range []string{"/", "%"} {
+		if strings.Contains(name, illegal) {
+			errs = append(errs, fmt.Sprintf("may not contain '%s'", illegal))
+		}
+	}
+	return errs
+}
+
+func validatePodTemplate(selector *metav1.LabelSelector, template *corev1.PodTemplateSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if selector == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("selector"), ""))
+	} else if labelSelector, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), selector, err.Error()))
+	} else if labelSelector.Empty() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), selector, "empty selector is invalid"))
+	} else if !labelSelector.Matches(labels.Set(template.Labels)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("template", "metadata", "labels"), template.Labels,
+			"`selector` does not match template `labels`"))
+	}
+
+	containersPath := fldPath.Child("template", "spec", "containers")
+	if len(template.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(containersPath, ""))
+	}
+	names := map[string]struct{}{}
+	allErrs = append(allErrs, validateContainers(template.Spec.InitContainers, names, fldPath.Child("template", "spec", "initContainers"))...)
+	allErrs = append(allErrs, validateContainers(template.Spec.Containers, names, containersPath)...)
+
+	return allErrs
+}
+
+func validateContainers(containers []corev1.Container, names map[string]struct{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, container := range containers {
+		path := fldPath.Index(i)
+		if container.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+		} else if _, duplicate := names[container.Name]; duplicate {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), container.Name))
+		}
+		names[container.Name] = struct{}{}
+
+		if container.Image == "" {
+			allErrs = append(allErrs, field.Required(path.Child("image"), ""))
+		}
+	}
+	return allErrs
+}
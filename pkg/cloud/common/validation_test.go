@@ -0,0 +1,107 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestValidateRenderedObject(t *testing.T) {
+	daemonSet := func(mutate func(ds *appsv1.DaemonSet)) *appsv1.DaemonSet {
+		ds := &appsv1.DaemonSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: "foo", Image: "quay.io/foo"}},
+					},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(ds)
+		}
+		return ds
+	}
+
+	tc := []struct {
+		name        string
+		object      client.Object
+		reference   client.Object
+		expectedErr string
+	}{
+		{
+			name:      "valid daemonset",
+			object:    daemonSet(nil),
+			reference: &appsv1.DaemonSet{},
+		}, {
+			name: "valid cluster role with colon in the name",
+			object: &rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: "system:cloud-controller-manager"},
+			},
+			reference: &rbacv1.ClusterRole{},
+		}, {
+			name: "missing kind",
+			object: daemonSet(func(ds *appsv1.DaemonSet) {
+				ds.Kind = ""
+			}),
+			expectedErr: "invalid  \"bar/foo\": kind: Required value",
+		}, {
+			name:        "kind does not match the reference object",
+			object:      daemonSet(nil),
+			reference:   &appsv1.Deployment{},
+			expectedErr: "invalid DaemonSet \"bar/foo\": kind: Invalid value: \"DaemonSet\": expected Deployment",
+		}, {
+			name: "missing namespace",
+			object: daemonSet(func(ds *appsv1.DaemonSet) {
+				ds.Namespace = ""
+			}),
+			expectedErr: "invalid DaemonSet \"foo\": metadata.namespace: Required value",
+		}, {
+			name: "invalid name",
+			object: daemonSet(func(ds *appsv1.DaemonSet) {
+				ds.Name = "Foo_Bar"
+			}),
+			expectedErr: "invalid DaemonSet \"bar/Foo_Bar\": metadata.name: Invalid value: \"Foo_Bar\": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		}, {
+			name: "selector does not match template labels",
+			object: daemonSet(func(ds *appsv1.DaemonSet) {
+				ds.Spec.Template.Labels = map[string]string{"app": "baz"}
+			}),
+			expectedErr: "invalid DaemonSet \"bar/foo\": spec.template.metadata.labels: Invalid value: {\"app\":\"baz\"}: `selector` does not match template `labels`",
+		}, {
+			name: "container without name and image",
+			object: daemonSet(func(ds *appsv1.DaemonSet) {
+				ds.Spec.Template.Spec.Containers = append(ds.Spec.Template.Spec.Containers, v1.Container{})
+			}),
+			expectedErr: "invalid DaemonSet \"bar/foo\": [spec.template.spec.containers[1].name: Required value, spec.template.spec.containers[1].image: Required value]",
+		}, {
+			name: "duplicate container names",
+			object: daemonSet(func(ds *appsv1.DaemonSet) {
+				ds.Spec.Template.Spec.InitContainers = []v1.Container{{Name: "foo", Image: "quay.io/foo"}}
+			}),
+			expectedErr: "invalid DaemonSet \"bar/foo\": spec.template.spec.containers[0].name: Duplicate value: \"foo\"",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRenderedObject(tc.object, tc.reference)
+			if tc.expectedErr != "" {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
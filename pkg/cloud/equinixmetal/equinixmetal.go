@@ -0,0 +1,127 @@
+package equinixmetal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+const (
+	providerName = "equinixmetal"
+
+	// cloudConfigSecretName is the Secret in the managed namespace holding the cloud-sa.json configuration
+	// of the cloud provider (project, metro and load balancer settings). It is expected to be provided at install time.
+	cloudConfigSecretName = "metal-cloud-config"
+
+	// credentialsSecretName is an optional Secret in the managed namespace holding the "apiKey" and "projectID"
+	// keys. When present, they take precedence over the values in cloud-sa.json.
+	credentialsSecretName = "metal-credentials"
+)
+
+var (
+	// platformNames are the External platform names clusters installed on Equinix Metal are reported with.
+	// Equinix Metal was formerly known as Packet.
+	platformNames = []string{providerName, "equinix-metal", "packet"}
+)
+
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         providerName,
+		PlatformType: configv1.ExternalPlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool {
+			return IsEquinixMetal(externalPlatformName)
+		},
+		NewAssets: NewProviderAssets,
+	})
+}
+
+type imagesReference struct {
+	CloudControllerManager string `valid:"required"`
+}
+
+var templateValuesValidationMap = map[string]interface{}{
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
+}
+
+type equinixMetalAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *equinixMetalAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+// IsEquinixMetal returns true if the External platform name reported in the infrastructure refers to Equinix Metal.
+func IsEquinixMetal(externalPlatformName string) bool {
+	for _, name := range platformNames {
+		if strings.EqualFold(externalPlatformName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
+	values := common.TemplateValues{
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
+	}
+	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getDeploymentOverlay returns the Equinix Metal parts of the base cloud-controller-manager Deployment.
+func getDeploymentOverlay() common.DeploymentOverlay {
+	volume, mount := common.SecretVolume("cloud-sa", cloudConfigSecretName, "cloud-sa.json", "/etc/cloud-sa")
+	return common.DeploymentOverlay{
+		Name: "equinix-metal-cloud-controller-manager",
+		Exec: "/bin/cloud-provider-equinix-metal",
+		Args: []string{"--cloud-provider=equinixmetal", "--cloud-config=/etc/cloud-sa/cloud-sa.json"},
+		// Credentials in the Secret take precedence over the ones in cloud-sa.json,
+		// so they can be rotated independently from the rest of the configuration.
+		Env: []corev1.EnvVar{
+			common.SecretEnvVar("METAL_API_KEY", credentialsSecretName, "apiKey", true),
+			common.SecretEnvVar("METAL_PROJECT_ID", credentialsSecretName, "projectID", true),
+		},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Volumes:      []corev1.Volume{volume},
+	}
+}
+
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	images := &imagesReference{
+		CloudControllerManager: config.ImagesReference.CloudControllerManagerEquinixMetal,
+	}
+	_, err := govalidator.ValidateStruct(images)
+	if err != nil {
+		return nil, fmt.Errorf("%s: missed images in config: %v", providerName, err)
+	}
+	assets := &equinixMetalAssets{
+		operatorConfig: config,
+	}
+	templateValues, err := getTemplateValues(images, config)
+	if err != nil {
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
+	}
+
+	deployment, err := common.RenderBaseDeployment(getDeploymentOverlay(), templateValues)
+	if err != nil {
+		return nil, err
+	}
+	assets.renderedResources = []client.Object{deployment}
+	return assets, nil
+}
@@ -0,0 +1,72 @@
+package equinixmetal
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		config     config.OperatorConfig
+		initErrMsg string
+	}{
+		{
+			name:       "Empty config",
+			config:     config.OperatorConfig{},
+			initErrMsg: "equinixmetal: missed images in config: CloudControllerManager: non zero value required",
+		}, {
+			name: "No infra name",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerEquinixMetal: "CloudControllerManagerEquinixMetal",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "equinixmetal",
+			},
+			initErrMsg: "can not construct template values for equinixmetal assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerEquinixMetal: "CloudControllerManagerEquinixMetal",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "equinixmetal",
+				InfrastructureName:   "infra",
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(tc.config)
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			resources := assets.GetRenderedResources()
+			assert.Len(t, resources, 1)
+		})
+	}
+}
+
+func TestIsEquinixMetal(t *testing.T) {
+	assert.True(t, IsEquinixMetal("equinixmetal"))
+	assert.True(t, IsEquinixMetal("EquinixMetal"))
+	assert.True(t, IsEquinixMetal("equinix-metal"))
+	assert.True(t, IsEquinixMetal("packet"))
+	assert.False(t, IsEquinixMetal(""))
+	assert.False(t, IsEquinixMetal("hetzner"))
+}
@@ -0,0 +1,215 @@
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+const (
+	// trustedCAVolumeName is the name of the volume holding the cluster trusted CA bundle,
+	// the same name is used by the built-in assets.
+	trustedCAVolumeName = "trusted-ca"
+	// trustedCAConfigMapName is the ConfigMap the trusted CA bundle is synced to in the managed namespace.
+	trustedCAConfigMapName = "ccm-trusted-ca"
+	trustedCAMountPath     = "/etc/pki/ca-trust/extracted/pem"
+)
+
+// referenceObjects lists the kinds which are decoded into typed objects, so the common substitutions and
+// the kind specific apply logic are used for them. Any other kind is applied as an unstructured object.
+var referenceObjects = map[schema.GroupVersionKind]client.Object{
+	appsv1.SchemeGroupVersion.WithKind("Deployment"):                                        &appsv1.Deployment{},
+	appsv1.SchemeGroupVersion.WithKind("DaemonSet"):                                         &appsv1.DaemonSet{},
+	corev1.SchemeGroupVersion.WithKind("ConfigMap"):                                         &corev1.ConfigMap{},
+	corev1.SchemeGroupVersion.WithKind("Service"):                                           &corev1.Service{},
+	policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):                             &policyv1.PodDisruptionBudget{},
+	rbacv1.SchemeGroupVersion.WithKind("Role"):                                              &rbacv1.Role{},
+	rbacv1.SchemeGroupVersion.WithKind("ClusterRole"):                                       &rbacv1.ClusterRole{},
+	rbacv1.SchemeGroupVersion.WithKind("RoleBinding"):                                       &rbacv1.RoleBinding{},
+	rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"):                                &rbacv1.ClusterRoleBinding{},
+	admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicy"):        &admissionregistrationv1.ValidatingAdmissionPolicy{},
+	admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicyBinding"): &admissionregistrationv1.ValidatingAdmissionPolicyBinding{},
+}
+
+type externalAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *externalAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+// NewProviderAssets renders the user-supplied manifests of the External platform pass-through mode.
+// Each manifest is a template rendered with the same kind of values the built-in assets are rendered with,
+// which allows referencing e.g. the infrastructure name or the operator images without hardcoding them.
+// Rendered workloads placed into the managed namespace get the cluster trusted CA bundle mounted, and the
+// cloud-controller-manager label the common PodDisruptionBudget and Service select on.
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	if len(config.ExternalManifests) == 0 {
+		return nil, fmt.Errorf("no manifests supplied for the %s platform", config.GetPlatformNameString())
+	}
+
+	values := common.TemplateValues{
+		"images":               config.ImagesReference,
+		"infrastructureName":   config.InfrastructureName,
+		"clusterID":            config.ClusterID,
+		"apiServerURL":         config.APIServerURL,
+		"apiServerInternalURL": config.APIServerInternalURL,
+		"baseDomain":           config.BaseDomain,
+		"clusterCIDR":          strings.Join(config.ClusterCIDRs, ","),
+		"cloudproviderName":    config.GetPlatformNameString(),
+		"platformName":         config.ExternalPlatformName,
+		"managedNamespace":     config.ManagedNamespace,
+	}
+
+	// Manifests are rendered in the order of their names, so the result is stable across syncs.
+	names := make([]string, 0, len(config.ExternalManifests))
+	for name := range config.ExternalManifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assets := &externalAssets{
+		operatorConfig: config,
+	}
+	for _, name := range names {
+		objects, err := renderManifest(name, config.ExternalManifests[name], values)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			assets.renderedResources = append(assets.renderedResources, substituteObject(config, obj))
+		}
+	}
+	return assets, nil
+}
+
+// renderManifest renders a single, possibly multi-document, manifest and decodes every document in it.
+func renderManifest(name, content string, values common.TemplateValues) ([]client.Object, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: can not parse template: %w", name, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, values); err != nil {
+		return nil, fmt.Errorf("%s: can not render template: %w", name, err)
+	}
+
+	objects := []client.Object{}
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(buf))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: can not read manifest: %w", name, err)
+		}
+		obj, err := decodeObject(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		} else if obj == nil {
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// decodeObject decodes a single document, returning nil if the document holds no object, e.g. only comments.
+func decodeObject(doc []byte) (client.Object, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(doc, &raw); err != nil {
+		return nil, fmt.Errorf("can not decode manifest: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return nil, fmt.Errorf("can not decode manifest: %w", err)
+	}
+
+	var obj client.Object = &unstructured.Unstructured{}
+	if reference, ok := referenceObjects[typeMeta.GroupVersionKind()]; ok {
+		obj = reference.DeepCopyObject().(client.Object)
+	}
+	if err := yaml.UnmarshalStrict(doc, obj); err != nil {
+		return nil, fmt.Errorf("can not decode manifest: %w", err)
+	}
+	if err := common.ValidateRenderedObject(obj, nil); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// substituteObject applies the pass-through specific substitutions to workloads in the managed namespace.
+// Common substitutions, such as the cluster wide proxy settings, are applied to all assets later on.
+func substituteObject(config config.OperatorConfig, obj client.Object) client.Object {
+	if obj.GetNamespace() != config.ManagedNamespace {
+		return obj
+	}
+
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		if _, ok := o.Spec.Template.Labels[common.CloudControllerManagerProviderLabel]; !ok {
+			if o.Spec.Template.Labels == nil {
+				o.Spec.Template.Labels = map[string]string{}
+			}
+			o.Spec.Template.Labels[common.CloudControllerManagerProviderLabel] = config.GetPlatformNameString()
+		}
+		o.Spec.Template.Spec = setTrustedCABundle(o.Spec.Template.Spec)
+	case *appsv1.DaemonSet:
+		o.Spec.Template.Spec = setTrustedCABundle(o.Spec.Template.Spec)
+	}
+	return obj
+}
+
+// setTrustedCABundle mounts the cluster trusted CA bundle into every container, the same way the built-in
+// assets do, unless the manifest already defines a volume with the same name.
+func setTrustedCABundle(p corev1.PodSpec) corev1.PodSpec {
+	for _, volume := range p.Volumes {
+		if volume.Name == trustedCAVolumeName {
+			return p
+		}
+	}
+
+	p.Volumes = append(p.Volumes, corev1.Volume{
+		Name: trustedCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: trustedCAConfigMapName},
+				Items: []corev1.KeyToPath{
+					{Key: "ca-bundle.crt", Path: "tls-ca-bundle.pem"},
+				},
+			},
+		},
+	})
+	for i := range p.Containers {
+		p.Containers[i].VolumeMounts = append(p.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      trustedCAVolumeName,
+			MountPath: trustedCAMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return p
+}
@@ -0,0 +1,172 @@
+package external
+
+import (
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+const deploymentManifest = `# Cloud controller manager of an out-of-tree provider
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: acme-cloud-controller-manager
+  namespace: {{ .managedNamespace }}
+spec:
+  selector:
+    matchLabels:
+      k8s-app: acme-cloud-controller-manager
+  template:
+    metadata:
+      labels:
+        k8s-app: acme-cloud-controller-manager
+    spec:
+      containers:
+        - name: cloud-controller-manager
+          image: quay.io/acme/cloud-controller-manager:v1.0.0
+          args:
+            - --cluster-name={{ .infrastructureName }}
+`
+
+const rbacManifest = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: acme-cloud-controller-manager
+rules:
+  - apiGroups: [""]
+    resources: ["nodes"]
+    verbs: ["get", "list", "watch", "update", "patch"]
+---
+---
+apiVersion: acme.example.com/v1
+kind: CloudProviderSettings
+metadata:
+  name: cluster
+spec:
+  region: east
+`
+
+func getConfig(manifests map[string]string) config.OperatorConfig {
+	return config.OperatorConfig{
+		ManagedNamespace:     "openshift-cloud-controller-manager",
+		InfrastructureName:   "my-cluster",
+		PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+		ExternalPlatformName: "acme",
+		ExternalManifests:    manifests,
+	}
+}
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		manifests  map[string]string
+		kinds      []string
+		initErrMsg string
+	}{
+		{
+			name:       "No manifests",
+			initErrMsg: "no manifests supplied for the External platform",
+		}, {
+			name: "Manifests are rendered in the order of their names",
+			manifests: map[string]string{
+				"01-rbac.yaml":       rbacManifest,
+				"02-deployment.yaml": deploymentManifest,
+			},
+			kinds: []string{"ClusterRole", "CloudProviderSettings", "Deployment"},
+		}, {
+			name: "Unknown template value",
+			manifests: map[string]string{
+				"deployment.yaml": "{{ .region }}",
+			},
+			initErrMsg: `deployment.yaml: can not render template: template: deployment.yaml:1:3: executing "deployment.yaml" at <.region>: map has no entry for key "region"`,
+		}, {
+			name: "Invalid manifest",
+			manifests: map[string]string{
+				"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: foo\n",
+			},
+			initErrMsg: `deployment.yaml: invalid Deployment "foo": [metadata.namespace: Required value, spec.selector: Required value, spec.template.spec.containers: Required value]`,
+		}, {
+			name: "Unknown field",
+			manifests: map[string]string{
+				"rbac.yaml": "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: foo\nspec: {}\n",
+			},
+			initErrMsg: `rbac.yaml: can not decode manifest: error unmarshaling JSON: while decoding JSON: json: unknown field "spec"`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(getConfig(tc.manifests))
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			kinds := []string{}
+			for _, resource := range assets.GetRenderedResources() {
+				kinds = append(kinds, resource.GetObjectKind().GroupVersionKind().Kind)
+			}
+			assert.Equal(t, tc.kinds, kinds)
+		})
+	}
+}
+
+func TestRenderedResourceTypes(t *testing.T) {
+	assets, err := NewProviderAssets(getConfig(map[string]string{
+		"01-rbac.yaml":       rbacManifest,
+		"02-deployment.yaml": deploymentManifest,
+	}))
+	assert.NoError(t, err)
+
+	resources := assets.GetRenderedResources()
+	assert.Len(t, resources, 3)
+	assert.IsType(t, &rbacv1.ClusterRole{}, resources[0])
+	assert.IsType(t, &unstructured.Unstructured{}, resources[1])
+	assert.IsType(t, &appsv1.Deployment{}, resources[2])
+}
+
+func TestDeploymentSubstitution(t *testing.T) {
+	assets, err := NewProviderAssets(getConfig(map[string]string{"deployment.yaml": deploymentManifest}))
+	assert.NoError(t, err)
+
+	resources := assets.GetRenderedResources()
+	assert.Len(t, resources, 1)
+	deployment := resources[0].(*appsv1.Deployment)
+
+	assert.Equal(t, "openshift-cloud-controller-manager", deployment.Namespace)
+	assert.Equal(t, []string{"--cluster-name=my-cluster"}, deployment.Spec.Template.Spec.Containers[0].Args)
+
+	// The label selected by the common PodDisruptionBudget and Service is set on the pods only,
+	// as the Deployment selector is immutable.
+	assert.Equal(t, "External", deployment.Spec.Template.Labels[common.CloudControllerManagerProviderLabel])
+	assert.NotContains(t, deployment.Spec.Selector.MatchLabels, common.CloudControllerManagerProviderLabel)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "trusted-ca", podSpec.Volumes[0].Name)
+	assert.Equal(t, "ccm-trusted-ca", podSpec.Volumes[0].ConfigMap.Name)
+	assert.Len(t, podSpec.Containers[0].VolumeMounts, 1)
+	assert.Equal(t, "/etc/pki/ca-trust/extracted/pem", podSpec.Containers[0].VolumeMounts[0].MountPath)
+}
+
+func TestDeploymentOutsideOfManagedNamespaceIsNotSubstituted(t *testing.T) {
+	manifest := strings.Replace(deploymentManifest, "{{ .managedNamespace }}", "kube-system", 1)
+	assets, err := NewProviderAssets(getConfig(map[string]string{"deployment.yaml": manifest}))
+	assert.NoError(t, err)
+
+	deployment := assets.GetRenderedResources()[0].(*appsv1.Deployment)
+	assert.Equal(t, "kube-system", deployment.Namespace)
+	assert.NotContains(t, deployment.Spec.Template.Labels, common.CloudControllerManagerProviderLabel)
+	assert.Empty(t, deployment.Spec.Template.Spec.Volumes)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -16,6 +17,9 @@ import (
 
 const providerName = "gcp"
 
+// supportedArchitectures lists the node architectures operands can be scheduled on, clusters may mix them.
+var supportedArchitectures = []string{"amd64", "arm64"}
+
 var (
 	//go:embed assets/*.yaml
 	assetsFs  embed.FS
@@ -28,6 +32,21 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                   providerName,
+		PlatformType:           configv1.GCPPlatformType,
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+		CloudConfigValidator:   ValidateCloudConfig,
+		CloudConfigLinter:      LintCloudConfig,
+		AllowedArgs: []string{
+			"cloud-provider-gce-lb-src-cidrs",
+			"cloud-provider-gce-l7lb-src-cidrs",
+		},
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
@@ -47,6 +66,11 @@ func (assets *GCPAssets) GetRenderedResources() []client.Object {
 	return assets.renderedResources
 }
 
+// GetSupportedArchitectures returns the architectures the GCP cloud provider images are built for.
+func (assets *GCPAssets) GetSupportedArchitectures() []string {
+	return supportedArchitectures
+}
+
 func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
 	values := common.TemplateValues{
 		"images":             images,
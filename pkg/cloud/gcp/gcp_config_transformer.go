@@ -0,0 +1,161 @@
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+	ini "gopkg.in/ini.v1"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+)
+
+const (
+	globalSection = "global"
+	projectIDKey  = "project-id"
+
+	// computeAPIPath is the path of the Compute API, which has to be part of the api-endpoint
+	// as the cloud provider uses it as the base path of the Compute API client.
+	computeAPIPath = "compute/v1/"
+)
+
+// CloudConfigTransformer implements the cloudConfigTransformer. It takes the user-provided configuration
+// and sets the custom API endpoints from the infrastructure, so the cloud provider talks to the same endpoints
+// as the rest of the cluster, e.g. when using Private Service Connect or the restricted VIP.
+// Only the endpoints of the services the cloud provider consumes are set; endpoints from the infrastructure
+// take precedence over the ones in the user-provided configuration.
+// Options of a gce.conf written for the legacy in-tree provider are translated to their documented names first.
+// It returns an error if the platform is not GCPPlatformType or if the configuration can not be parsed.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.GCPPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.GCPPlatformType)
+	}
+
+	cfg, err := loadConfig(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	translated := translateLegacyConfig(cfg)
+
+	var projectID string
+	var endpoints []configv1.GCPServiceEndpoint
+	if gcpStatus := infra.Status.PlatformStatus.GCP; gcpStatus != nil {
+		projectID = gcpStatus.ProjectID
+		endpoints = gcpStatus.ServiceEndpoints
+	}
+
+	global := cfg.Section(globalSection)
+	// The project is backfilled from the infrastructure, so a minimal config does not depend on the metadata server.
+	backfillProjectID := projectID != "" && global.Key(projectIDKey).String() == ""
+	if !translated && !backfillProjectID && len(endpoints) == 0 {
+		// Nothing to set, keep the source as it is.
+		return source, nil
+	}
+
+	if backfillProjectID {
+		global.Key(projectIDKey).SetValue(projectID)
+	}
+	for _, endpoint := range endpoints {
+		var key, value string
+		switch endpoint.Name {
+		case configv1.GCPServiceEndpointNameCompute:
+			key = "api-endpoint"
+			value, err = withDefaultPath(endpoint.URL, computeAPIPath)
+			if err != nil {
+				return "", fmt.Errorf("invalid %s endpoint: %w", endpoint.Name, err)
+			}
+		case configv1.GCPServiceEndpointNameContainer:
+			key, value = "container-api-endpoint", endpoint.URL
+		default:
+			// Not consumed by the cloud provider.
+			continue
+		}
+		global.Key(key).SetValue(value)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := cfg.WriteTo(buf); err != nil {
+		return "", fmt.Errorf("failed to write the cloud.conf: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// ValidateCloudConfig checks the synced cloud config: it has to be an ini file whose boolean options
+// have boolean values, and whose Shared VPC options are complete.
+func ValidateCloudConfig(source string) error {
+	cfg, err := loadConfig(source)
+	if err != nil {
+		return fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	global := cfg.Section(globalSection)
+	for _, key := range []string{"regional", "multizone"} {
+		if !global.HasKey(key) {
+			continue
+		}
+		if _, err := global.Key(key).Bool(); err != nil {
+			return fmt.Errorf("invalid value %q for %q, expected a boolean", global.Key(key).String(), key)
+		}
+	}
+	return validateSharedVPCOptions(global)
+}
+
+// LintCloudConfig reports the options of the synced cloud config the cloud provider ignores: unknown options of
+// the [global] section, other sections, and a multizone option disabled on a regional cluster.
+func LintCloudConfig(source string) []string {
+	cfg, err := loadConfig(source)
+	if err != nil {
+		return nil
+	}
+
+	var findings []string
+	for _, section := range cfg.Sections() {
+		switch name := section.Name(); {
+		case name == ini.DefaultSection:
+			if len(section.Keys()) > 0 {
+				findings = append(findings, fmt.Sprintf("options outside of a section are ignored by the cloud provider, move them to the [%s] section", globalSection))
+			}
+		case name != globalSection:
+			findings = append(findings, fmt.Sprintf("section [%s] is ignored by the cloud provider, move its options to the [%s] section", name, globalSection))
+		}
+	}
+
+	global := cfg.Section(globalSection)
+	for _, key := range global.KeyStrings() {
+		if slices.Contains(globalOptions, key) {
+			continue
+		}
+		if closest := common.ClosestOption(key, globalOptions); closest != "" {
+			findings = append(findings, fmt.Sprintf("option %q is unknown to the cloud provider, did you mean %q?", key, closest))
+		} else {
+			findings = append(findings, fmt.Sprintf("option %q is unknown to the cloud provider and ignored, remove it", key))
+		}
+	}
+
+	if regional, err := global.Key("regional").Bool(); err == nil && regional {
+		if multizone, err := global.Key("multizone").Bool(); err == nil && !multizone {
+			findings = append(findings, "option \"multizone\" is disabled on a regional cluster, which always spans multiple zones, remove it")
+		}
+	}
+	return findings
+}
+
+// withDefaultPath appends the given path to the endpoint if it does not specify any.
+func withDefaultPath(endpoint, path string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		u.Path = "/" + path
+	} else if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,325 @@
+package gcp
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeInfrastructureResource(platform configv1.PlatformType, endpoints ...configv1.GCPServiceEndpoint) *configv1.Infrastructure {
+	infra := &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{Type: platform},
+		},
+	}
+	if platform == configv1.GCPPlatformType {
+		infra.Status.PlatformStatus.GCP = &configv1.GCPPlatformStatus{
+			ProjectID:        "openshift",
+			Region:           "us-central1",
+			ServiceEndpoints: endpoints,
+		}
+	}
+	return infra
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.AWSPlatformType),
+			errMsg: "invalid platform, expected to be GCP",
+		}, {
+			name: "No custom endpoints",
+			source: `[global]
+project-id = openshift
+`,
+			infra: makeInfrastructureResource(configv1.GCPPlatformType),
+			expected: `[global]
+project-id = openshift
+`,
+		}, {
+			name: "Custom endpoints",
+			source: `[global]
+project-id = openshift
+regional   = true
+`,
+			infra: makeInfrastructureResource(configv1.GCPPlatformType,
+				configv1.GCPServiceEndpoint{Name: configv1.GCPServiceEndpointNameCompute, URL: "https://compute-openshift.p.googleapis.com"},
+				configv1.GCPServiceEndpoint{Name: configv1.GCPServiceEndpointNameContainer, URL: "https://container-openshift.p.googleapis.com"},
+				configv1.GCPServiceEndpoint{Name: configv1.GCPServiceEndpointNameStorage, URL: "https://storage-openshift.p.googleapis.com"},
+			),
+			expected: `[global]
+project-id             = openshift
+regional               = true
+api-endpoint           = https://compute-openshift.p.googleapis.com/compute/v1/
+container-api-endpoint = https://container-openshift.p.googleapis.com
+`,
+		}, {
+			name: "Custom compute endpoint with a path overrides the source",
+			source: `[global]
+api-endpoint = https://www.googleapis.com/compute/v1/
+`,
+			infra: makeInfrastructureResource(configv1.GCPPlatformType,
+				configv1.GCPServiceEndpoint{Name: configv1.GCPServiceEndpointNameCompute, URL: "https://private.googleapis.com/compute/v1"},
+			),
+			expected: `[global]
+api-endpoint = https://private.googleapis.com/compute/v1/
+project-id   = openshift
+`,
+		}, {
+			name:   "Empty source with the project backfilled from the infrastructure",
+			source: "",
+			infra: makeInfrastructureResource(configv1.GCPPlatformType,
+				configv1.GCPServiceEndpoint{Name: configv1.GCPServiceEndpointNameCompute, URL: "https://restricted.googleapis.com"},
+			),
+			expected: `[global]
+project-id   = openshift
+api-endpoint = https://restricted.googleapis.com/compute/v1/
+`,
+		}, {
+			name: "Legacy in-tree config",
+			source: `[Global]
+ProjectID      = openshift
+NetworkName    = shared-network
+subnetworkname = shared-subnet
+node-tags      = openshift-master
+node-tags      = openshift-worker
+Multizone      = true
+`,
+			infra: makeInfrastructureResource(configv1.GCPPlatformType),
+			expected: `[global]
+project-id      = openshift
+network-name    = shared-network
+subnetwork-name = shared-subnet
+node-tags       = openshift-master
+node-tags       = openshift-worker
+multizone       = true
+`,
+		}, {
+			name: "Legacy option names within the global section",
+			source: `[global]
+project-id  = openshift
+NetworkName = shared-network
+network-name = other-network
+node-tags   = openshift-master
+NodeTags    = openshift-worker
+unknown     = foo
+`,
+			infra: makeInfrastructureResource(configv1.GCPPlatformType),
+			expected: `[global]
+project-id   = openshift
+network-name = other-network
+node-tags    = openshift-master
+node-tags    = openshift-worker
+unknown      = foo
+`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestMergeSharedVPCOptions(t *testing.T) {
+	source := `[global]
+project-id = openshift
+regional   = true
+`
+
+	tc := []struct {
+		name     string
+		source   string
+		options  map[string]string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "No options",
+			source:   source,
+			expected: source,
+		}, {
+			name:   "Shared VPC options",
+			source: source,
+			options: map[string]string{
+				"network-project-id": "host-project",
+				"network-name":       "shared-network",
+				"subnetwork-name":    "worker-subnet",
+			},
+			expected: `[global]
+project-id         = openshift
+regional           = true
+network-name       = shared-network
+network-project-id = host-project
+subnetwork-name    = worker-subnet
+`,
+		}, {
+			name: "Network name from the source",
+			source: `[global]
+project-id   = openshift
+network-name = shared-network
+`,
+			options: map[string]string{"network-project-id": "host-project"},
+			expected: `[global]
+project-id         = openshift
+network-name       = shared-network
+network-project-id = host-project
+`,
+		}, {
+			name: "Repeated node tags are kept",
+			source: `[global]
+network-name = shared-network
+node-tags    = openshift-master
+node-tags    = openshift-worker
+`,
+			options: map[string]string{"network-project-id": "host-project"},
+			expected: `[global]
+network-name       = shared-network
+node-tags          = openshift-master
+node-tags          = openshift-worker
+network-project-id = host-project
+`,
+		}, {
+			name:    "Unsupported option",
+			source:  source,
+			options: map[string]string{"node-tags": "worker"},
+			errMsg:  `unsupported Shared VPC option "node-tags"`,
+		}, {
+			name:    "Empty value",
+			source:  source,
+			options: map[string]string{"network-project-id": ""},
+			errMsg:  `invalid value for Shared VPC option "network-project-id": must not be empty`,
+		}, {
+			name:    "Missing network name",
+			source:  source,
+			options: map[string]string{"network-project-id": "host-project"},
+			errMsg:  `invalid Shared VPC options: "network-project-id" requires "network-name" to be set`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := MergeSharedVPCOptions(tc.source, tc.options)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestValidateCloudConfig(t *testing.T) {
+	tc := []struct {
+		name   string
+		source string
+		errMsg string
+	}{
+		{
+			name: "Valid config",
+			source: `[global]
+project-id   = openshift
+regional     = true
+network-name = shared-network
+`,
+		}, {
+			name: "Invalid boolean",
+			source: `[global]
+multizone = maybe
+`,
+			errMsg: `invalid value "maybe" for "multizone", expected a boolean`,
+		}, {
+			name: "Incomplete Shared VPC options",
+			source: `[global]
+subnetwork-name = worker-subnet
+`,
+			errMsg: `invalid Shared VPC options: "subnetwork-name" requires "network-name" to be set`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCloudConfig(tc.source)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLintCloudConfig(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name: "No findings",
+			source: `[global]
+project-id = openshift
+regional   = true
+multizone  = true
+node-tags  = openshift-master
+node-tags  = openshift-worker
+`,
+		}, {
+			name: "Unknown options",
+			source: `[global]
+project-id    = openshift
+netwrok-name  = shared-network
+custom-option = foo
+`,
+			expected: []string{
+				`option "netwrok-name" is unknown to the cloud provider, did you mean "network-name"?`,
+				`option "custom-option" is unknown to the cloud provider and ignored, remove it`,
+			},
+		}, {
+			name: "Ignored sections",
+			source: `project-id = openshift
+
+[global]
+regional = true
+
+[LoadBalancer]
+enabled = true
+`,
+			expected: []string{
+				"options outside of a section are ignored by the cloud provider, move them to the [global] section",
+				"section [LoadBalancer] is ignored by the cloud provider, move its options to the [global] section",
+			},
+		}, {
+			name: "Multizone disabled on a regional cluster",
+			source: `[global]
+regional  = true
+multizone = false
+`,
+			expected: []string{
+				`option "multizone" is disabled on a regional cluster, which always spans multiple zones, remove it`,
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, LintCloudConfig(tc.source))
+		})
+	}
+}
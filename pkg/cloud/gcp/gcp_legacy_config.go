@@ -0,0 +1,101 @@
+package gcp
+
+import (
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+const nodeTagsKey = "node-tags"
+
+// globalOptions lists the options of the [global] section in the form the external cloud provider documents them.
+// The legacy in-tree provider read the gce.conf with the same parser, which also accepts the section and option names
+// in any case and option names without dashes, e.g. `[Global]` and `NetworkName`, as they match the Go field names.
+var globalOptions = []string{
+	"token-url",
+	"token-body",
+	"project-id",
+	"network-project-id",
+	"network-name",
+	"subnetwork-name",
+	"secondary-range-name",
+	nodeTagsKey,
+	"node-instance-prefix",
+	"regional",
+	"multizone",
+	"api-endpoint",
+	"container-api-endpoint",
+	"local-zone",
+	"alpha-features",
+}
+
+// loadConfig parses the given gce.conf, keeping all values of the options which can be repeated, such as node-tags.
+func loadConfig(source string) (*ini.File, error) {
+	return ini.LoadSources(ini.LoadOptions{AllowShadows: true}, []byte(source))
+}
+
+// translateLegacyConfig moves the options of a gce.conf written for the legacy in-tree provider into the [global]
+// section under their documented names, so they are found when the config is transformed, merged and validated.
+// All values of the node-tags option are kept, for other options the last value wins, as they can only be set once.
+// It returns whether the config was changed.
+func translateLegacyConfig(cfg *ini.File) bool {
+	changed := false
+	var names []string
+	values := map[string][]string{}
+
+	for _, section := range cfg.Sections() {
+		if !strings.EqualFold(section.Name(), globalSection) {
+			continue
+		}
+		if section.Name() != globalSection {
+			changed = true
+		}
+
+		for _, key := range section.Keys() {
+			name := canonicalGlobalOption(key.Name())
+			if name != key.Name() {
+				changed = true
+			}
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			} else if name != nodeTagsKey {
+				// The option is set more than once, e.g. under its legacy and documented names.
+				changed = true
+			}
+			if name == nodeTagsKey {
+				values[name] = append(values[name], key.ValueWithShadows()...)
+			} else {
+				values[name] = key.ValueWithShadows()[len(key.ValueWithShadows())-1:]
+			}
+		}
+	}
+
+	if !changed {
+		return false
+	}
+
+	for _, section := range cfg.Sections() {
+		if strings.EqualFold(section.Name(), globalSection) {
+			cfg.DeleteSection(section.Name())
+		}
+	}
+	global := cfg.Section(globalSection)
+	for _, name := range names {
+		for _, value := range values[name] {
+			// Adding a value to an existing key only fails for boolean keys, which loadConfig does not allow.
+			_, _ = global.NewKey(name, value)
+		}
+	}
+	return true
+}
+
+// canonicalGlobalOption returns the documented name of the given [global] option,
+// or the name itself for options the cloud provider does not know.
+func canonicalGlobalOption(name string) string {
+	for _, option := range globalOptions {
+		if strings.EqualFold(strings.ReplaceAll(name, "-", ""), strings.ReplaceAll(option, "-", "")) {
+			return option
+		}
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/ini.v1"
+)
+
+const (
+	networkProjectIDKey = "network-project-id"
+	networkNameKey      = "network-name"
+	subnetworkNameKey   = "subnetwork-name"
+)
+
+// sharedVPCOptions lists the options users are allowed to set for Shared VPC (XPN) deployments,
+// where the cluster network lives in a host project different from the cluster project.
+// Options are set in the [global] section of the cloud.conf.
+var sharedVPCOptions = map[string]bool{
+	networkProjectIDKey: true,
+	networkNameKey:      true,
+	subnetworkNameKey:   true,
+}
+
+// MergeSharedVPCOptions takes the cloud.conf produced by the CloudConfigTransformer and merges the
+// user-provided Shared VPC options into its [global] section, so load balancers are created in the
+// network of the host project. User-provided options take precedence over the ones in the cloud.conf.
+// It returns an error if any of the options is unsupported or empty, or if the resulting network
+// settings are incomplete.
+func MergeSharedVPCOptions(source string, options map[string]string) (string, error) {
+	if len(options) == 0 {
+		return source, nil
+	}
+
+	cfg, err := loadConfig(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	global := cfg.Section(globalSection)
+
+	// Sort the keys so the resulting config is stable across syncs.
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !sharedVPCOptions[key] {
+			return "", fmt.Errorf("unsupported Shared VPC option %q", key)
+		}
+		if options[key] == "" {
+			return "", fmt.Errorf("invalid value for Shared VPC option %q: must not be empty", key)
+		}
+		global.Key(key).SetValue(options[key])
+	}
+
+	if err := validateSharedVPCOptions(global); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("failed to modify the provided configuration: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// validateSharedVPCOptions checks the Shared VPC options of the given [global] section are complete.
+func validateSharedVPCOptions(global *ini.Section) error {
+	// The cloud provider builds the network and subnetwork URLs from the network name,
+	// which is thus required to point it to the host project.
+	if global.Key(networkNameKey).String() == "" {
+		for _, key := range []string{networkProjectIDKey, subnetworkNameKey} {
+			if global.Key(key).String() != "" {
+				return fmt.Errorf("invalid Shared VPC options: %q requires %q to be set", key, networkNameKey)
+			}
+		}
+	}
+	return nil
+}
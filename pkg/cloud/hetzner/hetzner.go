@@ -0,0 +1,210 @@
+package hetzner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/utils/net"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+)
+
+const (
+	providerName = "hetzner"
+
+	// tokenSecretName is the Secret in the managed namespace holding the Hetzner Cloud API token
+	// under the "token" key. It is expected to be provided at install time.
+	tokenSecretName = "hcloud"
+)
+
+var (
+	// platformNames are the External platform names clusters installed on Hetzner are reported with.
+	platformNames = []string{providerName, "hcloud"}
+)
+
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         providerName,
+		PlatformType: configv1.ExternalPlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool {
+			return IsHetzner(externalPlatformName)
+		},
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+	})
+}
+
+type imagesReference struct {
+	CloudControllerManager string `valid:"required"`
+}
+
+var templateValuesValidationMap = map[string]interface{}{
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
+}
+
+type hetznerAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *hetznerAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+// IsHetzner returns true if the External platform name reported in the infrastructure refers to Hetzner Cloud.
+func IsHetzner(externalPlatformName string) bool {
+	for _, name := range platformNames {
+		if strings.EqualFold(externalPlatformName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
+	values := common.TemplateValues{
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
+	}
+	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getDeploymentOverlay returns the Hetzner parts of the base cloud-controller-manager Deployment. The cloud
+// controller manager is configured through env variables, which are read from the synced cloud config.
+func getDeploymentOverlay() common.DeploymentOverlay {
+	volume, mount := common.CloudConfigVolume("hcloud-config", "/etc/hcloud")
+	return common.DeploymentOverlay{
+		Name:         "hcloud-cloud-controller-manager",
+		Exec:         "/usr/bin/env $(grep -E '^[A-Z_]+=' /etc/hcloud/cloud.conf) /bin/hcloud-cloud-controller-manager",
+		Args:         []string{"--cloud-provider=hcloud"},
+		Env:          []corev1.EnvVar{common.SecretEnvVar("HCLOUD_TOKEN", tokenSecretName, "token", false)},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Volumes:      []corev1.Volume{volume},
+	}
+}
+
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	images := &imagesReference{
+		CloudControllerManager: config.ImagesReference.CloudControllerManagerHetzner,
+	}
+	_, err := govalidator.ValidateStruct(images)
+	if err != nil {
+		return nil, fmt.Errorf("%s: missed images in config: %v", providerName, err)
+	}
+	assets := &hetznerAssets{
+		operatorConfig: config,
+	}
+	templateValues, err := getTemplateValues(images, config)
+	if err != nil {
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
+	}
+
+	deployment, err := common.RenderBaseDeployment(getDeploymentOverlay(), templateValues)
+	if err != nil {
+		return nil, err
+	}
+	assets.renderedResources = []client.Object{deployment}
+	return assets, nil
+}
+
+// cloudConfig is the user-provided configuration of the Hetzner cloud provider,
+// referenced by the infrastructure cloudConfig field.
+type cloudConfig struct {
+	// Network is the name or ID of the private network the cluster nodes are attached to.
+	Network       string `json:"network,omitempty"`
+	LoadBalancers struct {
+		Location     string `json:"location,omitempty"`
+		NetworkZone  string `json:"networkZone,omitempty"`
+		UsePrivateIP *bool  `json:"usePrivateIP,omitempty"`
+	} `json:"loadBalancers,omitempty"`
+	Instances struct {
+		// AddressFamily is one of ipv4, ipv6 or dualstack. It is derived from the cluster network when omitted.
+		AddressFamily string `json:"addressFamily,omitempty"`
+	} `json:"instances,omitempty"`
+}
+
+// CloudConfigTransformer implements the cloudConfigTransformer. The Hetzner cloud-controller-manager is
+// configured through environment variables only, so it translates the user-provided YAML configuration
+// into an environment file which is loaded by the cloud-controller-manager container command.
+// It returns an error if the platform is not ExternalPlatformType or if the configuration is not valid.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.ExternalPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.ExternalPlatformType)
+	}
+
+	cfg := cloudConfig{}
+	if err := yaml.UnmarshalStrict([]byte(source), &cfg); err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	addressFamily := cfg.Instances.AddressFamily
+	if addressFamily == "" {
+		addressFamily = getAddressFamily(network)
+	}
+	switch addressFamily {
+	case "ipv4", "ipv6", "dualstack":
+	default:
+		return "", fmt.Errorf("unsupported instances address family %q, expected one of ipv4, ipv6 or dualstack", addressFamily)
+	}
+
+	env := map[string]string{
+		"HCLOUD_INSTANCES_ADDRESS_FAMILY": addressFamily,
+		// Routes are never configured in the cloud, as OpenShift uses an overlay network.
+		"HCLOUD_NETWORK_ROUTES_ENABLED": "false",
+	}
+	for key, value := range map[string]string{
+		"HCLOUD_NETWORK":                     cfg.Network,
+		"HCLOUD_LOAD_BALANCERS_LOCATION":     cfg.LoadBalancers.Location,
+		"HCLOUD_LOAD_BALANCERS_NETWORK_ZONE": cfg.LoadBalancers.NetworkZone,
+	} {
+		if value != "" {
+			env[key] = value
+		}
+	}
+	if cfg.LoadBalancers.UsePrivateIP != nil {
+		env["HCLOUD_LOAD_BALANCERS_USE_PRIVATE_IP"] = strconv.FormatBool(*cfg.LoadBalancers.UsePrivateIP)
+	}
+
+	return common.RenderEnvFile(env)
+}
+
+// getAddressFamily derives the instances address family from the cluster network CIDRs.
+func getAddressFamily(network *configv1.Network) string {
+	if network == nil {
+		return "ipv4"
+	}
+
+	var hasIPv4, hasIPv6 bool
+	for _, clusterNetwork := range network.Status.ClusterNetwork {
+		if utilnet.IsIPv6CIDRString(clusterNetwork.CIDR) {
+			hasIPv6 = true
+		} else {
+			hasIPv4 = true
+		}
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		return "dualstack"
+	case hasIPv6:
+		return "ipv6"
+	default:
+		return "ipv4"
+	}
+}
@@ -0,0 +1,165 @@
+package hetzner
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		config     config.OperatorConfig
+		initErrMsg string
+	}{
+		{
+			name:       "Empty config",
+			config:     config.OperatorConfig{},
+			initErrMsg: "hetzner: missed images in config: CloudControllerManager: non zero value required",
+		}, {
+			name: "No infra name",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerHetzner: "CloudControllerManagerHetzner",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "hetzner",
+			},
+			initErrMsg: "can not construct template values for hetzner assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerHetzner: "CloudControllerManagerHetzner",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "hetzner",
+				InfrastructureName:   "infra",
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(tc.config)
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			resources := assets.GetRenderedResources()
+			assert.Len(t, resources, 1)
+		})
+	}
+}
+
+func TestIsHetzner(t *testing.T) {
+	assert.True(t, IsHetzner("hetzner"))
+	assert.True(t, IsHetzner("Hetzner"))
+	assert.True(t, IsHetzner("hcloud"))
+	assert.False(t, IsHetzner(""))
+	assert.False(t, IsHetzner("oci"))
+}
+
+func makeInfrastructureResource(platform configv1.PlatformType) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{Type: platform},
+		},
+	}
+}
+
+func makeNetworkResource(cidrs ...string) *configv1.Network {
+	network := &configv1.Network{}
+	for _, cidr := range cidrs {
+		network.Status.ClusterNetwork = append(network.Status.ClusterNetwork, configv1.ClusterNetworkEntry{CIDR: cidr})
+	}
+	return network
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		network  *configv1.Network
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.AWSPlatformType),
+			errMsg: "invalid platform, expected to be External",
+		}, {
+			name:    "Empty config",
+			infra:   makeInfrastructureResource(configv1.ExternalPlatformType),
+			network: makeNetworkResource("10.128.0.0/14"),
+			expected: `HCLOUD_INSTANCES_ADDRESS_FAMILY=ipv4
+HCLOUD_NETWORK_ROUTES_ENABLED=false
+`,
+		}, {
+			name: "Full config",
+			source: `network: my-network
+loadBalancers:
+  location: fsn1
+  networkZone: eu-central
+  usePrivateIP: true
+instances:
+  addressFamily: ipv6
+`,
+			infra:   makeInfrastructureResource(configv1.ExternalPlatformType),
+			network: makeNetworkResource("10.128.0.0/14"),
+			expected: `HCLOUD_INSTANCES_ADDRESS_FAMILY=ipv6
+HCLOUD_LOAD_BALANCERS_LOCATION=fsn1
+HCLOUD_LOAD_BALANCERS_NETWORK_ZONE=eu-central
+HCLOUD_LOAD_BALANCERS_USE_PRIVATE_IP=true
+HCLOUD_NETWORK=my-network
+HCLOUD_NETWORK_ROUTES_ENABLED=false
+`,
+		}, {
+			name:    "Address family derived from dual stack cluster network",
+			infra:   makeInfrastructureResource(configv1.ExternalPlatformType),
+			network: makeNetworkResource("10.128.0.0/14", "fd01::/48"),
+			expected: `HCLOUD_INSTANCES_ADDRESS_FAMILY=dualstack
+HCLOUD_NETWORK_ROUTES_ENABLED=false
+`,
+		}, {
+			name:   "Unknown field",
+			source: "foo: bar",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "failed to read the cloud.conf: error unmarshaling JSON: while decoding JSON: json: unknown field \"foo\"",
+		}, {
+			name:   "Unsupported address family",
+			source: "instances:\n  addressFamily: ipv5",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "unsupported instances address family \"ipv5\", expected one of ipv4, ipv6 or dualstack",
+		}, {
+			name:   "Value which is not safe to expand in the shell",
+			source: "network: $(reboot)",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "invalid value \"$(reboot)\" for HCLOUD_NETWORK, only alphanumeric characters, '.', '_', ':', '/' and '-' are allowed",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, tc.network, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -4,9 +4,8 @@ import (
 	"embed"
 	"fmt"
 
-	configv1 "github.com/openshift/api/config/v1"
-
 	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -24,14 +23,22 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                   providerName,
+		PlatformType:           configv1.IBMCloudPlatformType,
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
 
 var templateValuesValidationMap = map[string]interface{}{
-	"images":               "required",
-	"enablePublicEndpoint": "required",
-	"cloudproviderName":    "required,type(string)",
+	"images":            "required",
+	"cloudproviderName": "required,type(string)",
 }
 
 type ibmAssets struct {
@@ -43,11 +50,25 @@ func (assets *ibmAssets) GetRenderedResources() []client.Object {
 	return assets.renderedResources
 }
 
+// GetContainerProbes returns the probe parameters of the cloud-controller-manager container.
+// The CCM has to reach the IBM Cloud APIs, often through a proxy, before reporting healthy.
+func (assets *ibmAssets) GetContainerProbes() map[string]common.ContainerProbes {
+	return map[string]common.ContainerProbes{
+		"cloud-controller-manager": {
+			Liveness: &common.ProbeParameters{
+				InitialDelaySeconds: 300,
+				PeriodSeconds:       10,
+				TimeoutSeconds:      160,
+				FailureThreshold:    3,
+			},
+		},
+	}
+}
+
 func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
 	values := common.TemplateValues{
-		"images":               images,
-		"enablePublicEndpoint": fmt.Sprintf("%t", getEnablePublicEndpointValue(operatorConfig)),
-		"cloudproviderName":    operatorConfig.GetPlatformNameString(),
+		"images":            images,
+		"cloudproviderName": operatorConfig.GetPlatformNameString(),
 	}
 	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
 	if err != nil {
@@ -56,19 +77,6 @@ func getTemplateValues(images *imagesReference, operatorConfig config.OperatorCo
 	return values, nil
 }
 
-func getEnablePublicEndpointValue(operatorConfig config.OperatorConfig) bool {
-	if operatorConfig.PlatformStatus == nil {
-		return false
-	}
-	switch operatorConfig.PlatformStatus.Type {
-	case configv1.PowerVSPlatformType:
-		// For Power VS Platform enable public endpoints
-		return true
-	default:
-		return false
-	}
-}
-
 func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
 	images := &imagesReference{
 		CloudControllerManager: config.ImagesReference.CloudControllerManagerIBM,
@@ -0,0 +1,131 @@
+package ibm
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+	ini "gopkg.in/ini.v1"
+)
+
+// providerSection is the cloud.conf section holding the IBM cloud provider options.
+const providerSection = "provider"
+
+// credentialsFile is the path of the API key of the ibm-cloud-credentials secret in the cloud controller manager.
+const credentialsFile = "/etc/vpc/ibmcloud_api_key"
+
+// defaultConfigTemplate is the configuration of the IBM Cloud VPC provider generated when none is provided,
+// the VPC name is formatted with the infrastructure name, as the installer does when creating it.
+const defaultConfigTemplate = `[global]
+version = 1.1.0
+
+[kubernetes]
+config-file = ""
+
+[provider]
+cluster-default-provider = g2
+g2Credentials            = ` + credentialsFile + `
+g2VpcName                = %s-vpc
+`
+
+// endpointOverrideKeys maps the IBM Cloud services consumed by the cloud provider to the
+// options overriding their endpoints.
+var endpointOverrideKeys = map[string]string{
+	string(configv1.IBMCloudServiceIAM):             "iamEndpointOverride",
+	string(configv1.IBMCloudServiceVPC):             "g2EndpointOverride",
+	string(configv1.IBMCloudServiceResourceManager): "rmEndpointOverride",
+}
+
+// EndpointOverrideKey returns the cloud.conf option overriding the endpoint of the given IBM Cloud
+// service, and false if the service is not consumed by the cloud provider.
+func EndpointOverrideKey(service string) (string, bool) {
+	key, ok := endpointOverrideKeys[service]
+	return key, ok
+}
+
+// CloudConfigTransformer implements the cloudConfigTransformer. It takes the user-provided configuration,
+// or generates one for the VPC of the cluster if it is empty, and sets the cluster ID, account ID, region,
+// resource group and custom service endpoints of the IBM Cloud VPC from the infrastructure into its provider
+// section. Values from the infrastructure take precedence over the ones in the user-provided configuration.
+// It returns an error if the platform is not IBMCloudPlatformType or if the configuration can not be parsed.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.IBMCloudPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.IBMCloudPlatformType)
+	}
+
+	ibmStatus := infra.Status.PlatformStatus.IBMCloud
+	if ibmStatus == nil {
+		return source, nil
+	}
+
+	if strings.TrimSpace(source) == "" && infra.Status.InfrastructureName != "" {
+		source = fmt.Sprintf(defaultConfigTemplate, infra.Status.InfrastructureName)
+	}
+
+	accountID := accountIDFromCRN(ibmStatus.CISInstanceCRN)
+	if accountID == "" {
+		accountID = accountIDFromCRN(ibmStatus.DNSInstanceCRN)
+	}
+
+	options := map[string]string{
+		"accountID":           accountID,
+		"clusterID":           infra.Status.InfrastructureName,
+		"region":              ibmStatus.Location,
+		"g2ResourceGroupName": ibmStatus.ResourceGroupName,
+	}
+	for _, endpoint := range ibmStatus.ServiceEndpoints {
+		if key, ok := EndpointOverrideKey(string(endpoint.Name)); ok {
+			options[key] = endpoint.URL
+		}
+	}
+
+	return SetProviderOptions(source, options)
+}
+
+// accountIDFromCRN returns the ID of the account owning the resource with the given CRN,
+// e.g. crn:v1:bluemix:public:internet-svcs:global:a/<account ID>:<instance ID>::, or an empty string
+// if the CRN has no account scope.
+func accountIDFromCRN(crn string) string {
+	segments := strings.Split(crn, ":")
+	if len(segments) < 7 || !strings.HasPrefix(segments[6], "a/") {
+		return ""
+	}
+	return strings.TrimPrefix(segments[6], "a/")
+}
+
+// SetProviderOptions sets the given options in the provider section of the cloud.conf.
+// Options with an empty value are left untouched.
+func SetProviderOptions(source string, options map[string]string) (string, error) {
+	// Sort the keys so the resulting config is stable across syncs.
+	keys := make([]string, 0, len(options))
+	for key, value := range options {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return source, nil
+	}
+	sort.Strings(keys)
+
+	// Quoted values, e.g. the empty kubernetes config-file, are kept as they are.
+	cfg, err := ini.LoadSources(ini.LoadOptions{PreserveSurroundedQuote: true}, []byte(source))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	provider := cfg.Section(providerSection)
+	for _, key := range keys {
+		provider.Key(key).SetValue(options[key])
+	}
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("failed to write the cloud.conf: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,156 @@
+package ibm
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeInfrastructureResource(platform configv1.PlatformType, status *configv1.IBMCloudPlatformStatus) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{
+				Type:     platform,
+				IBMCloud: status,
+			},
+		},
+	}
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+	source := `[global]
+version = 1.1.0
+
+[kubernetes]
+config-file = ""
+
+[provider]
+accountID                = 1e1f75646aef447814a6d907cc83fb3c
+clusterID                = ocp-cluster-id
+cluster-default-provider = g2
+region                   = us-south
+g2Credentials            = /etc/vpc/ibmcloud_api_key
+g2ResourceGroupName      = ocp-cluster-rg
+`
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.PowerVSPlatformType, nil),
+			errMsg: "invalid platform, expected to be IBMCloud",
+		}, {
+			name:     "No platform status",
+			source:   source,
+			infra:    makeInfrastructureResource(configv1.IBMCloudPlatformType, nil),
+			expected: source,
+		}, {
+			name:   "Location, resource group and service endpoints",
+			source: source,
+			infra: makeInfrastructureResource(configv1.IBMCloudPlatformType, &configv1.IBMCloudPlatformStatus{
+				Location:          "eu-de",
+				ResourceGroupName: "other-rg",
+				ServiceEndpoints: []configv1.IBMCloudServiceEndpoint{
+					{Name: configv1.IBMCloudServiceIAM, URL: "https://private.iam.cloud.ibm.com"},
+					{Name: configv1.IBMCloudServiceVPC, URL: "https://eu-de.private.iaas.cloud.ibm.com/v1"},
+					{Name: configv1.IBMCloudServiceResourceManager, URL: "https://private.resource-controller.cloud.ibm.com"},
+					{Name: configv1.IBMCloudServiceCOS, URL: "https://s3.direct.eu-de.cloud-object-storage.appdomain.cloud"},
+				},
+			}),
+			expected: `[global]
+version = 1.1.0
+
+[kubernetes]
+config-file = ""
+
+[provider]
+accountID                = 1e1f75646aef447814a6d907cc83fb3c
+clusterID                = ocp-cluster-id
+cluster-default-provider = g2
+region                   = eu-de
+g2Credentials            = /etc/vpc/ibmcloud_api_key
+g2ResourceGroupName      = other-rg
+g2EndpointOverride       = https://eu-de.private.iaas.cloud.ibm.com/v1
+iamEndpointOverride      = https://private.iam.cloud.ibm.com
+rmEndpointOverride       = https://private.resource-controller.cloud.ibm.com
+`,
+		}, {
+			name:   "Empty values are left untouched",
+			source: source,
+			infra: makeInfrastructureResource(configv1.IBMCloudPlatformType, &configv1.IBMCloudPlatformStatus{
+				ProviderType: configv1.IBMCloudProviderTypeVPC,
+			}),
+			expected: source,
+		}, {
+			name:   "Generated from the infrastructure",
+			source: "",
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.IBMCloudPlatformType, &configv1.IBMCloudPlatformStatus{
+					Location:          "us-south",
+					ResourceGroupName: "ocp-cluster-rg",
+					ProviderType:      configv1.IBMCloudProviderTypeVPC,
+					CISInstanceCRN:    "crn:v1:bluemix:public:internet-svcs:global:a/1e1f75646aef447814a6d907cc83fb3c:a1b2c3d4::",
+				})
+				infra.Status.InfrastructureName = "ocp-cluster-id"
+				return infra
+			}(),
+			expected: `[global]
+version = 1.1.0
+
+[kubernetes]
+config-file = ""
+
+[provider]
+cluster-default-provider = g2
+g2Credentials            = /etc/vpc/ibmcloud_api_key
+g2VpcName                = ocp-cluster-id-vpc
+accountID                = 1e1f75646aef447814a6d907cc83fb3c
+clusterID                = ocp-cluster-id
+g2ResourceGroupName      = ocp-cluster-rg
+region                   = us-south
+`,
+		}, {
+			name:   "Account and cluster ID from the infrastructure take precedence",
+			source: source,
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.IBMCloudPlatformType, &configv1.IBMCloudPlatformStatus{
+					DNSInstanceCRN: "crn:v1:bluemix:public:dns-svcs:global:a/9f8e7d6c5b4a39281706f5e4d3c2b1a0:e5f6a7b8::",
+				})
+				infra.Status.InfrastructureName = "other-cluster-id"
+				return infra
+			}(),
+			expected: `[global]
+version = 1.1.0
+
+[kubernetes]
+config-file = ""
+
+[provider]
+accountID                = 9f8e7d6c5b4a39281706f5e4d3c2b1a0
+clusterID                = other-cluster-id
+cluster-default-provider = g2
+region                   = us-south
+g2Credentials            = /etc/vpc/ibmcloud_api_key
+g2ResourceGroupName      = ocp-cluster-rg
+`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
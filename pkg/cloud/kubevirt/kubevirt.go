@@ -0,0 +1,142 @@
+package kubevirt
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+)
+
+const (
+	providerName = "kubevirt"
+
+	// infraKubeconfigSecretName is the Secret in the managed namespace holding the kubeconfig
+	// of the infra cluster, the one running the KubeVirt virtual machines, under the "kubeconfig" key.
+	infraKubeconfigSecretName = "kubevirt-infra-kubeconfig"
+	// infraKubeconfigDir is where the infra cluster kubeconfig is mounted in the cloud-controller-manager container.
+	infraKubeconfigDir = "/etc/infra-kubeconfig"
+)
+
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                   providerName,
+		PlatformType:           configv1.KubevirtPlatformType,
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+	})
+}
+
+type imagesReference struct {
+	CloudControllerManager string `valid:"required"`
+}
+
+var templateValuesValidationMap = map[string]interface{}{
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
+}
+
+type kubevirtAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *kubevirtAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
+	values := common.TemplateValues{
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
+	}
+	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getDeploymentOverlay returns the KubeVirt parts of the base cloud-controller-manager Deployment. The cloud
+// config points to the infra cluster kubeconfig, mounted from the infraKubeconfigSecretName Secret.
+func getDeploymentOverlay() common.DeploymentOverlay {
+	cloudConfigVolume, cloudConfigMount := common.CloudConfigVolume("cloud-config", "/etc/cloud")
+	kubeconfigVolume, kubeconfigMount := common.SecretVolume("infra-kubeconfig", infraKubeconfigSecretName, "kubeconfig", infraKubeconfigDir)
+	return common.DeploymentOverlay{
+		Name:         "kubevirt-cloud-controller-manager",
+		Exec:         "/bin/kubevirt-cloud-controller-manager",
+		Args:         []string{"--cloud-provider=kubevirt", "--cloud-config=/etc/cloud/cloud.conf"},
+		VolumeMounts: []corev1.VolumeMount{cloudConfigMount, kubeconfigMount},
+		Volumes:      []corev1.Volume{cloudConfigVolume, kubeconfigVolume},
+	}
+}
+
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	images := &imagesReference{
+		CloudControllerManager: config.ImagesReference.CloudControllerManagerKubevirt,
+	}
+	_, err := govalidator.ValidateStruct(images)
+	if err != nil {
+		return nil, fmt.Errorf("%s: missed images in config: %v", providerName, err)
+	}
+	assets := &kubevirtAssets{
+		operatorConfig: config,
+	}
+	templateValues, err := getTemplateValues(images, config)
+	if err != nil {
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
+	}
+
+	deployment, err := common.RenderBaseDeployment(getDeploymentOverlay(), templateValues)
+	if err != nil {
+		return nil, err
+	}
+	assets.renderedResources = []client.Object{deployment}
+	return assets, nil
+}
+
+// CloudConfigTransformer implements the cloudConfigTransformer. It points the user-provided configuration
+// to the infra cluster kubeconfig mounted from the infraKubeconfigSecretName Secret, and enables the
+// load balancer and instances controllers unless the configuration says otherwise.
+// The KubeVirt platform status does not carry the infra cluster namespace, so it is taken from the
+// "namespace" field of the configuration, or from the current context of the infra cluster kubeconfig
+// by the cloud-controller-manager itself when omitted.
+// It returns an error if the platform is not KubevirtPlatformType or if the configuration can not be parsed.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.KubevirtPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.KubevirtPlatformType)
+	}
+
+	cfg := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(source), &cfg); err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	kubeconfig := path.Join(infraKubeconfigDir, "kubeconfig")
+	if value, ok := cfg["kubeconfig"]; ok && value != kubeconfig {
+		return "", fmt.Errorf("'kubeconfig' is set to a non-default value, the infra cluster kubeconfig must be provided in the %s secret", infraKubeconfigSecretName)
+	}
+	cfg["kubeconfig"] = kubeconfig
+
+	for _, section := range []string{"loadBalancer", "instancesV2"} {
+		if _, ok := cfg[section]; !ok {
+			cfg[section] = map[string]interface{}{"enabled": true}
+		}
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to modify the provided configuration: %w", err)
+	}
+	return string(out), nil
+}
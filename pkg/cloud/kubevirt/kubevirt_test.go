@@ -0,0 +1,135 @@
+package kubevirt
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		config     config.OperatorConfig
+		initErrMsg string
+	}{
+		{
+			name:       "Empty config",
+			config:     config.OperatorConfig{},
+			initErrMsg: "kubevirt: missed images in config: CloudControllerManager: non zero value required",
+		}, {
+			name: "No infra name",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerKubevirt: "CloudControllerManagerKubevirt",
+				},
+				PlatformStatus: &configv1.PlatformStatus{Type: configv1.KubevirtPlatformType},
+			},
+			initErrMsg: "can not construct template values for kubevirt assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerKubevirt: "CloudControllerManagerKubevirt",
+				},
+				PlatformStatus:     &configv1.PlatformStatus{Type: configv1.KubevirtPlatformType},
+				InfrastructureName: "infra",
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(tc.config)
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			resources := assets.GetRenderedResources()
+			assert.Len(t, resources, 1)
+		})
+	}
+}
+
+func makeInfrastructureResource(platform configv1.PlatformType) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{Type: platform},
+		},
+	}
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.AWSPlatformType),
+			errMsg: "invalid platform, expected to be KubeVirt",
+		}, {
+			name:  "Empty config",
+			infra: makeInfrastructureResource(configv1.KubevirtPlatformType),
+			expected: `instancesV2:
+  enabled: true
+kubeconfig: /etc/infra-kubeconfig/kubeconfig
+loadBalancer:
+  enabled: true
+`,
+		}, {
+			name: "Config with namespace and disabled load balancer",
+			source: `namespace: guest-cluster
+loadBalancer:
+  enabled: false
+infraLabels:
+  cluster: foo
+`,
+			infra: makeInfrastructureResource(configv1.KubevirtPlatformType),
+			expected: `infraLabels:
+  cluster: foo
+instancesV2:
+  enabled: true
+kubeconfig: /etc/infra-kubeconfig/kubeconfig
+loadBalancer:
+  enabled: false
+namespace: guest-cluster
+`,
+		}, {
+			name:   "Config with unsupported kubeconfig override",
+			source: "kubeconfig: /etc/foo",
+			infra:  makeInfrastructureResource(configv1.KubevirtPlatformType),
+			errMsg: "'kubeconfig' is set to a non-default value, the infra cluster kubeconfig must be provided in the kubevirt-infra-kubeconfig secret",
+		}, {
+			name:   "Invalid config",
+			source: "foo",
+			infra:  makeInfrastructureResource(configv1.KubevirtPlatformType),
+			errMsg: "failed to read the cloud.conf: error unmarshaling JSON: while decoding JSON: json: cannot unmarshal string into Go value of type map[string]interface {}",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package linode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+)
+
+const (
+	providerName = "linode"
+
+	// tokenSecretName is the Secret in the managed namespace holding the Linode API token
+	// under the "apiToken" key. It is expected to be provided at install time.
+	tokenSecretName = "linode-credentials"
+)
+
+var (
+	// platformNames are the External platform names clusters installed on Linode are reported with.
+	// Linode is now part of Akamai Connected Cloud.
+	platformNames = []string{providerName, "akamai"}
+)
+
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         providerName,
+		PlatformType: configv1.ExternalPlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool {
+			return IsLinode(externalPlatformName)
+		},
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+	})
+}
+
+type imagesReference struct {
+	CloudControllerManager string `valid:"required"`
+}
+
+var templateValuesValidationMap = map[string]interface{}{
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
+}
+
+type linodeAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *linodeAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+// IsLinode returns true if the External platform name reported in the infrastructure refers to Linode.
+func IsLinode(externalPlatformName string) bool {
+	for _, name := range platformNames {
+		if strings.EqualFold(externalPlatformName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
+	values := common.TemplateValues{
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
+	}
+	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getDeploymentOverlay returns the Linode parts of the base cloud-controller-manager Deployment. The cloud
+// controller manager is configured through env variables, which are read from the synced cloud config.
+func getDeploymentOverlay() common.DeploymentOverlay {
+	volume, mount := common.CloudConfigVolume("linode-config", "/etc/linode")
+	return common.DeploymentOverlay{
+		Name:         "linode-cloud-controller-manager",
+		Exec:         "/usr/bin/env $(grep -E '^[A-Z_]+=' /etc/linode/cloud.conf) /linode-cloud-controller-manager",
+		Args:         []string{"--cloud-provider=linode", "--enable-route-controller=false"},
+		Env:          []corev1.EnvVar{common.SecretEnvVar("LINODE_API_TOKEN", tokenSecretName, "apiToken", false)},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Volumes:      []corev1.Volume{volume},
+	}
+}
+
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	images := &imagesReference{
+		CloudControllerManager: config.ImagesReference.CloudControllerManagerLinode,
+	}
+	_, err := govalidator.ValidateStruct(images)
+	if err != nil {
+		return nil, fmt.Errorf("%s: missed images in config: %v", providerName, err)
+	}
+	assets := &linodeAssets{
+		operatorConfig: config,
+	}
+	templateValues, err := getTemplateValues(images, config)
+	if err != nil {
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
+	}
+
+	deployment, err := common.RenderBaseDeployment(getDeploymentOverlay(), templateValues)
+	if err != nil {
+		return nil, err
+	}
+	assets.renderedResources = []client.Object{deployment}
+	return assets, nil
+}
+
+// cloudConfig is the user-provided configuration of the Linode cloud provider,
+// referenced by the infrastructure cloudConfig field.
+type cloudConfig struct {
+	// Region is the Linode region the cluster runs in, e.g. us-east.
+	Region string `json:"region"`
+	// APIURL overrides the Linode API endpoint.
+	APIURL string `json:"apiURL,omitempty"`
+	// RequestTimeoutSeconds is the timeout of requests to the Linode API.
+	RequestTimeoutSeconds *int `json:"requestTimeoutSeconds,omitempty"`
+}
+
+// CloudConfigTransformer implements the cloudConfigTransformer. The Linode cloud-controller-manager is
+// configured through environment variables, so it translates the user-provided YAML configuration
+// into an environment file which is loaded by the cloud-controller-manager container command.
+// It returns an error if the platform is not ExternalPlatformType or if the configuration is not valid.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.ExternalPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.ExternalPlatformType)
+	}
+
+	cfg := cloudConfig{}
+	if err := yaml.UnmarshalStrict([]byte(source), &cfg); err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+	if cfg.Region == "" {
+		return "", fmt.Errorf("'region' is required in the cloud.conf")
+	}
+
+	env := map[string]string{
+		"LINODE_REGION": cfg.Region,
+	}
+	if cfg.APIURL != "" {
+		env["LINODE_URL"] = cfg.APIURL
+	}
+	if cfg.RequestTimeoutSeconds != nil {
+		if *cfg.RequestTimeoutSeconds <= 0 {
+			return "", fmt.Errorf("'requestTimeoutSeconds' must be positive, got %d", *cfg.RequestTimeoutSeconds)
+		}
+		env["LINODE_REQUEST_TIMEOUT_SECONDS"] = strconv.Itoa(*cfg.RequestTimeoutSeconds)
+	}
+
+	return common.RenderEnvFile(env)
+}
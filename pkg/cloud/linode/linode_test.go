@@ -0,0 +1,137 @@
+package linode
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		config     config.OperatorConfig
+		initErrMsg string
+	}{
+		{
+			name:       "Empty config",
+			config:     config.OperatorConfig{},
+			initErrMsg: "linode: missed images in config: CloudControllerManager: non zero value required",
+		}, {
+			name: "No infra name",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerLinode: "CloudControllerManagerLinode",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "linode",
+			},
+			initErrMsg: "can not construct template values for linode assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerLinode: "CloudControllerManagerLinode",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "linode",
+				InfrastructureName:   "infra",
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(tc.config)
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			resources := assets.GetRenderedResources()
+			assert.Len(t, resources, 1)
+		})
+	}
+}
+
+func TestIsLinode(t *testing.T) {
+	assert.True(t, IsLinode("linode"))
+	assert.True(t, IsLinode("Akamai"))
+	assert.False(t, IsLinode(""))
+	assert.False(t, IsLinode("hetzner"))
+}
+
+func makeInfrastructureResource(platform configv1.PlatformType) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{Type: platform},
+		},
+	}
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.AWSPlatformType),
+			errMsg: "invalid platform, expected to be External",
+		}, {
+			name:   "Empty config",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "'region' is required in the cloud.conf",
+		}, {
+			name:     "Region only",
+			source:   "region: us-east",
+			infra:    makeInfrastructureResource(configv1.ExternalPlatformType),
+			expected: "LINODE_REGION=us-east\n",
+		}, {
+			name: "Full config",
+			source: `region: eu-west
+apiURL: https://api.linode.com/v4
+requestTimeoutSeconds: 30
+`,
+			infra: makeInfrastructureResource(configv1.ExternalPlatformType),
+			expected: `LINODE_REGION=eu-west
+LINODE_REQUEST_TIMEOUT_SECONDS=30
+LINODE_URL=https://api.linode.com/v4
+`,
+		}, {
+			name:   "Invalid request timeout",
+			source: "region: us-east\nrequestTimeoutSeconds: 0",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "'requestTimeoutSeconds' must be positive, got 0",
+		}, {
+			name:   "Unknown field",
+			source: "region: us-east\nzone: foo",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "failed to read the cloud.conf: error unmarshaling JSON: while decoding JSON: json: unknown field \"zone\"",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +33,17 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                     providerName,
+		PlatformType:             configv1.NutanixPlatformType,
+		NewAssets:                NewProviderAssets,
+		CloudConfigTransformer:   CloudConfigTransformer,
+		CloudConfigKey:           "nutanix_config.json",
+		CloudConfigSensitiveKeys: []string{"username", "password"},
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
@@ -0,0 +1,156 @@
+package nutanix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// globalCredsSecretNamespace is the namespace the credentials secret is requested in,
+	// see manifests/0000_26_cloud-controller-manager-operator_16_credentialsrequest-nutanix.yaml
+	globalCredsSecretNamespace = "openshift-cloud-controller-manager"
+
+	topologyDiscoveryPrism      = "Prism"
+	topologyDiscoveryCategories = "Categories"
+)
+
+// cloudConfig mirrors the configuration of the Nutanix cloud-controller-manager.
+// https://github.com/nutanix-cloud-native/cloud-provider-nutanix/blob/main/pkg/provider/config/config.go
+type cloudConfig struct {
+	PrismCentral         prismEndpoint     `json:"prismCentral"`
+	TopologyDiscovery    topologyDiscovery `json:"topologyDiscovery"`
+	EnableCustomLabeling *bool             `json:"enableCustomLabeling,omitempty"`
+	IgnoredNodeIPs       []string          `json:"ignoredNodeIPs,omitempty"`
+}
+
+type prismEndpoint struct {
+	Address               string               `json:"address"`
+	Port                  int32                `json:"port"`
+	Insecure              bool                 `json:"insecure,omitempty"`
+	AdditionalTrustBundle json.RawMessage      `json:"additionalTrustBundle,omitempty"`
+	CredentialRef         *credentialReference `json:"credentialRef,omitempty"`
+}
+
+type credentialReference struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type topologyDiscovery struct {
+	Type               string              `json:"type"`
+	TopologyCategories *topologyCategories `json:"topologyCategories,omitempty"`
+}
+
+type topologyCategories struct {
+	ZoneCategory   string `json:"zoneCategory"`
+	RegionCategory string `json:"regionCategory"`
+}
+
+// CloudConfigTransformer takes the user-provided configuration and completes it with the values
+// known from the Infrastructure resource, so the cloud-controller-manager configuration does not
+// have to be kept in sync with the cluster by hand.
+// Returns an error if the platform is not NutanixPlatformType or if the resulting configuration is not valid.
+// Currently, CloudConfigTransformer is responsible to populate:
+//   - the Prism Central endpoint from the Infrastructure spec,
+//   - the reference to the credentials secret requested by the operator,
+//   - the topology discovery, which relies on categories only if the user configured them,
+//   - the node IPs to ignore, which are the API and Ingress virtual IPs from the Infrastructure status.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.NutanixPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.NutanixPlatformType)
+	}
+
+	cfg := &cloudConfig{}
+	if strings.TrimSpace(source) != "" {
+		decoder := json.NewDecoder(bytes.NewBufferString(source))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(cfg); err != nil {
+			return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+		}
+	}
+
+	if infra.Spec.PlatformSpec.Nutanix != nil && infra.Spec.PlatformSpec.Nutanix.PrismCentral.Address != "" {
+		cfg.PrismCentral.Address = infra.Spec.PlatformSpec.Nutanix.PrismCentral.Address
+		cfg.PrismCentral.Port = infra.Spec.PlatformSpec.Nutanix.PrismCentral.Port
+	}
+	if cfg.PrismCentral.CredentialRef == nil {
+		cfg.PrismCentral.CredentialRef = &credentialReference{
+			Kind:      "Secret",
+			Name:      globalCredsSecretName,
+			Namespace: globalCredsSecretNamespace,
+		}
+	}
+	if cfg.EnableCustomLabeling == nil {
+		enabled := true
+		cfg.EnableCustomLabeling = &enabled
+	}
+	setTopologyDiscovery(cfg)
+	setIgnoredNodeIPs(cfg, infra.Status.PlatformStatus.Nutanix)
+
+	if err := validateConfig(cfg); err != nil {
+		return "", err
+	}
+
+	out, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the cloud.conf: %w", err)
+	}
+	return string(out), nil
+}
+
+// setTopologyDiscovery discovers topology from the Prism Elements, unless the user configured
+// the categories the zones and regions are tagged with.
+func setTopologyDiscovery(cfg *cloudConfig) {
+	if cfg.TopologyDiscovery.Type != "" {
+		return
+	}
+	cfg.TopologyDiscovery.Type = topologyDiscoveryPrism
+	if cfg.TopologyDiscovery.TopologyCategories != nil {
+		cfg.TopologyDiscovery.Type = topologyDiscoveryCategories
+	}
+}
+
+// setIgnoredNodeIPs prevents the virtual IPs held by the control plane and ingress nodes from being
+// reported as node addresses.
+func setIgnoredNodeIPs(cfg *cloudConfig, status *configv1.NutanixPlatformStatus) {
+	if status == nil {
+		return
+	}
+	ignored := sets.New(cfg.IgnoredNodeIPs...)
+	for _, ip := range append(append([]string{}, status.APIServerInternalIPs...), status.IngressIPs...) {
+		if ip != "" && !ignored.Has(ip) {
+			ignored.Insert(ip)
+			cfg.IgnoredNodeIPs = append(cfg.IgnoredNodeIPs, ip)
+		}
+	}
+}
+
+func validateConfig(cfg *cloudConfig) error {
+	if cfg.PrismCentral.Address == "" {
+		return fmt.Errorf("prism central address is not set in the infrastructure nor in the cloud.conf")
+	}
+	if cfg.PrismCentral.Port <= 0 {
+		return fmt.Errorf("invalid prism central port %d", cfg.PrismCentral.Port)
+	}
+
+	switch cfg.TopologyDiscovery.Type {
+	case topologyDiscoveryPrism:
+	case topologyDiscoveryCategories:
+		categories := cfg.TopologyDiscovery.TopologyCategories
+		if categories == nil || categories.ZoneCategory == "" || categories.RegionCategory == "" {
+			return fmt.Errorf("both zone and region categories are required for the %s topology discovery", topologyDiscoveryCategories)
+		}
+	default:
+		return fmt.Errorf("unsupported topology discovery type %q, expected %s or %s",
+			cfg.TopologyDiscovery.Type, topologyDiscoveryPrism, topologyDiscoveryCategories)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package nutanix
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeInfrastructureResource(platform configv1.PlatformType) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Spec: configv1.InfrastructureSpec{
+			PlatformSpec: configv1.PlatformSpec{
+				Type: platform,
+				Nutanix: &configv1.NutanixPlatformSpec{
+					PrismCentral: configv1.NutanixPrismEndpoint{
+						Address: "prism-central.example.com",
+						Port:    9440,
+					},
+				},
+			},
+		},
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{
+				Type: platform,
+				Nutanix: &configv1.NutanixPlatformStatus{
+					APIServerInternalIPs: []string{"10.0.0.1"},
+					IngressIPs:           []string{"10.0.0.2"},
+				},
+			},
+		},
+	}
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.AWSPlatformType),
+			errMsg: "invalid platform, expected to be Nutanix",
+		}, {
+			name:  "Empty source config",
+			infra: makeInfrastructureResource(configv1.NutanixPlatformType),
+			expected: `{
+  "prismCentral": {
+    "address": "prism-central.example.com",
+    "port": 9440,
+    "credentialRef": {
+      "kind": "Secret",
+      "name": "nutanix-credentials",
+      "namespace": "openshift-cloud-controller-manager"
+    }
+  },
+  "topologyDiscovery": {
+    "type": "Prism"
+  },
+  "enableCustomLabeling": true,
+  "ignoredNodeIPs": [
+    "10.0.0.1",
+    "10.0.0.2"
+  ]
+}`,
+		}, {
+			name: "Installer generated config",
+			source: `{
+  "prismCentral": {
+    "address": "outdated.example.com",
+    "port": 9440,
+    "credentialRef": {"kind": "Secret", "name": "custom-credentials", "namespace": "openshift-cloud-controller-manager"}
+  },
+  "topologyDiscovery": {"type": "Prism", "topologyCategories": null},
+  "enableCustomLabeling": false,
+  "ignoredNodeIPs": ["10.0.0.1", "10.0.0.3"]
+}`,
+			infra: makeInfrastructureResource(configv1.NutanixPlatformType),
+			expected: `{
+  "prismCentral": {
+    "address": "prism-central.example.com",
+    "port": 9440,
+    "credentialRef": {
+      "kind": "Secret",
+      "name": "custom-credentials",
+      "namespace": "openshift-cloud-controller-manager"
+    }
+  },
+  "topologyDiscovery": {
+    "type": "Prism"
+  },
+  "enableCustomLabeling": false,
+  "ignoredNodeIPs": [
+    "10.0.0.1",
+    "10.0.0.3",
+    "10.0.0.2"
+  ]
+}`,
+		}, {
+			name:   "Categories topology discovery",
+			source: `{"topologyDiscovery": {"topologyCategories": {"zoneCategory": "ocp-zone", "regionCategory": "ocp-region"}}}`,
+			infra:  makeInfrastructureResource(configv1.NutanixPlatformType),
+			expected: `{
+  "prismCentral": {
+    "address": "prism-central.example.com",
+    "port": 9440,
+    "credentialRef": {
+      "kind": "Secret",
+      "name": "nutanix-credentials",
+      "namespace": "openshift-cloud-controller-manager"
+    }
+  },
+  "topologyDiscovery": {
+    "type": "Categories",
+    "topologyCategories": {
+      "zoneCategory": "ocp-zone",
+      "regionCategory": "ocp-region"
+    }
+  },
+  "enableCustomLabeling": true,
+  "ignoredNodeIPs": [
+    "10.0.0.1",
+    "10.0.0.2"
+  ]
+}`,
+		}, {
+			name:   "Incomplete categories",
+			source: `{"topologyDiscovery": {"type": "Categories", "topologyCategories": {"zoneCategory": "ocp-zone"}}}`,
+			infra:  makeInfrastructureResource(configv1.NutanixPlatformType),
+			errMsg: "both zone and region categories are required for the Categories topology discovery",
+		}, {
+			name:   "Unsupported topology discovery",
+			source: `{"topologyDiscovery": {"type": "Magic"}}`,
+			infra:  makeInfrastructureResource(configv1.NutanixPlatformType),
+			errMsg: "unsupported topology discovery type \"Magic\", expected Prism or Categories",
+		}, {
+			name: "No prism central",
+			infra: func() *configv1.Infrastructure {
+				infra := makeInfrastructureResource(configv1.NutanixPlatformType)
+				infra.Spec.PlatformSpec.Nutanix = nil
+				return infra
+			}(),
+			errMsg: "prism central address is not set in the infrastructure nor in the cloud.conf",
+		}, {
+			name:   "Unknown field",
+			source: `{"prismCentral": {"address": "foo", "port": 9440}, "foo": "bar"}`,
+			infra:  makeInfrastructureResource(configv1.NutanixPlatformType),
+			errMsg: "failed to read the cloud.conf: json: unknown field \"foo\"",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
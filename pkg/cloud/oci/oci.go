@@ -0,0 +1,126 @@
+package oci
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+const (
+	providerName = "oci"
+
+	// cloudConfigSecretName is the Secret holding the OCI cloud-provider.yaml config in the managed namespace.
+	// It is expected to be provided at install time, as it contains the compartment, VCN and load balancer
+	// subnets of the cluster, and optionally API credentials when instance principals are not used.
+	cloudConfigSecretName = "oci-cloud-controller-manager"
+)
+
+var (
+	//go:embed assets/*
+	assetsFs  embed.FS
+	templates = []common.TemplateSource{
+		{ReferenceObject: &rbacv1.ClusterRole{}, EmbedFsPath: "assets/oci-cloud-controller-manager-clusterrole.yaml"},
+		{ReferenceObject: &rbacv1.ClusterRoleBinding{}, EmbedFsPath: "assets/oci-cloud-controller-manager-clusterrolebinding.yaml"},
+	}
+)
+
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         providerName,
+		PlatformType: configv1.ExternalPlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool {
+			return IsOCI(externalPlatformName)
+		},
+		NewAssets: NewProviderAssets,
+	})
+}
+
+type imagesReference struct {
+	CloudControllerManager string `valid:"required"`
+}
+
+var templateValuesValidationMap = map[string]interface{}{
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
+}
+
+type ociAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *ociAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+// IsOCI returns true if the External platform name reported in the infrastructure refers to Oracle Cloud Infrastructure.
+func IsOCI(externalPlatformName string) bool {
+	return strings.EqualFold(externalPlatformName, providerName)
+}
+
+func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
+	values := common.TemplateValues{
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
+	}
+	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getDeploymentOverlay returns the OCI parts of the base cloud-controller-manager Deployment.
+func getDeploymentOverlay() common.DeploymentOverlay {
+	volume, mount := common.SecretVolume("oci-config", cloudConfigSecretName, "cloud-provider.yaml", "/etc/oci")
+	return common.DeploymentOverlay{
+		Name:         "oci-cloud-controller-manager",
+		Exec:         "/usr/local/bin/oci-cloud-controller-manager",
+		Args:         []string{"--cloud-provider=oci", "--cloud-config=/etc/oci/cloud-provider.yaml"},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Volumes:      []corev1.Volume{volume},
+	}
+}
+
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	images := &imagesReference{
+		CloudControllerManager: config.ImagesReference.CloudControllerManagerOCI,
+	}
+	_, err := govalidator.ValidateStruct(images)
+	if err != nil {
+		return nil, fmt.Errorf("%s: missed images in config: %v", providerName, err)
+	}
+	assets := &ociAssets{
+		operatorConfig: config,
+	}
+	objTemplates, err := common.ReadTemplates(assetsFs, templates)
+	if err != nil {
+		return nil, err
+	}
+	templateValues, err := getTemplateValues(images, config)
+	if err != nil {
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
+	}
+
+	deployment, err := common.RenderBaseDeployment(getDeploymentOverlay(), templateValues)
+	if err != nil {
+		return nil, err
+	}
+	rbacResources, err := common.RenderTemplates(objTemplates, templateValues)
+	if err != nil {
+		return nil, err
+	}
+	assets.renderedResources = append([]client.Object{deployment}, rbacResources...)
+	return assets, nil
+}
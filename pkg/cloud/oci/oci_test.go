@@ -0,0 +1,70 @@
+package oci
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		config     config.OperatorConfig
+		initErrMsg string
+	}{
+		{
+			name:       "Empty config",
+			config:     config.OperatorConfig{},
+			initErrMsg: "oci: missed images in config: CloudControllerManager: non zero value required",
+		}, {
+			name: "No infra name",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerOCI: "CloudControllerManagerOCI",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "oci",
+			},
+			initErrMsg: "can not construct template values for oci assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerOCI: "CloudControllerManagerOCI",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "oci",
+				InfrastructureName:   "infra",
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(tc.config)
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			resources := assets.GetRenderedResources()
+			assert.Len(t, resources, 3)
+		})
+	}
+}
+
+func TestIsOCI(t *testing.T) {
+	assert.True(t, IsOCI("oci"))
+	assert.True(t, IsOCI("OCI"))
+	assert.False(t, IsOCI(""))
+	assert.False(t, IsOCI("Unknown"))
+}
@@ -28,6 +28,17 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                     providerName,
+		PlatformType:             configv1.OpenStackPlatformType,
+		NewAssets:                NewProviderAssets,
+		CloudConfigTransformer:   CloudConfigTransformer,
+		CloudConfigLinter:        LintCloudConfig,
+		CloudConfigSensitiveKeys: []string{"password", "application-credential-secret"},
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
@@ -182,7 +193,7 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 	for _, o := range []struct{ k, v string }{
 		{"use-clouds", "true"},
 		{"clouds-file", "/etc/openstack/secret/clouds.yaml"},
-		{"cloud", "openstack"},
+		{"cloud", cloudName},
 	} {
 		_, err = global.NewKey(o.k, o.v)
 		if err != nil {
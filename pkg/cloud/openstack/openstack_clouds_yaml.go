@@ -0,0 +1,105 @@
+package openstack
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	ini "gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// CloudsYAMLSecretName is the secret minted by the cloud-credential-operator from the openstack-credentials
+	// root secret, which is mounted into the openstack-cloud-controller-manager pods.
+	CloudsYAMLSecretName = "openstack-cloud-credentials"
+	// CloudsYAMLSecretKey is the key of the clouds.yaml in the CloudsYAMLSecretName secret.
+	CloudsYAMLSecretKey = "clouds.yaml"
+	// cacertSecretKey is the key of the CA bundle the clouds.yaml cacert refers to in the CloudsYAMLSecretName secret.
+	cacertSecretKey = "cacert"
+
+	// cloudName is the name of the cloud in the clouds.yaml used by the openstack-cloud-controller-manager.
+	cloudName = "openstack"
+
+	// trustedCAFile is the path of the trusted CA bundle mounted into the openstack-cloud-controller-manager
+	// pods. It includes the CA bundle from the cloud-provider-config, which is the one the clouds.yaml
+	// cacert refers to on the installer host, and the one found by CloudsYAMLCABundle.
+	trustedCAFile = "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem"
+)
+
+// cloudsYAML is the subset of the clouds.yaml format used to synthesize the cloud.conf.
+// https://docs.openstack.org/python-openstackclient/latest/configuration/index.html#clouds-yaml
+type cloudsYAML struct {
+	Clouds map[string]struct {
+		Auth struct {
+			AuthURL string `json:"auth_url"`
+		} `json:"auth"`
+		RegionName string `json:"region_name"`
+		CACert     string `json:"cacert"`
+	} `json:"clouds"`
+}
+
+// SetCloudsYAMLOptions takes the cloud.conf produced by the CloudConfigTransformer and sets the auth URL,
+// region and CA file in its [Global] section from the given clouds.yaml, so the cloud.conf does not need
+// to be pre-baked and follows the credentials on rotation. Region and CA file from the cloud.conf are kept
+// when the clouds.yaml does not set them.
+// It returns an error if the clouds.yaml can not be parsed or has no auth URL for the cloud used by the CCM.
+func SetCloudsYAMLOptions(source string, data []byte) (string, error) {
+	clouds := cloudsYAML{}
+	if err := yaml.Unmarshal(data, &clouds); err != nil {
+		return "", fmt.Errorf("failed to read the clouds.yaml: %w", err)
+	}
+
+	cloud, ok := clouds.Clouds[cloudName]
+	if !ok {
+		return "", fmt.Errorf("cloud %q not found in the clouds.yaml", cloudName)
+	}
+	if cloud.Auth.AuthURL == "" {
+		return "", fmt.Errorf("auth_url is not set for cloud %q in the clouds.yaml", cloudName)
+	}
+
+	cfg, err := ini.Load([]byte(source))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	global := cfg.Section("Global")
+	global.Key("auth-url").SetValue(cloud.Auth.AuthURL)
+	if cloud.RegionName != "" {
+		global.Key("region").SetValue(cloud.RegionName)
+	}
+	if cloud.CACert != "" {
+		global.Key("ca-file").SetValue(trustedCAFile)
+	}
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("failed to modify the provided configuration: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// CloudsYAMLCABundle returns the custom CA bundle of the given clouds.yaml credentials secret data, so it can be
+// merged into the trusted CA bundle of the openstack-cloud-controller-manager. The bundle is either set in the
+// "cacert" key of the secret, or embedded as PEM in the cacert option of the cloud used by the CCM. A cacert
+// option holding a path can not be resolved and is ignored. It returns nil if there is no custom CA bundle.
+func CloudsYAMLCABundle(data map[string][]byte) ([]byte, error) {
+	if cacert := bytes.TrimSpace(data[cacertSecretKey]); len(cacert) > 0 {
+		return cacert, nil
+	}
+
+	cloudsData, ok := data[CloudsYAMLSecretKey]
+	if !ok {
+		return nil, nil
+	}
+	clouds := cloudsYAML{}
+	if err := yaml.Unmarshal(cloudsData, &clouds); err != nil {
+		return nil, fmt.Errorf("failed to read the clouds.yaml: %w", err)
+	}
+	cacert := []byte(strings.TrimSpace(clouds.Clouds[cloudName].CACert))
+	if !bytes.HasPrefix(cacert, []byte("-----BEGIN")) {
+		return nil, nil
+	}
+	return cacert, nil
+}
@@ -0,0 +1,151 @@
+package openstack
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ini "gopkg.in/ini.v1"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+)
+
+// octaviaOptionValidators lists the Octavia options which users are allowed to tune, along with
+// the validation of their values. Options are set in the [LoadBalancer] section of the cloud.conf.
+// https://github.com/kubernetes/cloud-provider-openstack/blob/master/docs/openstack-cloud-controller-manager/using-openstack-cloud-controller-manager.md#load-balancer
+var octaviaOptionValidators = map[string]func(string) error{
+	"lb-provider":                  validateNotEmpty,
+	"lb-method":                    validateNotEmpty,
+	"flavor-id":                    validateNotEmpty,
+	"availability-zone":            validateNotEmpty,
+	"create-monitor":               validateBool,
+	"monitor-delay":                validateDuration,
+	"monitor-timeout":              validateDuration,
+	"monitor-max-retries":          validateUint,
+	"monitor-max-retries-down":     validateUint,
+	"enable-ingress-hostname":      validateBool,
+	"ingress-hostname-suffix":      validateNotEmpty,
+	"max-shared-lb":                validateUint,
+	"internal-lb":                  validateBool,
+	"cascade-delete":               validateBool,
+	"provider-requires-serial-api": validateBool,
+}
+
+// MergeOctaviaOptions takes the cloud.conf produced by the CloudConfigTransformer and merges the
+// user-provided Octavia options into its [LoadBalancer] section. User-provided options take
+// precedence over the ones in the cloud.conf. It returns an error if any of the options is
+// unsupported or has an invalid value.
+func MergeOctaviaOptions(source string, options map[string]string) (string, error) {
+	if len(options) == 0 {
+		return source, nil
+	}
+
+	cfg, err := ini.Load([]byte(source))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+
+	loadBalancer := cfg.Section("LoadBalancer")
+
+	// Sort the keys so the resulting config is stable across syncs.
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		validate, ok := octaviaOptionValidators[key]
+		if !ok {
+			return "", fmt.Errorf("unsupported Octavia option %q", key)
+		}
+		if err := validate(options[key]); err != nil {
+			return "", fmt.Errorf("invalid value for Octavia option %q: %w", key, err)
+		}
+		loadBalancer.Key(key).SetValue(options[key])
+	}
+
+	var buf bytes.Buffer
+	if _, err := cfg.WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("failed to modify the provided configuration: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func validateNotEmpty(value string) error {
+	if value == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	return nil
+}
+
+func validateBool(value string) error {
+	_, err := strconv.ParseBool(value)
+	return err
+}
+
+func validateDuration(value string) error {
+	_, err := time.ParseDuration(value)
+	return err
+}
+
+func validateUint(value string) error {
+	_, err := strconv.ParseUint(value, 10, 32)
+	return err
+}
+
+// loadBalancerOptions lists the options of the [LoadBalancer] section which are set by the operator or which
+// users commonly set in their cloud.conf, besides the Octavia options above.
+var loadBalancerOptions = []string{
+	"enabled",
+	"floating-network-id",
+	"floating-subnet-id",
+	"floating-subnet",
+	"floating-subnet-tags",
+	"subnet-id",
+	"member-subnet-id",
+	"network-id",
+	"manage-security-groups",
+	"node-selector",
+}
+
+// ovnLBMethod is the only load balancing algorithm supported by the OVN Octavia provider.
+const ovnLBMethod = "SOURCE_IP_PORT"
+
+// LintCloudConfig reports the issues of the [LoadBalancer] section of the synced cloud config: options which are
+// likely typos of a supported option, and a load balancing algorithm the OVN Octavia provider does not support.
+func LintCloudConfig(source string) []string {
+	cfg, err := ini.Load([]byte(source))
+	if err != nil {
+		return nil
+	}
+	loadBalancer, err := cfg.GetSection("LoadBalancer")
+	if err != nil {
+		return nil
+	}
+
+	known := append(slices.Sorted(maps.Keys(octaviaOptionValidators)), loadBalancerOptions...)
+
+	var findings []string
+	for _, key := range loadBalancer.KeyStrings() {
+		if slices.Contains(known, key) {
+			continue
+		}
+		if closest := common.ClosestOption(key, known); closest != "" {
+			findings = append(findings, fmt.Sprintf("option %q of the [LoadBalancer] section is unknown to the cloud provider, did you mean %q?", key, closest))
+		}
+	}
+
+	if strings.EqualFold(loadBalancer.Key("lb-provider").String(), "ovn") {
+		if method := loadBalancer.Key("lb-method").String(); method != "" && method != ovnLBMethod {
+			findings = append(findings, fmt.Sprintf("option \"lb-method\" is set to %q, which the ovn Octavia provider does not support, set it to %q", method, ovnLBMethod))
+		}
+	}
+	return findings
+}
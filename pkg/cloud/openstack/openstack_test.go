@@ -192,3 +192,286 @@ manage-security-groups = true`
 		})
 	}
 }
+
+func TestMergeOctaviaOptions(t *testing.T) {
+	source := `[Global]
+use-clouds  = true
+clouds-file = /etc/openstack/secret/clouds.yaml
+cloud       = openstack
+
+[LoadBalancer]
+max-shared-lb          = 1
+manage-security-groups = true
+`
+
+	tc := []struct {
+		name     string
+		options  map[string]string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "No options",
+			expected: source,
+		}, {
+			name: "Octavia options",
+			options: map[string]string{
+				"lb-provider":       "ovn",
+				"lb-method":         "SOURCE_IP_PORT",
+				"flavor-id":         "a1b2c3",
+				"availability-zone": "az1",
+				"create-monitor":    "true",
+				"monitor-delay":     "5s",
+				"max-shared-lb":     "2",
+			},
+			expected: `[Global]
+use-clouds  = true
+clouds-file = /etc/openstack/secret/clouds.yaml
+cloud       = openstack
+
+[LoadBalancer]
+max-shared-lb          = 2
+manage-security-groups = true
+availability-zone      = az1
+create-monitor         = true
+flavor-id              = a1b2c3
+lb-method              = SOURCE_IP_PORT
+lb-provider            = ovn
+monitor-delay          = 5s
+`,
+		}, {
+			name:    "Unsupported option",
+			options: map[string]string{"use-octavia": "true"},
+			errMsg:  `unsupported Octavia option "use-octavia"`,
+		}, {
+			name:    "Invalid boolean",
+			options: map[string]string{"create-monitor": "maybe"},
+			errMsg:  `invalid value for Octavia option "create-monitor": strconv.ParseBool: parsing "maybe": invalid syntax`,
+		}, {
+			name:    "Invalid duration",
+			options: map[string]string{"monitor-timeout": "3"},
+			errMsg:  `invalid value for Octavia option "monitor-timeout": time: missing unit in duration "3"`,
+		}, {
+			name:    "Empty provider",
+			options: map[string]string{"lb-provider": ""},
+			errMsg:  `invalid value for Octavia option "lb-provider": must not be empty`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			actual, err := MergeOctaviaOptions(source, tc.options)
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(actual).Should(Equal(tc.expected))
+		})
+	}
+}
+
+func TestLintCloudConfig(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name: "No findings",
+			source: `[Global]
+use-clouds = true
+
+[LoadBalancer]
+lb-provider            = ovn
+lb-method              = SOURCE_IP_PORT
+floating-network-id    = public
+manage-security-groups = true
+`,
+		}, {
+			name: "No LoadBalancer section",
+			source: `[Global]
+use-clouds = true
+`,
+		}, {
+			name: "Typo in an option name",
+			source: `[LoadBalancer]
+lb-provder    = amphora
+monitor-delai = 5s
+custom-option = foo
+`,
+			expected: []string{
+				`option "lb-provder" of the [LoadBalancer] section is unknown to the cloud provider, did you mean "lb-provider"?`,
+				`option "monitor-delai" of the [LoadBalancer] section is unknown to the cloud provider, did you mean "monitor-delay"?`,
+			},
+		}, {
+			name: "Unsupported OVN load balancing algorithm",
+			source: `[LoadBalancer]
+lb-provider = OVN
+lb-method   = ROUND_ROBIN
+`,
+			expected: []string{
+				`option "lb-method" is set to "ROUND_ROBIN", which the ovn Octavia provider does not support, set it to "SOURCE_IP_PORT"`,
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(LintCloudConfig(tc.source)).Should(Equal(tc.expected))
+		})
+	}
+}
+
+func TestSetCloudsYAMLOptions(t *testing.T) {
+	source := `[Global]
+use-clouds  = true
+clouds-file = /etc/openstack/secret/clouds.yaml
+cloud       = openstack
+`
+
+	tc := []struct {
+		name       string
+		source     string
+		cloudsYAML string
+		expected   string
+		errMsg     string
+	}{
+		{
+			name:   "Auth URL, region and CA",
+			source: source,
+			cloudsYAML: `clouds:
+  openstack:
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+      username: user
+      password: secret
+    region_name: regionOne
+    cacert: /home/user/ca.pem
+`,
+			expected: `[Global]
+use-clouds  = true
+clouds-file = /etc/openstack/secret/clouds.yaml
+cloud       = openstack
+auth-url    = https://keystone.example.com:5000/v3
+region      = regionOne
+ca-file     = /etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem
+`,
+		}, {
+			name: "Rotated auth URL keeps region and CA from the config",
+			source: `[Global]
+use-clouds  = true
+clouds-file = /etc/openstack/secret/clouds.yaml
+cloud       = openstack
+auth-url    = https://keystone.example.com:5000/v3
+region      = regionOne
+ca-file     = /etc/kubernetes/static-pod-resources/configmaps/cloud-config/ca-bundle.pem
+`,
+			cloudsYAML: `clouds:
+  openstack:
+    auth:
+      auth_url: https://identity.example.com/v3
+`,
+			expected: `[Global]
+use-clouds  = true
+clouds-file = /etc/openstack/secret/clouds.yaml
+cloud       = openstack
+auth-url    = https://identity.example.com/v3
+region      = regionOne
+ca-file     = /etc/kubernetes/static-pod-resources/configmaps/cloud-config/ca-bundle.pem
+`,
+		}, {
+			name:   "Missing cloud",
+			source: source,
+			cloudsYAML: `clouds:
+  devstack:
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+`,
+			errMsg: `cloud "openstack" not found in the clouds.yaml`,
+		}, {
+			name:   "Missing auth URL",
+			source: source,
+			cloudsYAML: `clouds:
+  openstack:
+    region_name: regionOne
+`,
+			errMsg: `auth_url is not set for cloud "openstack" in the clouds.yaml`,
+		}, {
+			name:       "Invalid clouds.yaml",
+			source:     source,
+			cloudsYAML: `clouds: [`,
+			errMsg:     "failed to read the clouds.yaml: error converting YAML to JSON: yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			actual, err := SetCloudsYAMLOptions(tc.source, []byte(tc.cloudsYAML))
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(actual).Should(Equal(tc.expected))
+		})
+	}
+}
+
+func TestCloudsYAMLCABundle(t *testing.T) {
+	pem := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+
+	tc := []struct {
+		name     string
+		data     map[string][]byte
+		expected []byte
+		errMsg   string
+	}{
+		{
+			name: "CA bundle in the secret",
+			data: map[string][]byte{
+				CloudsYAMLSecretKey: []byte("clouds:\n  openstack:\n    cacert: /home/user/ca.pem\n"),
+				"cacert":            []byte(pem + "\n"),
+			},
+			expected: []byte(pem),
+		}, {
+			name: "CA bundle embedded in the clouds.yaml",
+			data: map[string][]byte{
+				CloudsYAMLSecretKey: []byte("clouds:\n  openstack:\n    cacert: |\n      " + strings.ReplaceAll(pem, "\n", "\n      ") + "\n"),
+			},
+			expected: []byte(pem),
+		}, {
+			name: "CA path in the clouds.yaml",
+			data: map[string][]byte{
+				CloudsYAMLSecretKey: []byte("clouds:\n  openstack:\n    cacert: /home/user/ca.pem\n"),
+			},
+		}, {
+			name: "No CA",
+			data: map[string][]byte{
+				CloudsYAMLSecretKey: []byte("clouds:\n  openstack:\n    region_name: regionOne\n"),
+			},
+		}, {
+			name: "Invalid clouds.yaml",
+			data: map[string][]byte{
+				CloudsYAMLSecretKey: []byte("clouds: ["),
+			},
+			errMsg: "failed to read the clouds.yaml: error converting YAML to JSON: yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			actual, err := CloudsYAMLCABundle(tc.data)
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(actual).Should(Equal(tc.expected))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -22,6 +23,15 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                   providerName,
+		PlatformType:           configv1.PowerVSPlatformType,
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
@@ -40,6 +50,21 @@ func (assets *powerVSAssets) GetRenderedResources() []client.Object {
 	return assets.renderedResources
 }
 
+// GetContainerProbes returns the probe parameters of the cloud-controller-manager container.
+// The CCM has to reach the IBM Cloud APIs, often through a proxy, before reporting healthy.
+func (assets *powerVSAssets) GetContainerProbes() map[string]common.ContainerProbes {
+	return map[string]common.ContainerProbes{
+		"cloud-controller-manager": {
+			Liveness: &common.ProbeParameters{
+				InitialDelaySeconds: 300,
+				PeriodSeconds:       10,
+				TimeoutSeconds:      160,
+				FailureThreshold:    3,
+			},
+		},
+	}
+}
+
 func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
 	values := common.TemplateValues{
 		"images":            images,
@@ -0,0 +1,45 @@
+package powervs
+
+import (
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/ibm"
+)
+
+// powerServiceName is the name of the Power Cloud service in the PowerVS service endpoints.
+const powerServiceName = "Power"
+
+// CloudConfigTransformer implements the cloudConfigTransformer. It takes the user-provided configuration
+// and sets the Power VS region, zone, resource group and custom service endpoints from the infrastructure
+// into its provider section. Values from the infrastructure take precedence over the ones in the
+// user-provided configuration.
+// It returns an error if the platform is not PowerVSPlatformType or if the configuration can not be parsed.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.PowerVSPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.PowerVSPlatformType)
+	}
+
+	powerVSStatus := infra.Status.PlatformStatus.PowerVS
+	if powerVSStatus == nil {
+		return source, nil
+	}
+
+	options := map[string]string{
+		"powerVSRegion":       powerVSStatus.Region,
+		"powerVSZone":         powerVSStatus.Zone,
+		"g2ResourceGroupName": powerVSStatus.ResourceGroup,
+	}
+	for _, endpoint := range powerVSStatus.ServiceEndpoints {
+		if endpoint.Name == powerServiceName {
+			options["powerVSEndpointOverride"] = endpoint.URL
+		} else if key, ok := ibm.EndpointOverrideKey(endpoint.Name); ok {
+			options[key] = endpoint.URL
+		}
+	}
+
+	return ibm.SetProviderOptions(source, options)
+}
@@ -0,0 +1,89 @@
+package powervs
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeInfrastructureResource(platform configv1.PlatformType, status *configv1.PowerVSPlatformStatus) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{
+				Type:    platform,
+				PowerVS: status,
+			},
+		},
+	}
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+	source := `[global]
+version = 1.1.0
+
+[provider]
+cluster-default-provider = g2
+g2Credentials            = /etc/vpc/ibmcloud_api_key
+powerVSCloudInstanceID   = e449d86e-c3a0-4c07-959e-8557fdf55482
+`
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.IBMCloudPlatformType, nil),
+			errMsg: "invalid platform, expected to be PowerVS",
+		}, {
+			name:     "No platform status",
+			source:   source,
+			infra:    makeInfrastructureResource(configv1.PowerVSPlatformType, nil),
+			expected: source,
+		}, {
+			name:   "Region, zone, resource group and service endpoints",
+			source: source,
+			infra: makeInfrastructureResource(configv1.PowerVSPlatformType, &configv1.PowerVSPlatformStatus{
+				Region:        "dal",
+				Zone:          "dal10",
+				ResourceGroup: "ocp-cluster-rg",
+				ServiceEndpoints: []configv1.PowerVSServiceEndpoint{
+					{Name: "Power", URL: "https://private.dal.power-iaas.cloud.ibm.com"},
+					{Name: "IAM", URL: "https://private.iam.cloud.ibm.com"},
+					{Name: "VPC", URL: "https://us-south.private.iaas.cloud.ibm.com/v1"},
+					{Name: "COS", URL: "https://s3.direct.us-south.cloud-object-storage.appdomain.cloud"},
+				},
+			}),
+			expected: `[global]
+version = 1.1.0
+
+[provider]
+cluster-default-provider = g2
+g2Credentials            = /etc/vpc/ibmcloud_api_key
+powerVSCloudInstanceID   = e449d86e-c3a0-4c07-959e-8557fdf55482
+g2EndpointOverride       = https://us-south.private.iaas.cloud.ibm.com/v1
+g2ResourceGroupName      = ocp-cluster-rg
+iamEndpointOverride      = https://private.iam.cloud.ibm.com
+powerVSEndpointOverride  = https://private.dal.power-iaas.cloud.ibm.com
+powerVSRegion            = dal
+powerVSZone              = dal10
+`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
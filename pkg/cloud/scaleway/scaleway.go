@@ -0,0 +1,193 @@
+package scaleway
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+)
+
+const (
+	providerName = "scaleway"
+
+	// credentialsSecretName is the Secret in the managed namespace holding the Scaleway API key
+	// under the "accessKey" and "secretKey" keys, and the project the cluster runs in under "projectID".
+	// It is expected to be provided at install time.
+	credentialsSecretName = "scaleway-credentials"
+)
+
+var (
+	// zoneRegexp matches Scaleway zone names, e.g. fr-par-1, capturing the region they belong to.
+	zoneRegexp = regexp.MustCompile(`^([a-z]{2}-[a-z]{3})-[0-9]+$`)
+	// regionRegexp matches Scaleway region names, e.g. fr-par.
+	regionRegexp = regexp.MustCompile(`^[a-z]{2}-[a-z]{3}$`)
+)
+
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:         providerName,
+		PlatformType: configv1.ExternalPlatformType,
+		Matches: func(platformStatus *configv1.PlatformStatus, externalPlatformName string) bool {
+			return IsScaleway(externalPlatformName)
+		},
+		NewAssets:              NewProviderAssets,
+		CloudConfigTransformer: CloudConfigTransformer,
+	})
+}
+
+type imagesReference struct {
+	CloudControllerManager string `valid:"required"`
+}
+
+var templateValuesValidationMap = map[string]interface{}{
+	"images":             "required",
+	"infrastructureName": "required,type(string)",
+	"cloudproviderName":  "required,type(string)",
+}
+
+type scalewayAssets struct {
+	operatorConfig    config.OperatorConfig
+	renderedResources []client.Object
+}
+
+func (assets *scalewayAssets) GetRenderedResources() []client.Object {
+	return assets.renderedResources
+}
+
+// IsScaleway returns true if the External platform name reported in the infrastructure refers to Scaleway.
+func IsScaleway(externalPlatformName string) bool {
+	return strings.EqualFold(externalPlatformName, providerName)
+}
+
+func getTemplateValues(images *imagesReference, operatorConfig config.OperatorConfig) (common.TemplateValues, error) {
+	values := common.TemplateValues{
+		"images":             images,
+		"infrastructureName": operatorConfig.InfrastructureName,
+		"cloudproviderName":  operatorConfig.GetPlatformNameString(),
+	}
+	_, err := govalidator.ValidateMap(values, templateValuesValidationMap)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getDeploymentOverlay returns the Scaleway parts of the base cloud-controller-manager Deployment. The cloud
+// controller manager is configured through env variables, which are read from the synced cloud config.
+func getDeploymentOverlay() common.DeploymentOverlay {
+	volume, mount := common.CloudConfigVolume("scaleway-config", "/etc/scaleway")
+	return common.DeploymentOverlay{
+		Name: "scaleway-cloud-controller-manager",
+		Exec: "/usr/bin/env $(grep -E '^[A-Z_]+=' /etc/scaleway/cloud.conf) /bin/scaleway-cloud-controller-manager",
+		Args: []string{"--cloud-provider=scaleway"},
+		Env: []corev1.EnvVar{
+			common.SecretEnvVar("SCW_ACCESS_KEY", credentialsSecretName, "accessKey", false),
+			common.SecretEnvVar("SCW_SECRET_KEY", credentialsSecretName, "secretKey", false),
+			common.SecretEnvVar("SCW_DEFAULT_PROJECT_ID", credentialsSecretName, "projectID", false),
+		},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Volumes:      []corev1.Volume{volume},
+	}
+}
+
+func NewProviderAssets(config config.OperatorConfig) (common.CloudProviderAssets, error) {
+	images := &imagesReference{
+		CloudControllerManager: config.ImagesReference.CloudControllerManagerScaleway,
+	}
+	_, err := govalidator.ValidateStruct(images)
+	if err != nil {
+		return nil, fmt.Errorf("%s: missed images in config: %v", providerName, err)
+	}
+	assets := &scalewayAssets{
+		operatorConfig: config,
+	}
+	templateValues, err := getTemplateValues(images, config)
+	if err != nil {
+		return nil, fmt.Errorf("can not construct template values for %s assets: %v", providerName, err)
+	}
+
+	deployment, err := common.RenderBaseDeployment(getDeploymentOverlay(), templateValues)
+	if err != nil {
+		return nil, err
+	}
+	assets.renderedResources = []client.Object{deployment}
+	return assets, nil
+}
+
+// cloudConfig is the user-provided configuration of the Scaleway cloud provider,
+// referenced by the infrastructure cloudConfig field.
+type cloudConfig struct {
+	// Region is the Scaleway region the cluster runs in, e.g. fr-par.
+	// Defaults to the region of the zone when omitted.
+	Region string `json:"region,omitempty"`
+	// Zone is the default Scaleway zone for resources created by the cloud-controller-manager, e.g. fr-par-1.
+	// Defaults to the first zone of the region when omitted.
+	Zone string `json:"zone,omitempty"`
+	// APIURL overrides the Scaleway API endpoint.
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// CloudConfigTransformer implements the cloudConfigTransformer. The Scaleway cloud-controller-manager is
+// configured through environment variables, so it translates the user-provided YAML configuration
+// into an environment file which is loaded by the cloud-controller-manager container command.
+// Either the region or the zone has to be set, the other one is defaulted from it.
+// It returns an error if the platform is not ExternalPlatformType or if the configuration is not valid.
+func CloudConfigTransformer(source string, infra *configv1.Infrastructure, network *configv1.Network, features featuregates.FeatureGate) (string, error) {
+	if infra.Status.PlatformStatus == nil ||
+		infra.Status.PlatformStatus.Type != configv1.ExternalPlatformType {
+		return "", fmt.Errorf("invalid platform, expected to be %s", configv1.ExternalPlatformType)
+	}
+
+	cfg := cloudConfig{}
+	if err := yaml.UnmarshalStrict([]byte(source), &cfg); err != nil {
+		return "", fmt.Errorf("failed to read the cloud.conf: %w", err)
+	}
+	if err := setDefaults(&cfg); err != nil {
+		return "", err
+	}
+
+	env := map[string]string{
+		"SCW_DEFAULT_REGION": cfg.Region,
+		"SCW_DEFAULT_ZONE":   cfg.Zone,
+	}
+	if cfg.APIURL != "" {
+		env["SCW_API_URL"] = cfg.APIURL
+	}
+
+	return common.RenderEnvFile(env)
+}
+
+// setDefaults fills the region from the zone, or the zone from the region, and ensures both are consistent.
+func setDefaults(cfg *cloudConfig) error {
+	if cfg.Region == "" && cfg.Zone == "" {
+		return fmt.Errorf("either 'region' or 'zone' is required in the cloud.conf")
+	}
+	if cfg.Region != "" && !regionRegexp.MatchString(cfg.Region) {
+		return fmt.Errorf("invalid region %q, expected a value like fr-par", cfg.Region)
+	}
+
+	if cfg.Zone == "" {
+		cfg.Zone = cfg.Region + "-1"
+		return nil
+	}
+
+	match := zoneRegexp.FindStringSubmatch(cfg.Zone)
+	if match == nil {
+		return fmt.Errorf("invalid zone %q, expected a value like fr-par-1", cfg.Zone)
+	}
+	if cfg.Region == "" {
+		cfg.Region = match[1]
+	} else if cfg.Region != match[1] {
+		return fmt.Errorf("zone %q does not belong to region %q", cfg.Zone, cfg.Region)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package scaleway
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+func TestResourcesRenderingSmoke(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		config     config.OperatorConfig
+		initErrMsg string
+	}{
+		{
+			name:       "Empty config",
+			config:     config.OperatorConfig{},
+			initErrMsg: "scaleway: missed images in config: CloudControllerManager: non zero value required",
+		}, {
+			name: "No infra name",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerScaleway: "CloudControllerManagerScaleway",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "scaleway",
+			},
+			initErrMsg: "can not construct template values for scaleway assets: infrastructureName: non zero value required",
+		}, {
+			name: "Minimal allowed config",
+			config: config.OperatorConfig{
+				ManagedNamespace: "my-cool-namespace",
+				ImagesReference: config.ImagesReference{
+					CloudControllerManagerScaleway: "CloudControllerManagerScaleway",
+				},
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+				ExternalPlatformName: "scaleway",
+				InfrastructureName:   "infra",
+			},
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			assets, err := NewProviderAssets(tc.config)
+			if tc.initErrMsg != "" {
+				assert.EqualError(t, err, tc.initErrMsg)
+				return
+			} else {
+				assert.NoError(t, err)
+			}
+
+			resources := assets.GetRenderedResources()
+			assert.Len(t, resources, 1)
+		})
+	}
+}
+
+func makeInfrastructureResource(platform configv1.PlatformType) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{Type: platform},
+		},
+	}
+}
+
+func TestCloudConfigTransformer(t *testing.T) {
+
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		expected string
+		errMsg   string
+	}{
+		{
+			name:   "Invalid platform",
+			infra:  makeInfrastructureResource(configv1.AWSPlatformType),
+			errMsg: "invalid platform, expected to be External",
+		}, {
+			name:   "Empty config",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "either 'region' or 'zone' is required in the cloud.conf",
+		}, {
+			name:     "Zone defaulted from region",
+			source:   "region: fr-par",
+			infra:    makeInfrastructureResource(configv1.ExternalPlatformType),
+			expected: "SCW_DEFAULT_REGION=fr-par\nSCW_DEFAULT_ZONE=fr-par-1\n",
+		}, {
+			name:     "Region defaulted from zone",
+			source:   "zone: nl-ams-2",
+			infra:    makeInfrastructureResource(configv1.ExternalPlatformType),
+			expected: "SCW_DEFAULT_REGION=nl-ams\nSCW_DEFAULT_ZONE=nl-ams-2\n",
+		}, {
+			name: "Full config",
+			source: `region: pl-waw
+zone: pl-waw-3
+apiURL: https://api.scaleway.com
+`,
+			infra:    makeInfrastructureResource(configv1.ExternalPlatformType),
+			expected: "SCW_API_URL=https://api.scaleway.com\nSCW_DEFAULT_REGION=pl-waw\nSCW_DEFAULT_ZONE=pl-waw-3\n",
+		}, {
+			name:   "Zone outside of region",
+			source: "region: fr-par\nzone: nl-ams-1",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "zone \"nl-ams-1\" does not belong to region \"fr-par\"",
+		}, {
+			name:   "Invalid region",
+			source: "region: Paris",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "invalid region \"Paris\", expected a value like fr-par",
+		}, {
+			name:   "Invalid zone",
+			source: "zone: fr-par",
+			infra:  makeInfrastructureResource(configv1.ExternalPlatformType),
+			errMsg: "invalid zone \"fr-par\", expected a value like fr-par-1",
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CloudConfigTransformer(tc.source, tc.infra, nil, nil)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/asaskevich/govalidator"
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/api/features"
 	appsv1 "k8s.io/api/apps/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -17,7 +18,7 @@ import (
 const (
 	providerName = "vsphere"
 
-	// see manifests/0000_26_cloud-controller-manager-operator_16_credentialsrequest-vsphere.yaml
+	// see manifests/0000_26_cloud-controller-manager-operator_17_credentialsrequest-vsphere.yaml
 	globalCredsSecretName = "vsphere-cloud-credentials"
 
 	vSpherePlatformTypeLabel = "node.openshift.io/platform-type=vsphere"
@@ -37,6 +38,16 @@ var (
 	}
 )
 
+func init() {
+	common.RegisterCloudProvider(common.CloudProvider{
+		Name:                     providerName,
+		PlatformType:             configv1.VSpherePlatformType,
+		NewAssets:                NewProviderAssets,
+		CloudConfigTransformer:   CloudConfigTransformer,
+		CloudConfigSensitiveKeys: []string{"user", "password"},
+	})
+}
+
 type imagesReference struct {
 	CloudControllerManager string `valid:"required"`
 }
@@ -2,11 +2,13 @@ package vsphere
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	"k8s.io/utils/net"
 
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
 	ccmConfig "github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/vsphere/vsphere_cloud_config"
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 )
@@ -19,6 +21,10 @@ import (
 const (
 	regionLabelValue = "openshift-region"
 	zoneLabelValue   = "openshift-zone"
+
+	// globalCredsSecretNamespace is the namespace the credentials secret is requested in,
+	// see manifests/0000_26_cloud-controller-manager-operator_17_credentialsrequest-vsphere.yaml
+	globalCredsSecretNamespace = "openshift-cloud-controller-manager"
 )
 
 // CloudConfigTransformer takes the user-provided, legacy cloud provider-compatible configuration and
@@ -57,7 +63,94 @@ func CloudConfigTransformer(source string, infra *configv1.Infrastructure, netwo
 		}
 	}
 
-	return ccmConfig.MarshalConfig(cpiCfg)
+	removeInlineCredentials(cpiCfg)
+
+	if err := validateTopology(cpiCfg, infra.Spec.PlatformSpec.VSphere); err != nil {
+		return "", fmt.Errorf("vSphere topology prerequisites are not met: %w", err)
+	}
+
+	out, err := ccmConfig.MarshalConfig(cpiCfg)
+	if err != nil {
+		return "", err
+	}
+	// Options of a YAML config unknown to the vendored config type, e.g. added by a newer cloud provider, are kept.
+	return common.PreserveUnknownYAMLFields(source, out, cpiCfg)
+}
+
+// HasInlineCredentials returns true if the given cloud.conf sets a vCenter username or password in clear text.
+// Such credentials are removed by the CloudConfigTransformer, it returns false for configs which can not be read.
+func HasInlineCredentials(source string) bool {
+	cpiCfg, err := ccmConfig.ReadConfig([]byte(source))
+	if err != nil {
+		return false
+	}
+	if cpiCfg.Global.User != "" || cpiCfg.Global.Password != "" {
+		return true
+	}
+	for _, vcenter := range cpiCfg.Vcenter {
+		if vcenter.User != "" || vcenter.Password != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// removeInlineCredentials removes the vCenter usernames and passwords from the config, so they do not land in the
+// synced ConfigMap, which is readable by anyone in the managed namespace. When the config does not reference a
+// global credentials secret, the one requested by the operator is referenced instead, the CCM then looks up the
+// "<server>.username" and "<server>.password" keys in it.
+func removeInlineCredentials(cfg *ccmConfig.CPIConfig) {
+	found := cfg.Global.User != "" || cfg.Global.Password != ""
+	cfg.Global.User = ""
+	cfg.Global.Password = ""
+	for _, vcenter := range cfg.Vcenter {
+		found = found || vcenter.User != "" || vcenter.Password != ""
+		vcenter.User = ""
+		vcenter.Password = ""
+	}
+
+	if found && cfg.Global.SecretName == "" {
+		cfg.Global.SecretName = globalCredsSecretName
+		cfg.Global.SecretNamespace = globalCredsSecretNamespace
+	}
+}
+
+// validateTopology checks that the zone and region tag categories referenced in the composed config
+// can be resolved by the CCM, so a misconfigured topology surfaces as an operator error instead of a
+// crash-looping CCM.
+func validateTopology(cfg *ccmConfig.CPIConfig, vSphereSpec *configv1.VSpherePlatformSpec) error {
+	if cfg.Labels.Zone == "" && cfg.Labels.Region == "" {
+		return nil
+	}
+
+	if cfg.Labels.Zone == "" || cfg.Labels.Region == "" {
+		return fmt.Errorf("both zone and region tag categories must be set in the labels section, got zone %q and region %q",
+			cfg.Labels.Zone, cfg.Labels.Region)
+	}
+	if cfg.Labels.Zone == cfg.Labels.Region {
+		return fmt.Errorf("zone and region tag categories must be different, got %q for both", cfg.Labels.Zone)
+	}
+
+	if len(cfg.Vcenter) == 0 {
+		return fmt.Errorf("no vcenter is configured to look up the %q and %q tag categories", cfg.Labels.Zone, cfg.Labels.Region)
+	}
+	for server, vcenter := range cfg.Vcenter {
+		if len(vcenter.Datacenters) == 0 {
+			return fmt.Errorf("vcenter %q has no datacenters configured to look up the %q and %q tag categories",
+				server, cfg.Labels.Zone, cfg.Labels.Region)
+		}
+	}
+
+	if vSphereSpec == nil {
+		return nil
+	}
+	for _, fd := range vSphereSpec.FailureDomains {
+		if fd.Region == "" || fd.Zone == "" {
+			return fmt.Errorf("failure domain %q must have both region and zone tags set", fd.Name)
+		}
+	}
+
+	return nil
 }
 
 // setNodes sets Nodes section in vsphere-cloud-provider config according passed VSpherePlatformNodeNetworking spec
@@ -71,37 +164,61 @@ func setNodes(cfg *ccmConfig.CPIConfig, nodeNetworking *configv1.VSpherePlatform
 	cfg.Nodes.ExcludeInternalNetworkSubnetCIDR = strings.Join(nodeNetworking.Internal.ExcludeNetworkSubnetCIDR, ",")
 }
 
-// setVirtualCenters sets vcenter server sections according passed VSpherePlatformSpec
+// setVirtualCenters sets vcenter server sections according passed VSpherePlatformSpec.
+// When the spec lists vCenters, it is considered authoritative: vcenter sections for servers which are
+// referenced neither by vCenters nor by failure domains are dropped, so stale servers from the user-provided
+// configuration do not make the CCM fail to connect.
+// Settings which are not part of the spec (e.g. insecureFlag, thumbprint or per-vCenter credentials secret
+// references) are preserved from the user-provided configuration. vCenters without their own secret reference
+// inherit the global one, where the CCM looks up the "<server>.username" and "<server>.password" keys.
 func setVirtualCenters(cfg *ccmConfig.CPIConfig, vSphereSpec *configv1.VSpherePlatformSpec) {
-	for _, vcenter := range vSphereSpec.VCenters {
-		cfg.Vcenter[vcenter.Server] = &ccmConfig.VirtualCenterConfig{
-			VCenterIP:   vcenter.Server,
-			VCenterPort: uint(vcenter.Port),
-			Datacenters: vcenter.Datacenters,
-		}
+	if cfg.Vcenter == nil {
+		cfg.Vcenter = map[string]*ccmConfig.VirtualCenterConfig{}
 	}
 
-	for _, fd := range vSphereSpec.FailureDomains {
-		vcenterCfg, ok := cfg.Vcenter[fd.Server]
+	getOrCreate := func(server string) *ccmConfig.VirtualCenterConfig {
+		vcenterCfg, ok := cfg.Vcenter[server]
 		if !ok {
-			cfg.Vcenter[fd.Server] = &ccmConfig.VirtualCenterConfig{
-				VCenterIP:   fd.Server,
-				Datacenters: []string{fd.Topology.Datacenter},
-			}
+			vcenterCfg = &ccmConfig.VirtualCenterConfig{}
+			cfg.Vcenter[server] = vcenterCfg
 		}
+		vcenterCfg.VCenterIP = server
+		return vcenterCfg
+	}
 
-		dcSeen := false
-		for _, dc := range vcenterCfg.Datacenters {
-			if dc == fd.Topology.Datacenter {
-				dcSeen = true
-				break
+	referenced := map[string]bool{}
+	for _, vcenter := range vSphereSpec.VCenters {
+		vcenterCfg := getOrCreate(vcenter.Server)
+		vcenterCfg.VCenterPort = uint(vcenter.Port)
+		vcenterCfg.Datacenters = appendDatacenters(nil, vcenter.Datacenters...)
+		referenced[vcenter.Server] = true
+	}
+
+	for _, fd := range vSphereSpec.FailureDomains {
+		vcenterCfg := getOrCreate(fd.Server)
+		vcenterCfg.Datacenters = appendDatacenters(vcenterCfg.Datacenters, fd.Topology.Datacenter)
+		referenced[fd.Server] = true
+	}
+
+	if len(vSphereSpec.VCenters) > 0 {
+		for server := range cfg.Vcenter {
+			if !referenced[server] {
+				delete(cfg.Vcenter, server)
 			}
 		}
+	}
+
+}
 
-		if !dcSeen {
-			vcenterCfg.Datacenters = append(vcenterCfg.Datacenters, fd.Topology.Datacenter)
+// appendDatacenters appends datacenters which are not yet in the list, preserving the order.
+func appendDatacenters(datacenters []string, toAdd ...string) []string {
+	for _, dc := range toAdd {
+		if dc == "" || slices.Contains(datacenters, dc) {
+			continue
 		}
+		datacenters = append(datacenters, dc)
 	}
+	return datacenters
 }
 
 // setIPFamilies updates the configuration required by the cloud-provider-vsphere to explicitly set
@@ -11,6 +11,7 @@ import (
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 
 	ccm "k8s.io/cloud-provider-vsphere/pkg/cloudprovider/vsphere/config"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -144,6 +145,65 @@ func (b infraBuilder) withVSphereZones() infraBuilder {
 	return b
 }
 
+func (b infraBuilder) withVSphereMultipleVCenters() infraBuilder {
+	vcenterSpecs := []configv1.VSpherePlatformVCenterSpec{
+		{
+			Server:      "vcenter-east",
+			Port:        443,
+			Datacenters: []string{"DC1"},
+		}, {
+			Server:      "vcenter-west",
+			Port:        8443,
+			Datacenters: []string{"DC3"},
+		},
+	}
+	failureDomainSpec := []configv1.VSpherePlatformFailureDomainSpec{
+		{
+			Name:   "east-1a",
+			Region: "east",
+			Zone:   "east-1a",
+			Server: "vcenter-east",
+			Topology: configv1.VSpherePlatformTopology{
+				Datacenter: "DC1",
+			},
+		}, {
+			Name:   "east-2a",
+			Region: "east",
+			Zone:   "east-2a",
+			Server: "vcenter-east",
+			Topology: configv1.VSpherePlatformTopology{
+				Datacenter: "DC2",
+			},
+		}, {
+			Name:   "west-1a",
+			Region: "west",
+			Zone:   "west-1a",
+			Server: "vcenter-west",
+			Topology: configv1.VSpherePlatformTopology{
+				Datacenter: "DC3",
+			},
+		}, {
+			Name:   "north-1a",
+			Region: "north",
+			Zone:   "north-1a",
+			Server: "vcenter-north",
+			Topology: configv1.VSpherePlatformTopology{
+				Datacenter: "DC4",
+			},
+		},
+	}
+	vspereSpecRef := b.platformSpec.VSphere
+	vspereSpecRef.FailureDomains = append(vspereSpecRef.FailureDomains, failureDomainSpec...)
+	vspereSpecRef.VCenters = append(vspereSpecRef.VCenters, vcenterSpecs...)
+	return b
+}
+
+func (b infraBuilder) withVSphereFailureDomainWithoutZone() infraBuilder {
+	b = b.withVSphereZones()
+	b.platformSpec.VSphere.FailureDomains[1].Zone = ""
+	return b
+}
+
 func (b infraBuilder) withPrimaryIPv4VIP() infraBuilder {
 	b.platformStatus.VSphere.APIServerInternalIPs = []string{"192.168.96.3", "fd65:a1a8:60ad:271c::200"}
 	b.platformStatus.VSphere.IngressIPs = []string{"192.168.96.4", "fd65:a1a8:60ad:271c::201"}
@@ -260,6 +320,41 @@ insecure-flag = "1"
 [VirtualCenter "test-server"]
 datacenters = "DC1"`
 
+const iniConfigWithInlineCredentials = `
+[Global]
+user = "admin"
+password = "secret"
+insecure-flag = "1"
+
+[VirtualCenter "test-server"]
+user = "vcenter-admin"
+password = "vcenter-secret"
+datacenters = "DC1"`
+
+const yamlConfigWithInlineCredentialsRemoved = `
+global:
+  insecureFlag: true
+  secretName: vsphere-cloud-credentials
+  secretNamespace: openshift-cloud-controller-manager
+vcenter:
+  test-server:
+    server: test-server
+    datacenters:
+    - DC1`
+
+const yamlConfigWithInlineCredentialsAndSecret = `
+global:
+  user: admin
+  password: secret
+  insecureFlag: true
+  secretName: vsphere-creds
+  secretNamespace: kube-system
+vcenter:
+  test-server:
+    server: test-server
+    datacenters:
+    - DC1`
+
 const iniConfigNodeNetworking = `
 [Global]
 secret-name = "vsphere-creds"
@@ -387,6 +482,77 @@ labels:
   zone: openshift-zone
   region: openshift-region`
 
+const yamlConfigMultipleVCenters = `
+global:
+  insecureFlag: true
+  secretName: vsphere-creds
+  secretNamespace: kube-system
+vcenter:
+  test-server:
+    server: test-server
+    datacenters:
+    - DC1
+  vcenter-west:
+    server: vcenter-west
+    thumbprint: "AA:BB:CC"
+    secretName: vsphere-west-creds
+    secretNamespace: kube-system
+    datacenters:
+    - DC5`
+
+const yamlConfigMultipleVCentersComposed = `
+global:
+  insecureFlag: true
+  secretName: vsphere-creds
+  secretNamespace: kube-system
+vcenter:
+  vcenter-east:
+    server: vcenter-east
+    port: 443
+    datacenters:
+    - DC1
+    - DC2
+  vcenter-west:
+    server: vcenter-west
+    port: 8443
+    thumbprint: "AA:BB:CC"
+    secretName: vsphere-west-creds
+    secretNamespace: kube-system
+    datacenters:
+    - DC3
+  vcenter-north:
+    server: vcenter-north
+    datacenters:
+    - DC4
+labels:
+  zone: openshift-zone
+  region: openshift-region`
+
+const yamlConfigZoneLabelOnly = `
+global:
+  insecureFlag: true
+  secretName: vsphere-creds
+  secretNamespace: kube-system
+vcenter:
+  test-server:
+    server: test-server
+    datacenters:
+    - DC1
+labels:
+  zone: k8s-zone`
+
+const yamlConfigLabelsWithoutDatacenters = `
+global:
+  insecureFlag: true
+  secretName: vsphere-creds
+  secretNamespace: kube-system
+vcenter:
+  test-server:
+    server: test-server
+labels:
+  zone: k8s-zone
+  region: k8s-region`
+
 func TestCloudConfigTransformer(t *testing.T) {
 	testcases := []struct {
 		name             string
@@ -493,6 +659,54 @@ func TestCloudConfigTransformer(t *testing.T) {
 			equivalentConfig: yamlConfigNodeNetworkingIPv6only,
 			features:         featuregates.NewFeatureGate(nil, nil),
 		},
+		{
+			name:             "yaml config should be composed from multiple vCenters and failure domains",
+			infraBuilder:     newVsphereInfraBuilder().withVSphereMultipleVCenters(),
+			networkBuilder:   makeDummyNetworkConfig(),
+			inputConfig:      yamlConfigMultipleVCenters,
+			equivalentConfig: yamlConfigMultipleVCentersComposed,
+			features:         featuregates.NewFeatureGate(nil, nil),
+		},
+		{
+			name:             "inline credentials should be replaced with the credentials secret",
+			infraBuilder:     newVsphereInfraBuilder(),
+			networkBuilder:   makeDummyNetworkConfig(),
+			inputConfig:      iniConfigWithInlineCredentials,
+			equivalentConfig: yamlConfigWithInlineCredentialsRemoved,
+			features:         featuregates.NewFeatureGate(nil, nil),
+		},
+		{
+			name:             "inline credentials should be removed keeping the configured secret",
+			infraBuilder:     newVsphereInfraBuilder(),
+			networkBuilder:   makeDummyNetworkConfig(),
+			inputConfig:      yamlConfigWithInlineCredentialsAndSecret,
+			equivalentConfig: yamlConfig,
+			features:         featuregates.NewFeatureGate(nil, nil),
+		},
+		{
+			name:           "zone tag category without region",
+			infraBuilder:   newVsphereInfraBuilder(),
+			networkBuilder: makeDummyNetworkConfig(),
+			inputConfig:    yamlConfigZoneLabelOnly,
+			errMsg:         "vSphere topology prerequisites are not met: both zone and region tag categories must be set in the labels section, got zone \"k8s-zone\" and region \"\"",
+			features:       featuregates.NewFeatureGate(nil, nil),
+		},
+		{
+			name:           "tag categories without datacenters",
+			infraBuilder:   newVsphereInfraBuilder(),
+			networkBuilder: makeDummyNetworkConfig(),
+			inputConfig:    yamlConfigLabelsWithoutDatacenters,
+			errMsg:         "vSphere topology prerequisites are not met: vcenter \"test-server\" has no datacenters configured",
+			features:       featuregates.NewFeatureGate(nil, nil),
+		},
+		{
+			name:           "failure domain without zone tag",
+			infraBuilder:   newVsphereInfraBuilder().withVSphereFailureDomainWithoutZone(),
+			networkBuilder: makeDummyNetworkConfig(),
+			inputConfig:    yamlConfig,
+			errMsg:         "vSphere topology prerequisites are not met: failure domain \"east-2a\" must have both region and zone tags set",
+			features:       featuregates.NewFeatureGate(nil, nil),
+		},
 		{
 			name:           "empty input",
 			infraBuilder:   newVsphereInfraBuilder(),
@@ -540,3 +754,56 @@ func TestCloudConfigTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudConfigTransformerUnknownFields(t *testing.T) {
+	g := gmg.NewWithT(t)
+
+	source := yamlConfig + `
+    newOption: value
+nodes:
+  newNodesOption: true`
+	transformedConfig, err := CloudConfigTransformer(source, newVsphereInfraBuilder().Build(), makeDummyNetworkConfig(), featuregates.NewFeatureGate(nil, nil))
+	g.Expect(err).ShouldNot(gmg.HaveOccurred())
+
+	observed := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(transformedConfig), &observed)).To(gmg.Succeed())
+	g.Expect(observed).To(gmg.HaveKeyWithValue("nodes", gmg.HaveKeyWithValue("newNodesOption", true)))
+	g.Expect(observed).To(gmg.HaveKeyWithValue("vcenter", gmg.HaveKeyWithValue("test-server", gmg.HaveKeyWithValue("newOption", "value"))))
+	g.Expect(observed).To(gmg.HaveKeyWithValue("global", gmg.HaveKeyWithValue("secretName", "vsphere-creds")))
+}
+
+func TestHasInlineCredentials(t *testing.T) {
+	testcases := []struct {
+		name     string
+		config   string
+		expected bool
+	}{
+		{
+			name:     "credentials secret reference",
+			config:   yamlConfig,
+			expected: false,
+		},
+		{
+			name:     "inline credentials in ini config",
+			config:   iniConfigWithInlineCredentials,
+			expected: true,
+		},
+		{
+			name:     "inline credentials in yaml config",
+			config:   yamlConfigWithInlineCredentialsAndSecret,
+			expected: true,
+		},
+		{
+			name:     "invalid config",
+			config:   ":",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gmg.NewWithT(t)
+			g.Expect(HasInlineCredentials(tc.config)).To(gmg.Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package cloudconfig exposes the transformation the operator applies to the cloud config of a cluster before
+// publishing it to the external cloud controller manager of its platform. Other components rendering the config
+// ahead of the operator, e.g. the installer or hypershift, can use it to produce identical configs.
+//
+// The API of this package is kept stable across releases. It only covers the parts of the sync which depend on
+// the given inputs: options the operator reads from credentials secrets or user-facing ConfigMaps of a running
+// cluster, e.g. the OpenStack clouds.yaml or the GCP Shared VPC options, are not set.
+package cloudconfig
+
+import (
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
+)
+
+// DefaultKey is the key of the synced cloud config ConfigMap the config is written to,
+// unless the provider of the platform expects it in another one.
+const DefaultKey = "cloud.conf"
+
+// Options holds the optional inputs of the transformation.
+type Options struct {
+	// Network is the cluster Network resource, transformers which need it fail without it.
+	Network *configv1.Network
+	// FeatureGates are the feature gates enabled in the cluster, none are enabled if nil.
+	FeatureGates featuregates.FeatureGate
+	// Overrides is a partial cloud config, in the same format as the transformed one, which is deep-merged on top of it,
+	// like the operator does with the ccm-cloud-config-overrides ConfigMap.
+	Overrides string
+}
+
+// Result is the transformed cloud config.
+type Result struct {
+	// Key is the key of the synced cloud config ConfigMap the cloud controller manager reads the config from.
+	Key string
+	// Config is the transformed cloud config.
+	Config string
+}
+
+// Transform transforms the given source cloud config into the config the external cloud controller manager of
+// the platform of the given infrastructure expects, and validates it.
+// It returns an error if the platform does not consume a cloud config, or if the source or the result is invalid.
+func Transform(source string, infra *configv1.Infrastructure, opts Options) (Result, error) {
+	if infra == nil || infra.Status.PlatformStatus == nil {
+		return Result{}, fmt.Errorf("infrastructure platform status is required")
+	}
+	platformStatus := infra.Status.PlatformStatus
+	externalPlatformName := config.GetExternalPlatformName(infra)
+
+	transformer, _, err := cloud.GetCloudConfigTransformer(platformStatus, externalPlatformName)
+	if err != nil {
+		return Result{}, err
+	}
+
+	features := opts.FeatureGates
+	if features == nil {
+		features = featuregates.NewFeatureGate(nil, nil)
+	}
+	output, err := transformer(source, infra, opts.Network, features)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to transform cloud config: %w", err)
+	}
+
+	if opts.Overrides != "" {
+		output, err = common.MergeCloudConfigOverrides(output, opts.Overrides)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to merge cloud config overrides: %w", err)
+		}
+	}
+
+	// Like the operator, never publish credentials set in clear text.
+	output, _, err = cloud.StripPlaintextCredentials(platformStatus, externalPlatformName, output)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to remove plaintext credentials: %w", err)
+	}
+
+	if err := cloud.ValidateCloudConfig(platformStatus, externalPlatformName, output); err != nil {
+		return Result{}, fmt.Errorf("invalid cloud config: %w", err)
+	}
+
+	key := cloud.GetCloudConfigKey(platformStatus, externalPlatformName)
+	if key == "" {
+		key = DefaultKey
+	}
+	return Result{Key: key, Config: output}, nil
+}
@@ -0,0 +1,125 @@
+package cloudconfig
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func makeInfrastructure(platformStatus *configv1.PlatformStatus) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		Status: configv1.InfrastructureStatus{
+			InfrastructureName: "my-cluster-abcde",
+			PlatformStatus:     platformStatus,
+		},
+	}
+}
+
+func TestTransform(t *testing.T) {
+	tc := []struct {
+		name     string
+		source   string
+		infra    *configv1.Infrastructure
+		opts     Options
+		expected Result
+		errMsg   string
+	}{
+		{
+			name:   "No platform status",
+			infra:  makeInfrastructure(nil),
+			errMsg: "infrastructure platform status is required",
+		},
+		{
+			name:   "Platform without cloud config",
+			infra:  makeInfrastructure(&configv1.PlatformStatus{Type: configv1.BareMetalPlatformType}),
+			errMsg: `unrecognized platform type "BareMetal" found in infrastructure`,
+		},
+		{
+			name:   "AWS",
+			source: "",
+			infra: makeInfrastructure(&configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS:  &configv1.AWSPlatformStatus{Region: "us-east-1"},
+			}),
+			expected: Result{
+				Key: DefaultKey,
+				Config: `[Global]
+Region                                          = us-east-1
+KubernetesClusterID                             = my-cluster-abcde
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			},
+		},
+		{
+			name:   "AWS with overrides",
+			source: "",
+			infra: makeInfrastructure(&configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS:  &configv1.AWSPlatformStatus{Region: "us-east-1"},
+			}),
+			opts: Options{Overrides: `[Global]
+DisableSecurityGroupIngress = true
+`},
+			expected: Result{
+				Key: DefaultKey,
+				Config: `[Global]
+Region                                          = us-east-1
+KubernetesClusterID                             = my-cluster-abcde
+DisableSecurityGroupIngress                     = true
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			},
+		},
+		{
+			name: "Plaintext credentials",
+			source: `[Global]
+SecretAccessKey = secret
+`,
+			infra: makeInfrastructure(&configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS:  &configv1.AWSPlatformStatus{Region: "us-east-1"},
+			}),
+			expected: Result{
+				Key: DefaultKey,
+				Config: `[Global]
+Region                                          = us-east-1
+KubernetesClusterID                             = my-cluster-abcde
+DisableSecurityGroupIngress                     = false
+ClusterServiceLoadBalancerHealthProbeMode       = Shared
+ClusterServiceSharedLoadBalancerHealthProbePort = 0
+`,
+			},
+		},
+		{
+			name:   "Invalid overrides",
+			source: "",
+			infra: makeInfrastructure(&configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS:  &configv1.AWSPlatformStatus{Region: "us-east-1"},
+			}),
+			opts: Options{Overrides: `[Global]
+NodeIPFamilies = ipv5
+`},
+			errMsg: `invalid cloud config: invalid NodeIPFamilies entry "ipv5", expected "ipv4" or "ipv6"`,
+		},
+	}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			actual, err := Transform(tc.source, tc.infra, tc.opts)
+			if tc.errMsg != "" {
+				g.Expect(err).Should(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(actual).Should(Equal(tc.expected))
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"k8s.io/klog/v2"
 
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/api/features"
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/util"
@@ -19,28 +21,76 @@ import (
 // `cloud-controller-manager-images` config map which manages and populating by `openshift-cluster-version-operator`.
 // See manifests/0000_26_cloud-controller-manager-operator_01_images.configmap.yaml
 type ImagesReference struct {
-	CloudControllerManagerOperator  string `json:"cloudControllerManagerOperator"`
-	CloudControllerManagerAWS       string `json:"cloudControllerManagerAWS"`
-	CloudControllerManagerAzure     string `json:"cloudControllerManagerAzure"`
-	CloudNodeManagerAzure           string `json:"cloudNodeManagerAzure"`
-	CloudControllerManagerGCP       string `json:"cloudControllerManagerGCP"`
-	CloudControllerManagerIBM       string `json:"cloudControllerManagerIBM"`
-	CloudControllerManagerOpenStack string `json:"cloudControllerManagerOpenStack"`
-	CloudControllerManagerVSphere   string `json:"cloudControllerManagerVSphere"`
-	CloudControllerManagerPowerVS   string `json:"cloudControllerManagerPowerVS"`
-	CloudControllerManagerNutanix   string `json:"cloudControllerManagerNutanix"`
+	CloudControllerManagerOperator     string `json:"cloudControllerManagerOperator"`
+	CloudControllerManagerAWS          string `json:"cloudControllerManagerAWS"`
+	CloudControllerManagerAzure        string `json:"cloudControllerManagerAzure"`
+	CloudNodeManagerAzure              string `json:"cloudNodeManagerAzure"`
+	CloudNodeManagerAzureWindows       string `json:"cloudNodeManagerAzureWindows,omitempty"`
+	CloudControllerManagerGCP          string `json:"cloudControllerManagerGCP"`
+	CloudControllerManagerIBM          string `json:"cloudControllerManagerIBM"`
+	CloudControllerManagerOpenStack    string `json:"cloudControllerManagerOpenStack"`
+	CloudControllerManagerVSphere      string `json:"cloudControllerManagerVSphere"`
+	CloudControllerManagerPowerVS      string `json:"cloudControllerManagerPowerVS"`
+	CloudControllerManagerNutanix      string `json:"cloudControllerManagerNutanix"`
+	CloudControllerManagerOCI          string `json:"cloudControllerManagerOCI"`
+	CloudControllerManagerHetzner      string `json:"cloudControllerManagerHetzner"`
+	CloudControllerManagerEquinixMetal string `json:"cloudControllerManagerEquinixMetal"`
+	CloudControllerManagerKubevirt     string `json:"cloudControllerManagerKubevirt"`
+	CloudControllerManagerLinode       string `json:"cloudControllerManagerLinode"`
+	CloudControllerManagerScaleway     string `json:"cloudControllerManagerScaleway"`
 }
 
 // OperatorConfig contains configuration values for templating resources
 type OperatorConfig struct {
-	ManagedNamespace   string
-	ImagesReference    ImagesReference
-	IsSingleReplica    bool
-	InfrastructureName string
-	PlatformStatus     *configv1.PlatformStatus
-	ClusterProxy       *configv1.Proxy
-	FeatureGates       string
-	OCPFeatureGates    featuregates.FeatureGate
+	ManagedNamespace string
+	ImagesReference  ImagesReference
+	// ControlPlaneTopology is the topology of the cluster control plane, the cloud-controller-manager
+	// replicas are derived from it.
+	ControlPlaneTopology configv1.TopologyMode
+	InfrastructureName   string
+	// ClusterID is the unique identifier of the cluster from the ClusterVersion, empty if it is not known.
+	ClusterID string
+	// APIServerURL and APIServerInternalURL are the external and internal endpoints of the API server from the
+	// Infrastructure, e.g. for hosted control planes where the operands can not use the in-cluster service.
+	APIServerURL         string
+	APIServerInternalURL string
+	// BaseDomain is the base domain of the cluster from the DNS config, empty if it is not known.
+	BaseDomain string
+	// ClusterCIDRs are the pod network CIDRs of the cluster from the Network config, passed to the cloud controller
+	// managers which configure cloud routes or allocate node CIDRs.
+	ClusterCIDRs   []string
+	PlatformStatus *configv1.PlatformStatus
+	// ExternalPlatformName is the provider name reported for the External platform type, e.g. "oci".
+	ExternalPlatformName string
+	// ExternalManifests holds user-supplied cloud controller manager manifests for the External platform type,
+	// keyed by their name. When set, they are deployed instead of the assets shipped with the operator.
+	ExternalManifests map[string]string
+	// CloudControllerManagerArgs holds admin-provided cloud-controller-manager flags, keyed by their name without
+	// leading dashes, which override or are appended to the flags set by the assets.
+	CloudControllerManagerArgs map[string]string
+	// ContainerResources holds admin-provided resource requests and limits of the operand containers, keyed by
+	// "<container>.<requests|limits>.<cpu|memory>", which override the ones set by the assets.
+	ContainerResources map[string]string
+	// DisableCloudNodeManager stops the cloud-node-manager DaemonSet from being deployed on platforms which ship one,
+	// while the cloud-controller-manager Deployment keeps being managed.
+	DisableCloudNodeManager bool
+	ClusterProxy            *configv1.Proxy
+	FeatureGates            string
+	OCPFeatureGates         featuregates.FeatureGate
+
+	// OperandLogLevel is the klog verbosity admins set for the operands, nil keeps the one set by the assets.
+	OperandLogLevel *int
+}
+
+// IsSingleReplica returns true if the control plane runs a single replica of its components, e.g. on single node clusters.
+func (cfg *OperatorConfig) IsSingleReplica() bool {
+	return cfg.ControlPlaneTopology == configv1.SingleReplicaTopologyMode
+}
+
+// IsControlPlaneExternal returns true if the control plane runs outside of the cluster, along with the
+// cloud-controller-manager, which thus must not be deployed in the cluster.
+func (cfg *OperatorConfig) IsControlPlaneExternal() bool {
+	return cfg.ControlPlaneTopology == configv1.ExternalTopologyMode
 }
 
 func (cfg *OperatorConfig) GetPlatformNameString() string {
@@ -51,6 +101,15 @@ func (cfg *OperatorConfig) GetPlatformNameString() string {
 	return platformName
 }
 
+// GetExternalPlatformName returns the provider name set at install time for the External platform type.
+func GetExternalPlatformName(infra *configv1.Infrastructure) string {
+	if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.Type != configv1.ExternalPlatformType ||
+		infra.Spec.PlatformSpec.External == nil {
+		return ""
+	}
+	return infra.Spec.PlatformSpec.External.PlatformName
+}
+
 // checkInfrastructureResource checks Infrastructure resource for platform status presence
 func checkInfrastructureResource(infra *configv1.Infrastructure) error {
 	if infra == nil || infra.Status.PlatformStatus == nil {
@@ -78,8 +137,32 @@ func getImagesFromJSONFile(filePath string) (ImagesReference, error) {
 	return i, nil
 }
 
-// ComposeConfig creates a Config for operator
-func ComposeConfig(infrastructure *configv1.Infrastructure, clusterProxy *configv1.Proxy, imagesFile, managedNamespace string, featureGateAccessor featuregates.FeatureGateAccess) (OperatorConfig, error) {
+// OverrideImages returns the images with the given overrides applied. Overrides are a JSON object keyed the same
+// way as the images ConfigMap, e.g. {"cloudControllerManagerAWS": "quay.io/dev/aws-cloud-controller-manager:test"}.
+// Unknown or empty images are rejected, so a typo does not silently deploy the stock image.
+func OverrideImages(images ImagesReference, overrides string) (ImagesReference, error) {
+	var values map[string]string
+	if err := json.Unmarshal([]byte(overrides), &values); err != nil {
+		return ImagesReference{}, fmt.Errorf("invalid image overrides: %w", err)
+	}
+	for name, image := range values {
+		if image == "" {
+			return ImagesReference{}, fmt.Errorf("invalid image overrides: image %q is empty", name)
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(overrides))
+	decoder.DisallowUnknownFields()
+	overridden := images
+	if err := decoder.Decode(&overridden); err != nil {
+		return ImagesReference{}, fmt.Errorf("invalid image overrides: %w", err)
+	}
+	return overridden, nil
+}
+
+// ComposeConfig creates a Config for operator. The feature gates are resolved from the given FeatureGate object,
+// which may be nil, when the feature gate accessor can not provide them, see currentFeatureGates.
+func ComposeConfig(infrastructure *configv1.Infrastructure, clusterProxy *configv1.Proxy, imagesFile, managedNamespace, releaseVersion string, featureGateAccessor featuregates.FeatureGateAccess, featureGate *configv1.FeatureGate) (OperatorConfig, error) {
 	err := checkInfrastructureResource(infrastructure)
 	if err != nil {
 		klog.Errorf("Unable to get platform from infrastructure: %s", err)
@@ -101,21 +184,47 @@ func ComposeConfig(infrastructure *configv1.Infrastructure, clusterProxy *config
 
 	var features featuregates.FeatureGate
 	if featureGateAccessor != nil {
-		features, _ = featureGateAccessor.CurrentFeatureGates()
-		enabled, _ := util.GetEnabledDisabledFeatures(features, upstreamGates)
-		featureGatesString = util.BuildFeatureGateString(enabled, nil)
+		features, err = currentFeatureGates(featureGateAccessor, featureGate, releaseVersion, infrastructure.Status.ControlPlaneTopology)
+		if err != nil {
+			klog.Errorf("Unable to get feature gates: %s", err)
+			return OperatorConfig{}, fmt.Errorf("unable to get feature gates: %w", err)
+		}
+		// Disabled gates are passed too, so the cloud controller managers do not fall back to the upstream
+		// defaults of gates the cluster turned off.
+		enabled, disabled := util.GetEnabledDisabledFeatures(features, upstreamGates)
+		featureGatesString = util.BuildFeatureGateString(enabled, disabled)
 	}
 
 	config := OperatorConfig{
-		PlatformStatus:     infrastructure.Status.PlatformStatus.DeepCopy(),
-		ClusterProxy:       clusterProxy,
-		ManagedNamespace:   managedNamespace,
-		ImagesReference:    images,
-		InfrastructureName: infrastructure.Status.InfrastructureName,
-		IsSingleReplica:    infrastructure.Status.ControlPlaneTopology == configv1.SingleReplicaTopologyMode,
-		FeatureGates:       featureGatesString,
-		OCPFeatureGates:    features,
+		PlatformStatus:       infrastructure.Status.PlatformStatus.DeepCopy(),
+		ExternalPlatformName: GetExternalPlatformName(infrastructure),
+		ClusterProxy:         clusterProxy,
+		ManagedNamespace:     managedNamespace,
+		ImagesReference:      images,
+		InfrastructureName:   infrastructure.Status.InfrastructureName,
+		APIServerURL:         infrastructure.Status.APIServerURL,
+		APIServerInternalURL: infrastructure.Status.APIServerInternalURL,
+		ControlPlaneTopology: infrastructure.Status.ControlPlaneTopology,
+		FeatureGates:         featureGatesString,
+		OCPFeatureGates:      features,
 	}
 
 	return config, nil
 }
+
+// currentFeatureGates returns the feature gates provided by the accessor. When it has not observed them yet, or fails
+// to read them, they are resolved from the FeatureGate object instead, either from its status or from its featureSet,
+// for the cluster profile matching the control plane topology.
+func currentFeatureGates(featureGateAccessor featuregates.FeatureGateAccess, featureGate *configv1.FeatureGate, releaseVersion string, topology configv1.TopologyMode) (featuregates.FeatureGate, error) {
+	current, err := featureGateAccessor.CurrentFeatureGates()
+	if err == nil || featureGate == nil {
+		return current, err
+	}
+
+	clusterProfile := features.SelfManaged
+	if topology == configv1.ExternalTopologyMode {
+		clusterProfile = features.Hypershift
+	}
+	klog.V(2).Infof("Feature gates are not available from the accessor (%v), resolving them from featuregate %q", err, featureGate.Name)
+	return util.FeatureGatesFromObject(featureGate, releaseVersion, clusterProfile)
+}
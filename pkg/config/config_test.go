@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"testing"
 
@@ -105,6 +106,55 @@ func TestGetImagesFromJSONFile(t *testing.T) {
 	}
 }
 
+func TestOverrideImages(t *testing.T) {
+	images := ImagesReference{
+		CloudControllerManagerOperator: "registry.ci.openshift.org/openshift:cluster-cloud-controller-manager-operator",
+		CloudControllerManagerAWS:      "registry.ci.openshift.org/openshift:aws-cloud-controller-manager",
+	}
+
+	tc := []struct {
+		name           string
+		overrides      string
+		expectedImages ImagesReference
+		expectError    string
+	}{{
+		name:      "Override an image",
+		overrides: `{"cloudControllerManagerAWS": "quay.io/dev/aws-cloud-controller-manager:test"}`,
+		expectedImages: ImagesReference{
+			CloudControllerManagerOperator: "registry.ci.openshift.org/openshift:cluster-cloud-controller-manager-operator",
+			CloudControllerManagerAWS:      "quay.io/dev/aws-cloud-controller-manager:test",
+		},
+	}, {
+		name:           "No overrides",
+		overrides:      `{}`,
+		expectedImages: images,
+	}, {
+		name:        "Unknown image name is rejected",
+		overrides:   `{"cloudControllerManagerAWZ": "quay.io/dev/aws-cloud-controller-manager:test"}`,
+		expectError: `invalid image overrides: json: unknown field "cloudControllerManagerAWZ"`,
+	}, {
+		name:        "Empty image is rejected",
+		overrides:   `{"cloudControllerManagerAWS": ""}`,
+		expectError: `invalid image overrides: image "cloudControllerManagerAWS" is empty`,
+	}, {
+		name:        "Broken JSON is rejected",
+		overrides:   `cloudControllerManagerAWS=quay.io/dev/aws-cloud-controller-manager:test`,
+		expectError: "invalid image overrides: invalid character 'c' looking for beginning of value",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			overridden, err := OverrideImages(images, tc.overrides)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedImages, overridden)
+		})
+	}
+}
+
 func TestCheckInfrastructure(t *testing.T) {
 	tc := []struct {
 		name      string
@@ -175,6 +225,7 @@ func TestComposeConfig(t *testing.T) {
 		expectConfig  OperatorConfig
 		expectError   string
 		featureGates  featuregates.FeatureGateAccess
+		featureGate   *configv1.FeatureGate
 	}{{
 		name:      "Unmarshal images from file",
 		namespace: defaultManagementNamespace,
@@ -221,10 +272,10 @@ func TestComposeConfig(t *testing.T) {
 			[]configv1.FeatureGateName{"ChocobombBlueberry", "ChocobombBanana"},
 		),
 		expectConfig: OperatorConfig{
-			ManagedNamespace: defaultManagementNamespace,
-			ImagesReference:  defaultImagesReference,
-			PlatformStatus:   &configv1.PlatformStatus{Type: configv1.OpenStackPlatformType},
-			IsSingleReplica:  true,
+			ManagedNamespace:     defaultManagementNamespace,
+			ImagesReference:      defaultImagesReference,
+			PlatformStatus:       &configv1.PlatformStatus{Type: configv1.OpenStackPlatformType},
+			ControlPlaneTopology: configv1.SingleReplicaTopologyMode,
 			// We only see CloudControllerManagerWebhook returned here because kubernetes defines
 			// white-listed features that are allowed to be used by cloud providers. Anything that
 			// is not defined there won't be passed to the cloud provider.
@@ -240,6 +291,89 @@ func TestComposeConfig(t *testing.T) {
 				[]configv1.FeatureGateName{"ChocobombBlueberry", "ChocobombBanana"},
 			),
 		},
+	}, {
+		name:      "Feature gates are resolved from the FeatureGate when not yet observed",
+		namespace: defaultManagementNamespace,
+		infra: &configv1.Infrastructure{
+			Status: configv1.InfrastructureStatus{
+				PlatformStatus: &configv1.PlatformStatus{
+					Type: configv1.OpenStackPlatformType,
+				},
+			},
+		},
+		featureGates: featuregates.NewHardcodedFeatureGateAccessForTesting(nil, nil, make(chan struct{}), errors.New("featureGates not yet observed")),
+		featureGate: &configv1.FeatureGate{
+			Status: configv1.FeatureGateStatus{
+				FeatureGates: []configv1.FeatureGateDetails{{
+					Version:  "4.99.0",
+					Enabled:  []configv1.FeatureGateAttributes{{Name: "CloudControllerManagerWebhook"}},
+					Disabled: []configv1.FeatureGateAttributes{{Name: "ChocobombBanana"}},
+				}},
+			},
+		},
+		expectConfig: OperatorConfig{
+			ManagedNamespace: defaultManagementNamespace,
+			ImagesReference:  defaultImagesReference,
+			PlatformStatus:   &configv1.PlatformStatus{Type: configv1.OpenStackPlatformType},
+			FeatureGates:     "CloudControllerManagerWebhook=true",
+			OCPFeatureGates: featuregates.NewFeatureGate(
+				[]configv1.FeatureGateName{"CloudControllerManagerWebhook"},
+				[]configv1.FeatureGateName{"ChocobombBanana"},
+			),
+		},
+	}, {
+		name:      "Feature gates not yet observed without a FeatureGate should return error",
+		namespace: defaultManagementNamespace,
+		infra: &configv1.Infrastructure{
+			Status: configv1.InfrastructureStatus{
+				PlatformStatus: &configv1.PlatformStatus{
+					Type: configv1.OpenStackPlatformType,
+				},
+			},
+		},
+		featureGates: featuregates.NewHardcodedFeatureGateAccessForTesting(nil, nil, make(chan struct{}), errors.New("featureGates not yet observed")),
+		expectError:  "unable to get feature gates: featureGates not yet observed",
+	}, {
+		name:      "API server endpoints",
+		namespace: defaultManagementNamespace,
+		infra: &configv1.Infrastructure{
+			Status: configv1.InfrastructureStatus{
+				APIServerURL:         "https://api.my-cluster.example.com:6443",
+				APIServerInternalURL: "https://api-int.my-cluster.example.com:6443",
+				PlatformStatus: &configv1.PlatformStatus{
+					Type: configv1.AWSPlatformType,
+				},
+			},
+		},
+		expectConfig: OperatorConfig{
+			ManagedNamespace:     defaultManagementNamespace,
+			ImagesReference:      defaultImagesReference,
+			PlatformStatus:       &configv1.PlatformStatus{Type: configv1.AWSPlatformType},
+			APIServerURL:         "https://api.my-cluster.example.com:6443",
+			APIServerInternalURL: "https://api-int.my-cluster.example.com:6443",
+		},
+	}, {
+		name:      "External platform name",
+		namespace: defaultManagementNamespace,
+		infra: &configv1.Infrastructure{
+			Spec: configv1.InfrastructureSpec{
+				PlatformSpec: configv1.PlatformSpec{
+					Type:     configv1.ExternalPlatformType,
+					External: &configv1.ExternalPlatformSpec{PlatformName: "oci"},
+				},
+			},
+			Status: configv1.InfrastructureStatus{
+				PlatformStatus: &configv1.PlatformStatus{
+					Type: configv1.ExternalPlatformType,
+				},
+			},
+		},
+		expectConfig: OperatorConfig{
+			ManagedNamespace:     defaultManagementNamespace,
+			ImagesReference:      defaultImagesReference,
+			PlatformStatus:       &configv1.PlatformStatus{Type: configv1.ExternalPlatformType},
+			ExternalPlatformName: "oci",
+		},
 	}, {
 		name:        "Empty infrastructure should return error",
 		expectError: "platform status is not populated on infrastructure",
@@ -295,7 +429,7 @@ func TestComposeConfig(t *testing.T) {
 			_, err = file.WriteString(tc.imagesContent)
 			assert.NoError(t, err)
 
-			config, err := ComposeConfig(tc.infra, tc.clusterProxy, path, tc.namespace, tc.featureGates)
+			config, err := ComposeConfig(tc.infra, tc.clusterProxy, path, tc.namespace, "4.99.0", tc.featureGates, tc.featureGate)
 			if tc.expectError != "" {
 				assert.EqualError(t, err, tc.expectError)
 			} else {
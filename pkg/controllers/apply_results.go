@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// applyResultsConfigMapName is the name of the ConfigMap in the managed namespace which holds
+	// the outcome of the most recent operands apply, so it can be inspected without going through logs.
+	applyResultsConfigMapName = "cloud-controller-manager-operator-apply-results"
+	applyResultsKey           = "results.yaml"
+)
+
+type applyOutcome string
+
+const (
+	applyOutcomeCreated   applyOutcome = "Created"
+	applyOutcomeUpdated   applyOutcome = "Updated"
+	applyOutcomeUnchanged applyOutcome = "Unchanged"
+	applyOutcomeFailed    applyOutcome = "Failed"
+)
+
+// applyResult describes what happened to a single resource during apply.
+type applyResult struct {
+	Kind      string       `json:"kind"`
+	Namespace string       `json:"namespace,omitempty"`
+	Name      string       `json:"name"`
+	Outcome   applyOutcome `json:"outcome"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// applyResults is the content stored in the apply results ConfigMap.
+type applyResults struct {
+	Time           metav1.Time   `json:"time"`
+	ReleaseVersion string        `json:"releaseVersion"`
+	Resources      []applyResult `json:"resources"`
+}
+
+func newApplyResult(resource client.Object, outcome applyOutcome, err error) applyResult {
+	result := applyResult{
+		Kind:      resource.GetObjectKind().GroupVersionKind().Kind,
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+		Outcome:   outcome,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// resourceExists checks whether the resource is already present in the cluster, to tell
+// apart created resources from updated ones.
+func (r *CloudOperatorReconciler) resourceExists(ctx context.Context, resource client.Object) bool {
+	existing, ok := resource.DeepCopyObject().(client.Object)
+	if !ok {
+		return true
+	}
+	return !errors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(resource), existing))
+}
+
+// recordApplyResults stores results of the latest apply into the apply results ConfigMap. The ConfigMap is not
+// updated when only the time of the apply changed, since the operator watches the ConfigMaps of the managed
+// namespace and would otherwise be triggered by every recording.
+func (r *CloudOperatorReconciler) recordApplyResults(ctx context.Context, results []applyResult) error {
+	content, err := yaml.Marshal(applyResults{
+		Time:           metav1.NewTime(r.Clock.Now()),
+		ReleaseVersion: r.ReleaseVersion,
+		Resources:      results,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal apply results: %w", err)
+	}
+
+	required := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      applyResultsConfigMapName,
+			Namespace: r.ManagedNamespace,
+		},
+		Data: map[string]string{applyResultsKey: string(content)},
+	}
+
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(required), existing); errors.IsNotFound(err) {
+		return r.Create(ctx, required)
+	} else if err != nil {
+		return err
+	}
+
+	recorded := applyResults{}
+	if err := yaml.Unmarshal([]byte(existing.Data[applyResultsKey]), &recorded); err == nil &&
+		recorded.ReleaseVersion == r.ReleaseVersion && reflect.DeepEqual(recorded.Resources, results) {
+		return nil
+	}
+
+	existing.Data = required.Data
+	return r.Update(ctx, existing)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRecordApplyResults(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(startTime)
+
+	reconciler := &CloudOperatorReconciler{
+		ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+			Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			Clock:            fakeClock,
+			ManagedNamespace: DefaultManagedNamespace,
+			ReleaseVersion:   "4.99.0",
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "ccm", Namespace: DefaultManagedNamespace},
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: DefaultManagedNamespace},
+	}
+
+	getResults := func(t *testing.T) applyResults {
+		t.Helper()
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Name: applyResultsConfigMapName, Namespace: DefaultManagedNamespace}
+		assert.NoError(t, reconciler.Get(context.TODO(), key, cm))
+		results := applyResults{}
+		assert.NoError(t, yaml.Unmarshal([]byte(cm.Data[applyResultsKey]), &results))
+		return results
+	}
+
+	// Results ConfigMap is created on the first recording
+	assert.NoError(t, reconciler.recordApplyResults(context.TODO(), []applyResult{
+		newApplyResult(deployment, applyOutcomeCreated, nil),
+		newApplyResult(configMap, applyOutcomeFailed, fmt.Errorf("boom")),
+	}))
+	results := getResults(t)
+	assert.Equal(t, "4.99.0", results.ReleaseVersion)
+	assert.True(t, results.Time.Equal(&metav1.Time{Time: startTime}))
+	assert.Equal(t, []applyResult{
+		{Kind: "Deployment", Namespace: DefaultManagedNamespace, Name: "ccm", Outcome: applyOutcomeCreated},
+		{Kind: "ConfigMap", Namespace: DefaultManagedNamespace, Name: "config", Outcome: applyOutcomeFailed, Error: "boom"},
+	}, results.Resources)
+
+	// Results ConfigMap is not updated when only the time of the apply changed
+	getResourceVersion := func(t *testing.T) string {
+		t.Helper()
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Name: applyResultsConfigMapName, Namespace: DefaultManagedNamespace}
+		assert.NoError(t, reconciler.Get(context.TODO(), key, cm))
+		return cm.ResourceVersion
+	}
+	resourceVersion := getResourceVersion(t)
+	fakeClock.SetTime(startTime.Add(time.Second))
+	assert.NoError(t, reconciler.recordApplyResults(context.TODO(), []applyResult{
+		newApplyResult(deployment, applyOutcomeCreated, nil),
+		newApplyResult(configMap, applyOutcomeFailed, fmt.Errorf("boom")),
+	}))
+	assert.Equal(t, resourceVersion, getResourceVersion(t))
+	results = getResults(t)
+	assert.True(t, results.Time.Equal(&metav1.Time{Time: startTime}))
+
+	// Results ConfigMap is overwritten by the subsequent recording
+	fakeClock.SetTime(startTime.Add(time.Minute))
+	assert.NoError(t, reconciler.recordApplyResults(context.TODO(), []applyResult{
+		newApplyResult(deployment, applyOutcomeUnchanged, nil),
+		newApplyResult(configMap, applyOutcomeUpdated, nil),
+	}))
+	results = getResults(t)
+	assert.True(t, results.Time.Equal(&metav1.Time{Time: startTime.Add(time.Minute)}))
+	assert.Equal(t, []applyResult{
+		{Kind: "Deployment", Namespace: DefaultManagedNamespace, Name: "ccm", Outcome: applyOutcomeUnchanged},
+		{Kind: "ConfigMap", Namespace: DefaultManagedNamespace, Name: "config", Outcome: applyOutcomeUpdated},
+	}, results.Resources)
+}
+
+func TestResourceExists(t *testing.T) {
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: DefaultManagedNamespace}}
+	missing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: DefaultManagedNamespace}}
+
+	reconciler := &CloudOperatorReconciler{
+		ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing.DeepCopy()).Build(),
+		},
+	}
+
+	assert.True(t, reconciler.resourceExists(context.TODO(), existing))
+	assert.False(t, reconciler.resourceExists(context.TODO(), missing))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -44,6 +45,7 @@ func NewObjectWatcher(opts WatcherOptions) (ObjectWatcher, error) {
 }
 
 type objectWatcher struct {
+	lock             sync.Mutex
 	objectCache      cache.Cache
 	scheme           *runtime.Scheme
 	eventChan        chan event.GenericEvent
@@ -55,6 +57,9 @@ func (n *objectWatcher) EventStream() <-chan event.GenericEvent {
 }
 
 func (n *objectWatcher) Watch(ctx context.Context, obj client.Object) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	key, err := n.watchKey(obj)
 	if err != nil {
 		return err
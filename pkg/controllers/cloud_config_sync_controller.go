@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -16,9 +17,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azure"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/gcp"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/openstack"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/vsphere"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
 )
 
 const (
@@ -29,21 +37,48 @@ const (
 	// Controller conditions for the Cluster Operator resource
 	cloudConfigControllerAvailableCondition = "CloudConfigControllerAvailable"
 	cloudConfigControllerDegradedCondition  = "CloudConfigControllerDegraded"
+	// cloudConfigControllerPlaintextCredentialsCondition is True while credentials set in clear text in the
+	// source cloud config are removed from the synced one.
+	cloudConfigControllerPlaintextCredentialsCondition = "CloudConfigControllerPlaintextCredentials"
+	// cloudConfigControllerLintCondition is True while the linter of the provider reports likely mistakes in the
+	// synced cloud config.
+	cloudConfigControllerLintCondition = "CloudConfigControllerLintWarnings"
+
+	// disableCloudConfigSyncAnnotation set to "true" on the ClusterOperator stops the controller from syncing the
+	// cloud config, so environments managing the synced ConfigMap externally (e.g. GitOps or hosted control planes)
+	// do not have their changes reverted. The operands are still deployed and mount the ConfigMap as is.
+	disableCloudConfigSyncAnnotation = "ccm.openshift.io/disable-cloud-config-sync"
 )
 
 type CloudConfigReconciler struct {
 	ClusterOperatorStatusClient
 	Scheme            *runtime.Scheme
 	FeatureGateAccess featuregates.FeatureGateAccess
+	// SourceConfigMap overrides the reference to the user cloud config ConfigMap of the Infrastructure resource,
+	// e.g. for topologies which do not keep it in the openshift-config namespace. An empty Namespace defaults
+	// to openshift-config, and an empty Name to the one referenced by the Infrastructure resource.
+	SourceConfigMap client.ObjectKey
 }
 
 func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	klog.V(1).Infof("Syncing cloud-conf ConfigMap")
 
+	syncDisabled, err := r.isSyncDisabled(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check if cloud config sync is disabled: %v", err)
+	}
+	if syncDisabled {
+		klog.Infof("cloud-config sync is disabled, returning early")
+		if err := r.setSyncDisabledCondition(ctx); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	infra := &configv1.Infrastructure{}
 	if err := r.Get(ctx, client.ObjectKey{Name: infrastructureResourceName}, infra); err != nil {
 		klog.Errorf("infrastructure resource not found")
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
 		return ctrl.Result{}, err
@@ -51,15 +86,16 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	network := &configv1.Network{}
 	if err := r.Get(ctx, client.ObjectKey{Name: "cluster"}, network); err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller when getting cluster Network object: %v", err)
 		}
 		return ctrl.Result{}, err
 	}
 
-	syncNeeded, err := r.isCloudConfigSyncNeeded(infra.Status.PlatformStatus, infra.Spec.CloudConfig)
+	externalPlatformName := config.GetExternalPlatformName(infra)
+	syncNeeded, err := r.isCloudConfigSyncNeeded(infra.Status.PlatformStatus, externalPlatformName, infra.Spec.CloudConfig)
 	if err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
 		return ctrl.Result{}, err
@@ -72,10 +108,10 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	cloudConfigTransformerFn, needsManagedConfigLookup, err := cloud.GetCloudConfigTransformer(infra.Status.PlatformStatus)
+	cloudConfigTransformerFn, needsManagedConfigLookup, err := cloud.GetCloudConfigTransformer(infra.Status.PlatformStatus, externalPlatformName)
 	if err != nil {
 		klog.Errorf("unable to get cloud config transformer function; unsupported platform")
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
 		return ctrl.Result{}, err
@@ -84,52 +120,77 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	sourceCM := &corev1.ConfigMap{}
 	managedConfigFound := false
 
-	// NOTE: We know that there is some transformation logic in place in the
-	// Cluster Config Operator (CCO) for AWS and Azure. We have not implemented
-	// this logic here yet so we've intentionally chosen to lookup up config
-	// from the (CCO-) managed namespace **only for these cloud platforms**
-	// TODO: Drop this once we implement the AWS and Azure transformers here in
-	// CCCMO, allowing us to drop this kinda-sorta reliance on CCO stuff. We
-	// may also wish to merge the use of cloudConfigTransformerFn into the
-	// prepareSourceConfigMap helper function
-	if needsManagedConfigLookup {
-		defaultSourceCMObjectKey := client.ObjectKey{
-			Name:      managedCloudConfigMapName,
-			Namespace: OpenshiftManagedConfigNamespace,
-		}
-		if err := r.Get(ctx, defaultSourceCMObjectKey, sourceCM); err == nil {
-			managedConfigFound = true
-		} else if errors.IsNotFound(err) {
+	// Prefer the kube-cloud-config ConfigMap maintained by the Cluster Config
+	// Operator (CCO) when it exists: it is a copy of the user config with the
+	// CA bundle of the cloud already merged in, so we do not duplicate that
+	// merge logic here. On AWS and Azure the CCO also transforms the config,
+	// hence a missing managed config is only expected on other platforms.
+	// TODO: Drop the reliance on the CCO transformations once we implement
+	// the AWS and Azure transformers here in CCCMO. We may also wish to merge
+	// the use of cloudConfigTransformerFn into the prepareSourceConfigMap
+	// helper function
+	defaultSourceCMObjectKey := client.ObjectKey{
+		Name:      managedCloudConfigMapName,
+		Namespace: OpenshiftManagedConfigNamespace,
+	}
+	if err := r.Get(ctx, defaultSourceCMObjectKey, sourceCM); err == nil {
+		managedConfigFound = true
+	} else if errors.IsNotFound(err) {
+		if needsManagedConfigLookup {
 			klog.Warningf("managed cloud-config is not found, falling back to infrastructure config")
-		} else if err != nil {
-			klog.Errorf("unable to get managed cloud-config for sync")
-			if err := r.setDegradedCondition(ctx); err != nil {
-				return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
-			}
-			return ctrl.Result{}, err
+		} else {
+			klog.V(1).Infof("managed cloud-config is not found, falling back to infrastructure config")
 		}
+	} else if err != nil {
+		klog.Errorf("unable to get managed cloud-config for sync")
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+		}
+		return ctrl.Result{}, err
 	}
 
-	// Only look for an unmanaged config if the managed one isn't found and a name was specified.
-	if !managedConfigFound && infra.Spec.CloudConfig.Name != "" {
-		openshiftUnmanagedCMKey := client.ObjectKey{
-			Name:      infra.Spec.CloudConfig.Name,
-			Namespace: OpenshiftConfigNamespace,
-		}
-		if err := r.Get(ctx, openshiftUnmanagedCMKey, sourceCM); errors.IsNotFound(err) {
-			klog.Warningf("managed cloud-config is not found, falling back to default cloud config.")
+	// Look for an unmanaged config if a name was specified. It is the sync source if the managed one isn't found,
+	// otherwise its auxiliary keys are preserved, as some providers split their config across multiple files.
+	if openshiftUnmanagedCMKey := r.sourceConfigMapKey(infra); openshiftUnmanagedCMKey.Name != "" {
+		unmanagedCM := &corev1.ConfigMap{}
+		if err := r.Get(ctx, openshiftUnmanagedCMKey, unmanagedCM); errors.IsNotFound(err) {
+			if !managedConfigFound {
+				klog.Warningf("managed cloud-config is not found, falling back to default cloud config.")
+			}
 		} else if err != nil {
 			klog.Errorf("unable to get cloud-config for sync: %v", err)
-			if err := r.setDegradedCondition(ctx); err != nil {
+			if err := r.setDegradedCondition(ctx, err); err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 			}
 			return ctrl.Result{}, err
+		} else if managedConfigFound {
+			sourceCM = preserveSourceKeys(sourceCM, unmanagedCM, infra.Spec.CloudConfig.Key)
+		} else {
+			sourceCM = unmanagedCM
 		}
 	}
 
+	// A cloud config sourced from a Secret takes precedence over the one of the ConfigMaps, their other keys are kept.
+	secretCloudConf, secretFound, err := r.cloudConfigFromSecret(ctx, infra.Status.PlatformStatus, externalPlatformName)
+	if err != nil {
+		klog.Errorf("unable to get cloud-config from secret: %v", err)
+		if err := r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to read cloud config from secret: %v", err)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+		}
+		return ctrl.Result{}, err
+	}
+	if secretFound {
+		sourceCM = sourceCM.DeepCopy()
+		if sourceCM.Data == nil {
+			sourceCM.Data = map[string]string{}
+		}
+		delete(sourceCM.Data, infra.Spec.CloudConfig.Key)
+		sourceCM.Data[defaultConfigKey] = secretCloudConf
+	}
+
 	sourceCM, err = r.prepareSourceConfigMap(sourceCM, infra)
 	if err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
 		return ctrl.Result{}, err
@@ -138,28 +199,73 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// Check if FeatureGateAccess is configured
 	if r.FeatureGateAccess == nil {
 		klog.Errorf("FeatureGateAccess is not configured")
-		if err := r.setDegradedCondition(ctx); err != nil {
+		err := fmt.Errorf("FeatureGateAccess is not configured")
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
-		return ctrl.Result{}, fmt.Errorf("FeatureGateAccess is not configured")
+		return ctrl.Result{}, err
 	}
 
 	features, err := r.FeatureGateAccess.CurrentFeatureGates()
 	if err != nil {
 		klog.Errorf("unable to get feature gates: %v", err)
-		if errD := r.setDegradedCondition(ctx); errD != nil {
+		if errD := r.setDegradedCondition(ctx, err); errD != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", errD)
 		}
 		return ctrl.Result{}, err
 	}
 
+	if infra.Status.PlatformStatus.Type == configv1.VSpherePlatformType && vsphere.HasInlineCredentials(sourceCM.Data[defaultConfigKey]) {
+		klog.Warningf("vSphere cloud config contains inline credentials, they are not synced")
+		if err := r.recordInlineCredentialsEvent(ctx); err != nil {
+			klog.Errorf("unable to record inline credentials event: %v", err)
+		}
+	}
+
 	if cloudConfigTransformerFn != nil {
 		// We ignore stuff in sourceCM.BinaryData. This isn't allowed to
 		// contain any key that overlaps with those found in sourceCM.Data and
 		// we're not expecting users to put their data in the former.
 		output, err := cloudConfigTransformerFn(sourceCM.Data[defaultConfigKey], infra, network, features)
 		if err != nil {
-			if err := r.setDegradedCondition(ctx); err != nil {
+			klog.Errorf("unable to transform cloud config: %v", err)
+			if err := r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to transform cloud config: %v", err)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+			}
+			return ctrl.Result{}, err
+		}
+		sourceCM.Data[defaultConfigKey] = output
+	}
+
+	if azure.IsAzure(infra) {
+		output, err := r.composeAzureConfig(ctx, sourceCM.Data[defaultConfigKey])
+		if err != nil {
+			klog.Errorf("unable to compose Azure cloud config: %v", err)
+			if err := r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to compose Azure cloud config: %v", err)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+			}
+			return ctrl.Result{}, err
+		}
+		sourceCM.Data[defaultConfigKey] = output
+	}
+
+	if infra.Status.PlatformStatus.Type == configv1.OpenStackPlatformType {
+		output, err := r.composeOpenStackConfig(ctx, sourceCM.Data[defaultConfigKey])
+		if err != nil {
+			klog.Errorf("unable to compose OpenStack cloud config: %v", err)
+			if err := r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to compose OpenStack cloud config: %v", err)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+			}
+			return ctrl.Result{}, err
+		}
+		sourceCM.Data[defaultConfigKey] = output
+	}
+
+	if infra.Status.PlatformStatus.Type == configv1.GCPPlatformType {
+		output, err := r.composeGCPConfig(ctx, sourceCM.Data[defaultConfigKey])
+		if err != nil {
+			klog.Errorf("unable to compose GCP cloud config: %v", err)
+			if err := r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to compose GCP cloud config: %v", err)); err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 			}
 			return ctrl.Result{}, err
@@ -167,6 +273,57 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		sourceCM.Data[defaultConfigKey] = output
 	}
 
+	// Overrides are merged last, so admins can tune any option of the resulting config.
+	output, err := r.mergeCloudConfigOverrides(ctx, sourceCM.Data[defaultConfigKey])
+	if err != nil {
+		klog.Errorf("unable to merge cloud config overrides: %v", err)
+		if err := r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to merge cloud config overrides: %v", err)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+		}
+		return ctrl.Result{}, err
+	}
+	sourceCM.Data[defaultConfigKey] = output
+
+	// Credentials are read by the cloud controller manager from its credentials secret, they are never published.
+	output, credentialKeys, err := cloud.StripPlaintextCredentials(infra.Status.PlatformStatus, externalPlatformName, sourceCM.Data[defaultConfigKey])
+	if err != nil {
+		klog.Errorf("unable to remove plaintext credentials from cloud config: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+		}
+		return ctrl.Result{}, err
+	}
+	if len(credentialKeys) > 0 {
+		klog.Warningf("removed plaintext credentials from cloud config: %s", strings.Join(credentialKeys, ", "))
+	}
+	if err := r.setPlaintextCredentialsCondition(ctx, credentialKeys); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+	}
+	sourceCM.Data[defaultConfigKey] = output
+
+	if err := cloud.ValidateCloudConfig(infra.Status.PlatformStatus, externalPlatformName, sourceCM.Data[defaultConfigKey]); err != nil {
+		klog.Errorf("invalid cloud config: %v", err)
+		if err := r.setInvalidCloudConfigCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Lint findings do not block the sync, they are surfaced with a hint to fix them.
+	findings := cloud.LintCloudConfig(infra.Status.PlatformStatus, externalPlatformName, sourceCM.Data[defaultConfigKey])
+	if len(findings) > 0 {
+		klog.Warningf("cloud config has likely mistakes: %s", strings.Join(findings, "; "))
+	}
+	if err := r.setLintCondition(ctx, findings); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
+	}
+
+	// Write the config under the key the provider assets mount it from, when it differs from the default one.
+	if configKey := cloud.GetCloudConfigKey(infra.Status.PlatformStatus, externalPlatformName); configKey != "" && configKey != defaultConfigKey {
+		sourceCM.Data[configKey] = sourceCM.Data[defaultConfigKey]
+		delete(sourceCM.Data, defaultConfigKey)
+	}
+
 	targetCM := &corev1.ConfigMap{}
 	targetConfigMapKey := client.ObjectKey{
 		Namespace: r.ManagedNamespace,
@@ -176,7 +333,7 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// If the config does not exist, it will be created later, so we can ignore a Not Found error
 	if err := r.Get(ctx, targetConfigMapKey, targetCM); err != nil && !errors.IsNotFound(err) {
 		klog.Errorf("unable to get target cloud-config for sync")
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
 		return ctrl.Result{}, err
@@ -193,7 +350,7 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if err := r.syncCloudConfigData(ctx, sourceCM, targetCM); err != nil {
 		klog.Errorf("unable to sync cloud config")
-		if err := r.setDegradedCondition(ctx); err != nil {
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for cloud config controller: %v", err)
 		}
 		return ctrl.Result{}, err
@@ -206,7 +363,16 @@ func (r *CloudConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
-func (r *CloudConfigReconciler) isCloudConfigSyncNeeded(platformStatus *configv1.PlatformStatus, infraCloudConfigRef configv1.ConfigMapFileReference) (bool, error) {
+// isSyncDisabled returns true when the sync was disabled via the disableCloudConfigSyncAnnotation on the ClusterOperator.
+func (r *CloudConfigReconciler) isSyncDisabled(ctx context.Context) (bool, error) {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return false, err
+	}
+	return co.GetAnnotations()[disableCloudConfigSyncAnnotation] == "true", nil
+}
+
+func (r *CloudConfigReconciler) isCloudConfigSyncNeeded(platformStatus *configv1.PlatformStatus, externalPlatformName string, infraCloudConfigRef configv1.ConfigMapFileReference) (bool, error) {
 	if platformStatus == nil {
 		return false, fmt.Errorf("platformStatus is required")
 	}
@@ -220,6 +386,8 @@ func (r *CloudConfigReconciler) isCloudConfigSyncNeeded(platformStatus *configv1
 		configv1.OpenStackPlatformType,
 		configv1.NutanixPlatformType:
 		return true, nil
+	case configv1.ExternalPlatformType:
+		return cloud.IsExternalCloudConfigSyncNeeded(externalPlatformName), nil
 	default:
 		return false, nil
 	}
@@ -238,32 +406,197 @@ func (r *CloudConfigReconciler) prepareSourceConfigMap(source *corev1.ConfigMap,
 
 	// Keys might be different between openshift-config/cloud-config and openshift-config-managed/kube-cloud-config
 	// Always use "cloud.conf" which is default one across openshift
-	if _, ok := cloudConfCm.Data[defaultConfigKey]; ok {
-		return cloudConfCm, nil
-	} else {
-		// Make an entry for the default key even if it didn't exist.
-		cloudConfCm.Data[defaultConfigKey] = ""
-	}
-
-	// If a user provides their own cloud config...
 	infraConfigKey := infra.Spec.CloudConfig.Key
-	if infraConfigKey != "" {
+
+	// If a user provides their own cloud config under the key selected in the infrastructure, it takes precedence
+	// over a "cloud.conf" key they might have along, copy it over into the default key.
+	if infraConfigKey != "" && infraConfigKey != defaultConfigKey {
 		if val, ok := cloudConfCm.Data[infraConfigKey]; ok {
-			// ..., copy that over into the default key.
 			cloudConfCm.Data[defaultConfigKey] = val
 			delete(cloudConfCm.Data, infraConfigKey)
 			return cloudConfCm, nil
-		} else if !ok {
-			// Return an error if they provided a non-existent one and there was a cloud.conf specified.
-			return nil, fmt.Errorf("key %s specified in infra resource does not exist in source configmap %s",
-				infraConfigKey, client.ObjectKeyFromObject(source),
-			)
 		}
 	}
 
+	if _, ok := cloudConfCm.Data[defaultConfigKey]; ok {
+		return cloudConfCm, nil
+	}
+
+	// Return an error if they provided a non-existent one and there was a cloud.conf specified.
+	if infraConfigKey != "" {
+		return nil, fmt.Errorf("key %s specified in infra resource does not exist in source configmap %s",
+			infraConfigKey, client.ObjectKeyFromObject(source),
+		)
+	}
+
+	// Make an entry for the default key even if it didn't exist.
+	cloudConfCm.Data[defaultConfigKey] = ""
 	return cloudConfCm, nil
 }
 
+// sourceConfigMapKey returns the key of the user cloud config ConfigMap, as referenced by the Infrastructure resource
+// unless overridden by the SourceConfigMap of the reconciler. The name is empty if no ConfigMap is referenced.
+func (r *CloudConfigReconciler) sourceConfigMapKey(infra *configv1.Infrastructure) client.ObjectKey {
+	key := client.ObjectKey{
+		Name:      infra.Spec.CloudConfig.Name,
+		Namespace: OpenshiftConfigNamespace,
+	}
+	if r.SourceConfigMap.Name != "" {
+		key.Name = r.SourceConfigMap.Name
+	}
+	if r.SourceConfigMap.Namespace != "" {
+		key.Namespace = r.SourceConfigMap.Namespace
+	}
+	return key
+}
+
+// cloudConfigFromSecret returns the cloud.conf of the user-facing Secret in the openshift-config namespace, without
+// the keys the provider declares as sensitive, so it is never published with secret material.
+// It returns false if the Secret does not exist.
+func (r *CloudConfigReconciler) cloudConfigFromSecret(ctx context.Context, platformStatus *configv1.PlatformStatus, externalPlatformName string) (string, bool, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{
+		Name:      cloudConfigSecretName,
+		Namespace: OpenshiftConfigNamespace,
+	}
+	if err := r.Get(ctx, secretKey, secret); errors.IsNotFound(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	cloudConf, ok := secret.Data[defaultConfigKey]
+	if !ok {
+		return "", false, fmt.Errorf("key %s does not exist in source secret %s", defaultConfigKey, secretKey)
+	}
+	stripped, err := cloud.StripCloudConfigSecrets(platformStatus, externalPlatformName, string(cloudConf))
+	if err != nil {
+		return "", false, err
+	}
+	return stripped, true, nil
+}
+
+// preserveSourceKeys returns a copy of the managed ConfigMap with the keys of the unmanaged one it lacks, so
+// auxiliary files of the user config (e.g. the Azure Stack Hub endpoints) can be mounted by the operands.
+// The cloud config itself is only taken from the managed ConfigMap, hence the infra key is skipped.
+func preserveSourceKeys(managed, unmanaged *corev1.ConfigMap, infraConfigKey string) *corev1.ConfigMap {
+	merged := managed.DeepCopy()
+	for key, value := range unmanaged.Data {
+		if key == infraConfigKey || key == defaultConfigKey {
+			continue
+		}
+		if _, ok := merged.Data[key]; ok {
+			continue
+		}
+		if _, ok := merged.BinaryData[key]; ok {
+			continue
+		}
+		if merged.Data == nil {
+			merged.Data = map[string]string{}
+		}
+		merged.Data[key] = value
+	}
+	for key, value := range unmanaged.BinaryData {
+		if _, ok := merged.Data[key]; ok {
+			continue
+		}
+		if _, ok := merged.BinaryData[key]; ok {
+			continue
+		}
+		if merged.BinaryData == nil {
+			merged.BinaryData = map[string][]byte{}
+		}
+		merged.BinaryData[key] = value
+	}
+	return merged
+}
+
+// composeAzureConfig sets the workload identity options into the given Azure cloud.conf when the credentials
+// secret minted for the cloud controller manager is in the workload identity mode.
+func (r *CloudConfigReconciler) composeAzureConfig(ctx context.Context, cloudConf string) (string, error) {
+	credentialsSecret := &corev1.Secret{}
+	credentialsSecretKey := client.ObjectKey{
+		Name:      azure.CredentialsSecretName,
+		Namespace: r.ManagedNamespace,
+	}
+	if err := r.Get(ctx, credentialsSecretKey, credentialsSecret); errors.IsNotFound(err) {
+		// The secret might not be minted yet, the credentials injector of the CCM sets the options meanwhile.
+		klog.Warningf("%s secret is not found, skipping workload identity options", credentialsSecretKey)
+		return cloudConf, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return azure.SetWorkloadIdentityOptions(cloudConf, credentialsSecret.Data)
+}
+
+// composeOpenStackConfig sets the options from the clouds.yaml credentials secret and merges the Octavia
+// options from the user-facing ConfigMap, if any, into the given OpenStack cloud.conf.
+func (r *CloudConfigReconciler) composeOpenStackConfig(ctx context.Context, cloudConf string) (string, error) {
+	credentialsSecret := &corev1.Secret{}
+	credentialsSecretKey := client.ObjectKey{
+		Name:      openstack.CloudsYAMLSecretName,
+		Namespace: r.ManagedNamespace,
+	}
+	if err := r.Get(ctx, credentialsSecretKey, credentialsSecret); errors.IsNotFound(err) {
+		// The secret might not be minted yet, the CCM reads the clouds.yaml on its own meanwhile.
+		klog.Warningf("%s secret is not found, skipping clouds.yaml options", credentialsSecretKey)
+	} else if err != nil {
+		return "", err
+	} else if cloudsYAML, ok := credentialsSecret.Data[openstack.CloudsYAMLSecretKey]; ok {
+		cloudConf, err = openstack.SetCloudsYAMLOptions(cloudConf, cloudsYAML)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	octaviaCM := &corev1.ConfigMap{}
+	octaviaCMKey := client.ObjectKey{
+		Name:      openstackOctaviaConfigMapName,
+		Namespace: OpenshiftConfigNamespace,
+	}
+	if err := r.Get(ctx, octaviaCMKey, octaviaCM); errors.IsNotFound(err) {
+		return cloudConf, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return openstack.MergeOctaviaOptions(cloudConf, octaviaCM.Data)
+}
+
+// composeGCPConfig merges the Shared VPC options from the user-facing ConfigMap, if any, into the given GCP cloud.conf.
+func (r *CloudConfigReconciler) composeGCPConfig(ctx context.Context, cloudConf string) (string, error) {
+	sharedVPCCM := &corev1.ConfigMap{}
+	sharedVPCCMKey := client.ObjectKey{
+		Name:      gcpSharedVPCConfigMapName,
+		Namespace: OpenshiftConfigNamespace,
+	}
+	if err := r.Get(ctx, sharedVPCCMKey, sharedVPCCM); errors.IsNotFound(err) {
+		return cloudConf, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return gcp.MergeSharedVPCOptions(cloudConf, sharedVPCCM.Data)
+}
+
+// mergeCloudConfigOverrides deep-merges the partial cloud config from the user-facing overrides ConfigMap, if any,
+// on top of the given cloud.conf.
+func (r *CloudConfigReconciler) mergeCloudConfigOverrides(ctx context.Context, cloudConf string) (string, error) {
+	overridesCM := &corev1.ConfigMap{}
+	overridesCMKey := client.ObjectKey{
+		Name:      cloudConfigOverridesConfigMapName,
+		Namespace: OpenshiftConfigNamespace,
+	}
+	if err := r.Get(ctx, overridesCMKey, overridesCM); errors.IsNotFound(err) {
+		return cloudConf, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return common.MergeCloudConfigOverrides(cloudConf, overridesCM.Data[defaultConfigKey])
+}
+
 func (r *CloudConfigReconciler) isCloudConfigEqual(source *corev1.ConfigMap, target *corev1.ConfigMap) bool {
 	return source.Immutable == target.Immutable &&
 		reflect.DeepEqual(source.Data, target.Data) && reflect.DeepEqual(source.BinaryData, target.BinaryData)
@@ -297,7 +630,7 @@ func (r *CloudConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			builder.WithPredicates(
 				predicate.Or(
 					ownCloudConfigPredicate(r.ManagedNamespace),
-					openshiftCloudConfigMapPredicates(),
+					openshiftCloudConfigMapPredicates(r.SourceConfigMap.Namespace),
 				),
 			),
 		).
@@ -309,6 +642,22 @@ func (r *CloudConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&configv1.Network{},
 			handler.EnqueueRequestsFromMapFunc(toManagedConfigMap),
+		).
+		Watches(
+			&configv1.ClusterOperator{},
+			handler.EnqueueRequestsFromMapFunc(toManagedConfigMap),
+			builder.WithPredicates(clusterOperatorAnnotationPredicates(disableCloudConfigSyncAnnotation)),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(toManagedConfigMap),
+			builder.WithPredicates(
+				predicate.Or(
+					openstackCredentialsSecretPredicates(r.ManagedNamespace),
+					azureCredentialsSecretPredicates(r.ManagedNamespace),
+					cloudConfigSecretPredicates(),
+				),
+			),
 		)
 
 	return build.Complete(r)
@@ -332,17 +681,120 @@ func (r *CloudConfigReconciler) setAvailableCondition(ctx context.Context) error
 	return r.syncStatus(ctx, co, conds, nil)
 }
 
-func (r *CloudConfigReconciler) setDegradedCondition(ctx context.Context) error {
+// setSyncDisabledCondition reports the controller as available while the sync is disabled, so the operator keeps
+// deploying the operands with the externally managed cloud config.
+func (r *CloudConfigReconciler) setSyncDisabledCondition(ctx context.Context) error {
 	co, err := r.getOrCreateClusterOperator(ctx)
 	if err != nil {
 		return err
 	}
 
+	message := fmt.Sprintf("Cloud Config Controller sync is disabled by the %s annotation", disableCloudConfigSyncAnnotation)
+	conds := []configv1.ClusterOperatorStatusCondition{
+		newClusterOperatorStatusCondition(cloudConfigControllerAvailableCondition, configv1.ConditionTrue, ReasonSyncDisabled, message),
+		newClusterOperatorStatusCondition(cloudConfigControllerDegradedCondition, configv1.ConditionFalse, ReasonSyncDisabled, message),
+	}
+
+	co.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: r.ReleaseVersion}}
+	klog.V(1).Info("Cloud Config Controller sync is disabled")
+	return r.syncStatus(ctx, co, conds, nil)
+}
+
+// setDegradedCondition sets the controller conditions to degraded with the error which made the sync fail.
+func (r *CloudConfigReconciler) setDegradedCondition(ctx context.Context, syncErr error) error {
+	return r.setDegradedConditionWithMessage(ctx, fmt.Sprintf("Cloud Config Controller failed to sync cloud config: %v", syncErr))
+}
+
+// setInvalidCloudConfigCondition sets the controller conditions to degraded with the violation found in the
+// cloud config, and records it as an event, so the config is fixed before the cloud controller manager consumes it.
+func (r *CloudConfigReconciler) setInvalidCloudConfigCondition(ctx context.Context, violation error) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Cloud Config Controller found an invalid cloud config: %v", violation)
+	r.Recorder.Event(co, corev1.EventTypeWarning, "InvalidCloudConfig", message)
+	return r.syncDegradedCondition(ctx, co, message)
+}
+
+// setPlaintextCredentialsCondition reports whether credentials set in clear text in the source cloud config were
+// removed from the synced one, and records a warning event when they were, so the admin moves them to the
+// credentials secret the cloud controller manager reads them from.
+func (r *CloudConfigReconciler) setPlaintextCredentialsCondition(ctx context.Context, credentialKeys []string) error {
+	var warning string
+	if len(credentialKeys) > 0 {
+		warning = fmt.Sprintf("Cloud config contains plaintext credentials (%s), they are not synced: "+
+			"store them in the credentials secret of the cloud controller manager instead", strings.Join(credentialKeys, ", "))
+	}
+	return r.setWarningCondition(ctx, cloudConfigControllerPlaintextCredentialsCondition, ReasonCredentialsRemoved,
+		"Cloud config does not contain plaintext credentials", warning)
+}
+
+// setLintCondition reports the findings of the linter of the provider on the synced cloud config, and records a
+// warning event when there are some, so likely mistakes are fixed before they make the cloud controller manager fail.
+func (r *CloudConfigReconciler) setLintCondition(ctx context.Context, findings []string) error {
+	var warning string
+	if len(findings) > 0 {
+		warning = fmt.Sprintf("Cloud config has likely mistakes: %s", strings.Join(findings, "; "))
+	}
+	return r.setWarningCondition(ctx, cloudConfigControllerLintCondition, ReasonCloudConfigLint,
+		"Cloud config has no likely mistakes", warning)
+}
+
+// setWarningCondition sets the given condition to True with the warning, or to False when the warning is empty.
+// The cluster operator is only updated, and the warning recorded as an event, when the condition changes.
+func (r *CloudConfigReconciler) setWarningCondition(ctx context.Context, conditionType configv1.ClusterStatusConditionType, reason, okMessage, warning string) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	cond := newClusterOperatorStatusCondition(conditionType, configv1.ConditionFalse, ReasonAsExpected, okMessage)
+	if warning != "" {
+		cond = newClusterOperatorStatusCondition(conditionType, configv1.ConditionTrue, reason, warning)
+	}
+
+	if existing := v1helpers.FindStatusCondition(co.Status.Conditions, cond.Type); existing != nil &&
+		existing.Status == cond.Status && existing.Message == cond.Message {
+		return nil
+	}
+	if warning != "" {
+		r.Recorder.Event(co, corev1.EventTypeWarning, reason, warning)
+	}
+	return r.syncStatus(ctx, co, []configv1.ClusterOperatorStatusCondition{cond}, nil)
+}
+
+// recordInlineCredentialsEvent warns that the vSphere credentials set in clear text in the source cloud config
+// are replaced with a reference to the credentials secret in the synced cloud config.
+func (r *CloudConfigReconciler) recordInlineCredentialsEvent(ctx context.Context) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.Recorder.Eventf(co, corev1.EventTypeWarning, "InlineCredentialsRemoved",
+		"vSphere cloud config contains inline credentials, they are replaced with a reference to the credentials secret in the synced cloud config")
+	return nil
+}
+
+// setDegradedConditionWithMessage sets the controller conditions to degraded with the given message, which is
+// surfaced by the operator when it refuses to provision the CCM, and records it as an event, so sync failures are
+// visible without reading the controller logs.
+func (r *CloudConfigReconciler) setDegradedConditionWithMessage(ctx context.Context, message string) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.Recorder.Event(co, corev1.EventTypeWarning, "CloudConfigSyncFailed", message)
+	return r.syncDegradedCondition(ctx, co, message)
+}
+
+func (r *CloudConfigReconciler) syncDegradedCondition(ctx context.Context, co *configv1.ClusterOperator, message string) error {
 	conds := []configv1.ClusterOperatorStatusCondition{
-		newClusterOperatorStatusCondition(cloudConfigControllerAvailableCondition, configv1.ConditionFalse, ReasonSyncFailed,
-			"Cloud Config Controller failed to sync cloud config"),
-		newClusterOperatorStatusCondition(cloudConfigControllerDegradedCondition, configv1.ConditionTrue, ReasonSyncFailed,
-			"Cloud Config Controller failed to sync cloud config"),
+		newClusterOperatorStatusCondition(cloudConfigControllerAvailableCondition, configv1.ConditionFalse, ReasonSyncFailed, message),
+		newClusterOperatorStatusCondition(cloudConfigControllerDegradedCondition, configv1.ConditionTrue, ReasonSyncFailed, message),
 	}
 
 	co.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: r.ReleaseVersion}}
@@ -22,7 +22,11 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azure"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/openstack"
 )
 
 const (
@@ -166,11 +170,42 @@ var _ = Describe("prepareSourceConfigMap reconciler method", func() {
 		Expect(ok).Should(BeTrue())
 		Expect(len(preparedConfig.Data)).Should(BeEquivalentTo(2))
 	})
+
+	It("config preparation should prefer the key selected in the infra resource over the default one", func() {
+		extendedInfraConfig := infraCloudConfig.DeepCopy()
+		extendedInfraConfig.Data[defaultConfigKey] = "stale"
+		preparedConfig, err := reconciler.prepareSourceConfigMap(extendedInfraConfig, infra)
+		Expect(err).Should(Succeed())
+		Expect(preparedConfig.Data).Should(Equal(map[string]string{defaultConfigKey: defaultAzureConfig}))
+	})
+
+	It("config preparation should fail if the key selected in the infra resource does not exist", func() {
+		_, err := reconciler.prepareSourceConfigMap(&corev1.ConfigMap{Data: map[string]string{"bar": "baz"}}, infra)
+		Expect(err).Should(MatchError(ContainSubstring("key foo specified in infra resource does not exist")))
+	})
 })
 
-var _ = Describe("Cloud config sync controller", func() {
-	var rec *record.FakeRecorder
+var _ = Describe("preserveSourceKeys function", func() {
+	It("should add the auxiliary keys of the unmanaged config", func() {
+		managed := makeManagedCloudConfig(configv1.AzurePlatformType)
+		managed.Data[cloudProviderConfigCABundleConfigMapKey] = "managed pem"
+		unmanaged := makeInfraCloudConfig(configv1.AzurePlatformType)
+		unmanaged.Data[cloudProviderConfigCABundleConfigMapKey] = "unmanaged pem"
+		unmanaged.Data["endpoints"] = "some endpoints"
+		unmanaged.BinaryData = map[string][]byte{"cert": []byte("cert")}
+
+		merged := preserveSourceKeys(managed, unmanaged, infraCloudConfKey)
+		Expect(merged.Data).Should(Equal(map[string]string{
+			defaultConfigKey:                        defaultAzureConfig,
+			cloudProviderConfigCABundleConfigMapKey: "managed pem",
+			"endpoints":                             "some endpoints",
+		}))
+		Expect(merged.BinaryData).Should(Equal(map[string][]byte{"cert": []byte("cert")}))
+		Expect(managed.Data).ShouldNot(HaveKey("endpoints"))
+	})
+})
 
+var _ = Describe("Cloud config sync controller", func() {
 	var mgr manager.Manager
 	var mgrCtxCancel context.CancelFunc
 	var mgrStopped chan struct{}
@@ -201,7 +236,7 @@ var _ = Describe("Cloud config sync controller", func() {
 		reconciler = &CloudConfigReconciler{
 			ClusterOperatorStatusClient: ClusterOperatorStatusClient{
 				Client:           cl,
-				Recorder:         rec,
+				Recorder:         record.NewFakeRecorder(32),
 				Clock:            clocktesting.NewFakePassiveClock(time.Now()),
 				ManagedNamespace: targetNamespaceName,
 			},
@@ -390,6 +425,19 @@ var _ = Describe("Cloud config sync controller", func() {
 				return len(syncedCloudConfigMap.Data)
 			}).Should(Equal(3))
 		})
+
+		It("auxiliary keys from the infra cloud-config should be synced along the managed config", func() {
+			changedInfraConfig := infraCloudConfig.DeepCopy()
+			changedInfraConfig.Data = map[string]string{infraCloudConfKey: "ignored", "endpoints": "some endpoints"}
+			Expect(cl.Update(ctx, changedInfraConfig)).Should(Succeed())
+
+			Eventually(func(g Gomega) map[string]string {
+				syncedCloudConfigMap := &corev1.ConfigMap{}
+				err := cl.Get(ctx, syncedConfigMapKey, syncedCloudConfigMap)
+				g.Expect(err).NotTo(HaveOccurred())
+				return syncedCloudConfigMap.Data
+			}).Should(Equal(map[string]string{defaultConfigKey: defaultAzureConfig, "endpoints": "some endpoints"}))
+		})
 	})
 })
 
@@ -405,6 +453,7 @@ var _ = Describe("Cloud config sync reconciler", func() {
 			ClusterOperatorStatusClient: ClusterOperatorStatusClient{
 				Client:           cl,
 				Clock:            clocktesting.NewFakePassiveClock(time.Now()),
+				Recorder:         record.NewFakeRecorder(32),
 				ManagedNamespace: targetNamespaceName,
 			},
 			Scheme:            scheme.Scheme,
@@ -522,6 +571,56 @@ var _ = Describe("Cloud config sync reconciler", func() {
 
 		})
 
+		It("should sync from the configured source ConfigMap", func() {
+			reconciler.SourceConfigMap = client.ObjectKey{
+				Namespace: OpenshiftManagedConfigNamespace,
+				Name:      "custom-cloud-config",
+			}
+			customCM := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      "custom-cloud-config",
+				Namespace: OpenshiftManagedConfigNamespace,
+			}, Data: map[string]string{infraCloudConfKey: "[Global]\nDisableSecurityGroupIngress = true\n"}}
+			Expect(cl.Create(ctx, customCM)).To(Succeed())
+
+			infraResource := makeInfrastructureResource(configv1.AWSPlatformType)
+			Expect(cl.Create(ctx, infraResource)).To(Succeed())
+
+			infraResource.Status = makeInfraStatus(infraResource.Spec.PlatformSpec.Type)
+			Expect(cl.Status().Update(ctx, infraResource.DeepCopy())).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Namespace: targetNamespaceName, Name: syncedCloudConfigMapName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(MatchRegexp(`DisableSecurityGroupIngress\s+= true`))
+		})
+
+		It("should not sync plaintext credentials", func() {
+			infraCM := makeInfraCloudConfig(configv1.AWSPlatformType)
+			Expect(cl.Get(ctx, client.ObjectKeyFromObject(infraCM), infraCM)).To(Succeed())
+			infraCM.Data[infraCloudConfKey] = "[Global]\nSecretAccessKey = secret\n"
+			Expect(cl.Update(ctx, infraCM)).To(Succeed())
+
+			infraResource := makeInfrastructureResource(configv1.AWSPlatformType)
+			Expect(cl.Create(ctx, infraResource)).To(Succeed())
+
+			infraResource.Status = makeInfraStatus(infraResource.Spec.PlatformSpec.Type)
+			Expect(cl.Status().Update(ctx, infraResource.DeepCopy())).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Namespace: targetNamespaceName, Name: syncedCloudConfigMapName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).NotTo(ContainSubstring("SecretAccessKey"))
+
+			co := &configv1.ClusterOperator{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: clusterOperatorName}, co)).To(Succeed())
+			Expect(v1helpers.FindStatusCondition(co.Status.Conditions, cloudConfigControllerPlaintextCredentialsCondition)).To(
+				HaveField("Status", configv1.ConditionTrue))
+		})
+
 		It("should continue with reconcile when feature gates are available", func() {
 			reconciler.FeatureGateAccess = featuregates.NewHardcodedFeatureGateAccessForTesting(
 				[]configv1.FeatureGateName{"CloudControllerManagerWebhook", "ChocobombVanilla", "ChocobombStrawberry"},
@@ -564,6 +663,277 @@ var _ = Describe("Cloud config sync reconciler", func() {
 			Expect(len(allCMs.Items)).NotTo(BeZero())
 			Expect(len(allCMs.Items)).To(BeEquivalentTo(1))
 		})
+
+		It("should set workload identity options from the credentials secret", func() {
+			credentialsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      azure.CredentialsSecretName,
+				Namespace: targetNamespaceName,
+			}, Data: map[string][]byte{
+				"azure_client_id":            []byte("client"),
+				"azure_tenant_id":            []byte("tenant"),
+				"azure_federated_token_file": []byte("/var/run/secrets/openshift/serviceaccount/token"),
+			}}
+			Expect(cl.Create(ctx, credentialsSecret)).To(Succeed())
+			defer func() {
+				Expect(cl.Delete(ctx, credentialsSecret)).To(Succeed())
+			}()
+
+			infraResource := makeInfrastructureResource(configv1.AzurePlatformType)
+			Expect(cl.Create(ctx, infraResource)).To(Succeed())
+			infraResource.Status = makeInfraStatus(infraResource.Spec.PlatformSpec.Type)
+			Expect(cl.Status().Update(ctx, infraResource.DeepCopy())).To(Succeed())
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring(`"useFederatedWorkloadIdentityExtension":true`))
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring(`"aadFederatedTokenFile":"/var/run/secrets/openshift/serviceaccount/token"`))
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring(`"tenantId":"tenant"`))
+		})
+	})
+
+	Context("On OpenStack platform", func() {
+		BeforeEach(func() {
+			infraCloudConfig := makeInfraCloudConfig(configv1.OpenStackPlatformType)
+			infraCloudConfig.Data[infraCloudConfKey] = ""
+			Expect(cl.Create(ctx, infraCloudConfig)).To(Succeed())
+
+			infraResource := makeInfrastructureResource(configv1.OpenStackPlatformType)
+			Expect(cl.Create(ctx, infraResource)).To(Succeed())
+			infraResource.Status = makeInfraStatus(infraResource.Spec.PlatformSpec.Type)
+			Expect(cl.Status().Update(ctx, infraResource.DeepCopy())).To(Succeed())
+		})
+
+		It("should merge Octavia options into the synced config", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      openstackOctaviaConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{"lb-provider": "ovn", "create-monitor": "true"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("lb-provider            = ovn"))
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("create-monitor         = true"))
+		})
+
+		It("should set options from the clouds.yaml credentials secret", func() {
+			credentialsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      openstack.CloudsYAMLSecretName,
+				Namespace: targetNamespaceName,
+			}, Data: map[string][]byte{openstack.CloudsYAMLSecretKey: []byte(`clouds:
+  openstack:
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+    region_name: regionOne
+`)}}
+			Expect(cl.Create(ctx, credentialsSecret)).To(Succeed())
+			defer func() {
+				Expect(cl.Delete(ctx, credentialsSecret)).To(Succeed())
+			}()
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("auth-url    = https://keystone.example.com:5000/v3"))
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("region      = regionOne"))
+		})
+
+		It("should source the config from the cloud config secret without its secret material", func() {
+			cloudConfigSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      cloudConfigSecretName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string][]byte{defaultConfigKey: []byte("[Global]\nusername = admin\npassword = secret\nregion = regionOne\n")}}
+			Expect(cl.Create(ctx, cloudConfigSecret)).To(Succeed())
+			defer func() {
+				Expect(cl.Delete(ctx, cloudConfigSecret)).To(Succeed())
+			}()
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(MatchRegexp(`username\s+= admin`))
+			Expect(syncedCM.Data[defaultConfigKey]).To(MatchRegexp(`region\s+= regionOne`))
+			Expect(syncedCM.Data[defaultConfigKey]).NotTo(ContainSubstring("password"))
+		})
+
+		It("should fail on unsupported Octavia options", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      openstackOctaviaConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{"use-octavia": "true"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(MatchError(`unsupported Octavia option "use-octavia"`))
+		})
+
+		It("should report sync failures in the degraded condition and as events", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      openstackOctaviaConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{"lb-provider": ""}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(HaveOccurred())
+
+			co := &configv1.ClusterOperator{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: clusterOperatorName}, co)).To(Succeed())
+			degraded := v1helpers.FindStatusCondition(co.Status.Conditions, cloudConfigControllerDegradedCondition)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(configv1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal(ReasonSyncFailed))
+			Expect(degraded.Message).To(ContainSubstring(`"lb-provider"`))
+
+			recorder, ok := reconciler.Recorder.(*record.FakeRecorder)
+			Expect(ok).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Warning CloudConfigSyncFailed Cloud Config Controller failed to compose OpenStack cloud config")))
+		})
+	})
+
+	Context("On GCP platform", func() {
+		BeforeEach(func() {
+			infraCloudConfig := makeInfraCloudConfig(configv1.GCPPlatformType)
+			infraCloudConfig.Data[infraCloudConfKey] = "[global]\nproject-id = openshift\n"
+			Expect(cl.Create(ctx, infraCloudConfig)).To(Succeed())
+
+			infraResource := makeInfrastructureResource(configv1.GCPPlatformType)
+			Expect(cl.Create(ctx, infraResource)).To(Succeed())
+			infraResource.Status = makeInfraStatus(infraResource.Spec.PlatformSpec.Type)
+			Expect(cl.Status().Update(ctx, infraResource.DeepCopy())).To(Succeed())
+		})
+
+		It("should prefer the managed cloud config when present", func() {
+			managedCloudConfig := makeManagedCloudConfig(configv1.GCPPlatformType)
+			managedCloudConfig.Data = map[string]string{
+				defaultConfigKey: "[global]\nproject-id = managed\n",
+				"ca-bundle.pem":  "-----BEGIN CERTIFICATE-----\n",
+			}
+			Expect(cl.Create(ctx, managedCloudConfig)).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("project-id = managed"))
+			Expect(syncedCM.Data).To(HaveKey("ca-bundle.pem"))
+		})
+
+		It("should merge Shared VPC options into the synced config", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      gcpSharedVPCConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{"network-project-id": "host-project", "network-name": "shared-network"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("network-project-id = host-project"))
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("network-name       = shared-network"))
+		})
+
+		It("should merge the cloud config overrides into the synced config", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      cloudConfigOverridesConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{defaultConfigKey: "[global]\nproject-id = other\nregional = true\n"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(Equal("[global]\nproject-id = other\nregional   = true\n"))
+		})
+
+		It("should report likely mistakes without blocking the sync", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      cloudConfigOverridesConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{defaultConfigKey: "[global]\nnetwrok-name = shared-network\n"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)).To(Succeed())
+			Expect(syncedCM.Data[defaultConfigKey]).To(ContainSubstring("netwrok-name"))
+
+			co := &configv1.ClusterOperator{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: clusterOperatorName}, co)).To(Succeed())
+			lint := v1helpers.FindStatusCondition(co.Status.Conditions, cloudConfigControllerLintCondition)
+			Expect(lint).NotTo(BeNil())
+			Expect(lint.Status).To(Equal(configv1.ConditionTrue))
+			Expect(lint.Message).To(ContainSubstring(`did you mean "network-name"?`))
+		})
+
+		It("should be degraded when the synced config is invalid", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      cloudConfigOverridesConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{defaultConfigKey: "[global]\nregional = maybe\n"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(MatchError(`invalid value "maybe" for "regional", expected a boolean`))
+
+			co := &configv1.ClusterOperator{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: clusterOperatorName}, co)).To(Succeed())
+			degraded := v1helpers.FindStatusCondition(co.Status.Conditions, cloudConfigControllerDegradedCondition)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(configv1.ConditionTrue))
+			Expect(degraded.Message).To(ContainSubstring(`invalid value "maybe" for "regional"`))
+		})
+
+		It("should fail on unsupported Shared VPC options", func() {
+			Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      gcpSharedVPCConfigMapName,
+				Namespace: OpenshiftConfigNamespace,
+			}, Data: map[string]string{"node-tags": "worker"}})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(MatchError(`unsupported Shared VPC option "node-tags"`))
+		})
+	})
+
+	Context("With the cloud config sync disabled", func() {
+		BeforeEach(func() {
+			Expect(cl.Create(ctx, makeInfraCloudConfig(configv1.AWSPlatformType))).To(Succeed())
+
+			infraResource := makeInfrastructureResource(configv1.AWSPlatformType)
+			Expect(cl.Create(ctx, infraResource)).To(Succeed())
+			infraResource.Status = makeInfraStatus(infraResource.Spec.PlatformSpec.Type)
+			Expect(cl.Status().Update(ctx, infraResource.DeepCopy())).To(Succeed())
+
+			co, err := reconciler.getOrCreateClusterOperator(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			co.SetAnnotations(map[string]string{disableCloudConfigSyncAnnotation: "true"})
+			Expect(cl.Update(ctx, co)).To(Succeed())
+		})
+
+		It("should not sync the config and report the controller as available", func() {
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
+			Expect(err).To(BeNil())
+
+			syncedCM := &corev1.ConfigMap{}
+			err = cl.Get(ctx, client.ObjectKey{Name: syncedCloudConfigMapName, Namespace: targetNamespaceName}, syncedCM)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			co := &configv1.ClusterOperator{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: clusterOperatorName}, co)).To(Succeed())
+			available := v1helpers.FindStatusCondition(co.Status.Conditions, cloudConfigControllerAvailableCondition)
+			Expect(available).NotTo(BeNil())
+			Expect(available.Status).To(Equal(configv1.ConditionTrue))
+			Expect(available.Reason).To(Equal(ReasonSyncDisabled))
+		})
 	})
 
 	Context("On BareMetal platform", func() {
@@ -584,6 +954,18 @@ var _ = Describe("Cloud config sync reconciler", func() {
 		})
 	})
 
+	It("lint findings should be recorded as an event only when the condition changes", func() {
+		findings := []string{`"subnet-id" looks like a name, 100% sure it should be an ID`}
+		Expect(reconciler.setLintCondition(ctx, findings)).To(Succeed())
+		Expect(reconciler.setLintCondition(ctx, findings)).To(Succeed())
+
+		recorder, ok := reconciler.Recorder.(*record.FakeRecorder)
+		Expect(ok).To(BeTrue())
+		Expect(recorder.Events).To(Receive(Equal(
+			`Warning CloudConfigLintWarnings Cloud config has likely mistakes: "subnet-id" looks like a name, 100% sure it should be an ID`)))
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
 	It("reconcile should fail if no infra resource found", func() {
 		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{})
 		Expect(err.Error()).Should(BeEquivalentTo("infrastructures.config.openshift.io \"cluster\" not found"))
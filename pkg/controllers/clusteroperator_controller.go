@@ -19,14 +19,26 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/library-go/pkg/cloudprovider"
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
+	"golang.org/x/sync/errgroup"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -35,6 +47,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/common"
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/controllers/resourceapply"
 )
@@ -42,16 +55,55 @@ import (
 const (
 	externalFeatureGateName = "cluster"
 	kcmResourceName         = "cluster"
+	clusterVersionName      = "version"
+	dnsResourceName         = "cluster"
+	networkResourceName     = "cluster"
 
 	// Condition type for Cloud Controller ownership
 	cloudControllerOwnershipCondition = "CloudControllerOwner"
+
+	// pausedAnnotation set to "true" on the ClusterOperator stops the operator from applying operands,
+	// allowing admins to temporarily debug or hotfix operand manifests. Status is still reported.
+	pausedAnnotation = "ccm.openshift.io/paused"
+
+	// disableCloudNodeManagerAnnotation set to "true" on the ClusterOperator stops the operator from deploying
+	// the cloud-node-manager DaemonSet on platforms which ship one, e.g. Azure, and removes an existing one.
+	// The cloud-controller-manager Deployment is still managed.
+	disableCloudNodeManagerAnnotation = "ccm.openshift.io/disable-cloud-node-manager"
+
+	// operandLogLevelAnnotation on the ClusterOperator sets the klog verbosity of the operands, from 0 to
+	// maxOperandLogLevel, e.g. to debug the cloud-controller-manager without editing its Deployment.
+	operandLogLevelAnnotation = "ccm.openshift.io/operand-log-level"
+	maxOperandLogLevel        = 10
+
+	// imageOverridesAnnotation on the ClusterOperator overrides operand images, e.g. to test candidate cloud
+	// controller manager builds. It holds a JSON object keyed like the images ConfigMap, and is only honored on
+	// clusters running a non-default feature set, which can not be upgraded anyway.
+	imageOverridesAnnotation = "ccm.openshift.io/image-overrides"
+
+	// managedByLabel marks the operands applied by the operator, so Deployments and DaemonSets which are not
+	// rendered anymore, e.g. once the platform is not supported anymore, can be found and removed.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "cluster-cloud-controller-manager-operator"
 )
 
+// applyConcurrency limits the number of resources applied at the same time.
+const applyConcurrency = 4
+
+// applyBackoff is used to retry applying a single resource before giving up on it for the current sync.
+var applyBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 100 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
 // CloudOperatorReconciler reconciles a ClusterOperator object
 type CloudOperatorReconciler struct {
 	ClusterOperatorStatusClient
 	Scheme            *runtime.Scheme
 	watcher           ObjectWatcher
+	rollouts          rolloutTracker
 	ImagesFile        string
 	FeatureGateAccess featuregates.FeatureGateAccess
 }
@@ -93,6 +145,20 @@ func (r *CloudOperatorReconciler) Reconcile(ctx context.Context, _ ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	paused, err := r.isPaused(ctx)
+	if err != nil {
+		klog.Errorf("Unable to determine if operand management is paused: %v", err)
+		return ctrl.Result{}, err
+	} else if paused {
+		klog.Infof("Operand management is paused by the %q annotation. Skipping...", pausedAnnotation)
+
+		if err := r.setStatusPaused(ctx, conditionOverrides); err != nil {
+			klog.Errorf("Unable to sync cluster operator status: %s", err)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	clusterProxy := &configv1.Proxy{}
 	if err := r.Get(ctx, client.ObjectKey{Name: proxyResourceName}, clusterProxy); err != nil && !errors.IsNotFound(err) {
 		klog.Errorf("Unable to retrive Proxy object: %v", err)
@@ -104,9 +170,9 @@ func (r *CloudOperatorReconciler) Reconcile(ctx context.Context, _ ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	operatorConfig, err := config.ComposeConfig(infra, clusterProxy, r.ImagesFile, r.ManagedNamespace, r.FeatureGateAccess)
+	operatorConfig, err := r.composeOperatorConfig(ctx, infra, clusterProxy)
 	if err != nil {
-		klog.Errorf("Unable to build operator config %s", err)
+		klog.Errorf("Unable to build operator config: %v", err)
 		if err := r.setStatusDegraded(ctx, err, conditionOverrides); err != nil {
 			klog.Errorf("Error syncing ClusterOperatorStatus: %v", err)
 			return ctrl.Result{}, fmt.Errorf("error syncing ClusterOperatorStatus: %v", err)
@@ -114,13 +180,17 @@ func (r *CloudOperatorReconciler) Reconcile(ctx context.Context, _ ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	if err := r.sync(ctx, operatorConfig, conditionOverrides); err != nil {
+	operandsReady, err := r.sync(ctx, operatorConfig, conditionOverrides)
+	if err != nil {
 		klog.Errorf("Unable to sync operands: %s", err)
 		if err := r.setStatusDegraded(ctx, err, conditionOverrides); err != nil {
 			klog.Errorf("Error syncing ClusterOperatorStatus: %v", err)
 			return ctrl.Result{}, fmt.Errorf("error syncing ClusterOperatorStatus: %v", err)
 		}
 		return ctrl.Result{}, err
+	} else if !operandsReady {
+		// Operands are watched, so any change in their status triggers a new reconcile.
+		return ctrl.Result{}, nil
 	}
 
 	if err := r.setStatusAvailable(ctx, conditionOverrides); err != nil {
@@ -136,39 +206,305 @@ func (r *CloudOperatorReconciler) Reconcile(ctx context.Context, _ ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
-func (r *CloudOperatorReconciler) sync(ctx context.Context, config config.OperatorConfig, conditionOverrides []configv1.ClusterOperatorStatusCondition) error {
+// composeOperatorConfig builds the operator config from the cluster configuration, along with the overrides set on
+// the ClusterOperator and the cluster wide settings rendered into the operands.
+func (r *CloudOperatorReconciler) composeOperatorConfig(ctx context.Context, infra *configv1.Infrastructure, clusterProxy *configv1.Proxy) (config.OperatorConfig, error) {
+	featureGate, err := r.getFeatureGate(ctx)
+	if err != nil {
+		return config.OperatorConfig{}, err
+	}
+
+	operatorConfig, err := config.ComposeConfig(infra, clusterProxy, r.ImagesFile, r.ManagedNamespace, r.ReleaseVersion, r.FeatureGateAccess, featureGate)
+	if err != nil {
+		return config.OperatorConfig{}, err
+	}
+
+	if operatorConfig.ExternalManifests, err = r.getExternalManifests(ctx, infra); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to retrieve external cloud controller manager manifests: %w", err)
+	}
+	if operatorConfig.CloudControllerManagerArgs, err = r.getCloudControllerManagerArgs(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to retrieve cloud controller manager arguments: %w", err)
+	}
+	if operatorConfig.ContainerResources, err = r.getContainerResources(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to retrieve container resources: %w", err)
+	}
+	if operatorConfig.DisableCloudNodeManager, err = r.isCloudNodeManagerDisabled(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to determine if cloud node manager is disabled: %w", err)
+	}
+	if operatorConfig.ImagesReference, err = r.getOverriddenImages(ctx, operatorConfig.ImagesReference); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to override operand images: %w", err)
+	}
+	if operatorConfig.OperandLogLevel, err = r.getOperandLogLevel(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to determine operand log level: %w", err)
+	}
+	if operatorConfig.ClusterID, err = r.getClusterID(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to retrieve cluster ID: %w", err)
+	}
+	if operatorConfig.BaseDomain, err = r.getBaseDomain(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to retrieve cluster base domain: %w", err)
+	}
+	if operatorConfig.ClusterCIDRs, err = r.getClusterCIDRs(ctx); err != nil {
+		return config.OperatorConfig{}, fmt.Errorf("unable to retrieve cluster network CIDRs: %w", err)
+	}
+	return operatorConfig, nil
+}
+
+// isPaused returns true when operand management was paused via the pausedAnnotation on the ClusterOperator.
+func (r *CloudOperatorReconciler) isPaused(ctx context.Context) (bool, error) {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return false, err
+	}
+	return co.GetAnnotations()[pausedAnnotation] == "true", nil
+}
+
+// isCloudNodeManagerDisabled returns true when the cloud-node-manager was disabled via the
+// disableCloudNodeManagerAnnotation on the ClusterOperator.
+func (r *CloudOperatorReconciler) isCloudNodeManagerDisabled(ctx context.Context) (bool, error) {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return false, err
+	}
+	return co.GetAnnotations()[disableCloudNodeManagerAnnotation] == "true", nil
+}
+
+// getOperandLogLevel returns the klog verbosity of the operands set via the operandLogLevelAnnotation on the
+// ClusterOperator, or nil if it is not set.
+func (r *CloudOperatorReconciler) getOperandLogLevel(ctx context.Context) (*int, error) {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := co.GetAnnotations()[operandLogLevelAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 0 || level > maxOperandLogLevel {
+		return nil, fmt.Errorf("invalid %s annotation value %q, must be an integer from 0 to %d", operandLogLevelAnnotation, value, maxOperandLogLevel)
+	}
+	return &level, nil
+}
+
+// getFeatureGate returns the cluster FeatureGate object, the feature gates are resolved from when the
+// FeatureGateAccess can not provide them. It returns nil if there is none.
+func (r *CloudOperatorReconciler) getFeatureGate(ctx context.Context) (*configv1.FeatureGate, error) {
+	featureGate := &configv1.FeatureGate{}
+	if err := r.Get(ctx, client.ObjectKey{Name: externalFeatureGateName}, featureGate); errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get FeatureGate %s: %w", externalFeatureGateName, err)
+	}
+	return featureGate, nil
+}
+
+// getOverriddenImages returns the images with the overrides set via the imageOverridesAnnotation on the
+// ClusterOperator applied. Overrides are ignored, with a warning event, unless the cluster runs a non-default
+// feature set, so production clusters always run the released images.
+func (r *CloudOperatorReconciler) getOverriddenImages(ctx context.Context, images config.ImagesReference) (config.ImagesReference, error) {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return images, err
+	}
+	overrides, ok := co.GetAnnotations()[imageOverridesAnnotation]
+	if !ok {
+		return images, nil
+	}
+
+	featureGate := &configv1.FeatureGate{}
+	if err := r.Get(ctx, client.ObjectKey{Name: externalFeatureGateName}, featureGate); err != nil && !errors.IsNotFound(err) {
+		return images, fmt.Errorf("unable to get FeatureGate %s: %w", externalFeatureGateName, err)
+	}
+	if featureGate.Spec.FeatureSet == configv1.Default {
+		klog.Warningf("Ignoring the %s annotation, the cluster runs the default feature set", imageOverridesAnnotation)
+		r.Recorder.Eventf(co, corev1.EventTypeWarning, "ImageOverridesIgnored",
+			"Ignoring the %s annotation, operand images can only be overridden on clusters running a non-default feature set", imageOverridesAnnotation)
+		return images, nil
+	}
+
+	overridden, err := config.OverrideImages(images, overrides)
+	if err != nil {
+		return images, err
+	}
+	klog.Warningf("Operand images are overridden by the %s annotation on the %s feature set: %s", imageOverridesAnnotation, featureGate.Spec.FeatureSet, overrides)
+	return overridden, nil
+}
+
+// sync applies operands for the platform and returns true once they are ready, so the operator can be reported as Available.
+func (r *CloudOperatorReconciler) sync(ctx context.Context, config config.OperatorConfig, conditionOverrides []configv1.ClusterOperatorStatusCondition) (bool, error) {
 	// Deploy resources for platform
 	resources, err := cloud.GetResources(config)
 	if err != nil {
-		return err
+		return false, err
 	}
+	setOperandsManagedBy(resources)
+
 	updated, err := r.applyResources(ctx, resources)
 	if err != nil {
-		return err
+		return false, err
+	}
+	if err := r.deleteStaleOperands(ctx, resources); err != nil {
+		return false, err
+	}
+	if config.DisableCloudNodeManager {
+		if err := r.deleteCloudNodeManager(ctx); err != nil {
+			return false, err
+		}
+	}
+	if err := r.rollouts.observe(ctx, r.Client, r.Clock); err != nil {
+		klog.Errorf("Unable to observe operands rollout state: %v", err)
 	}
 	if updated {
-		return r.setStatusProgressing(ctx, conditionOverrides)
+		if err := r.setStatusProgressing(ctx, conditionOverrides); err != nil {
+			return false, err
+		}
 	}
 
+	notReady, err := r.notReadyDaemonSets(ctx, resources)
+	if err != nil {
+		return false, err
+	}
+	if len(notReady) > 0 {
+		klog.Infof("Waiting for DaemonSets to become ready: %s", strings.Join(notReady, ", "))
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// setOperandsManagedBy labels all operands with the managedByLabel, see deleteStaleOperands.
+// Operands are deliberately not owned by the ClusterOperator: deleting it is a routine remediation,
+// after which the CVO recreates it, and must not cascade to the running cloud controller managers.
+func setOperandsManagedBy(resources []client.Object) {
+	for _, resource := range resources {
+		labels := resource.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[managedByLabel] = managedByValue
+		resource.SetLabels(labels)
+	}
+}
+
+// deleteStaleOperands removes the Deployments and DaemonSets in the managed namespace which were applied by
+// the operator, as marked by the managedByLabel, but are not part of the rendered resources anymore,
+// e.g. after the cloud controller manager of the platform got disabled.
+func (r *CloudOperatorReconciler) deleteStaleOperands(ctx context.Context, resources []client.Object) error {
+	rendered := sets.New[string]()
+	for _, resource := range resources {
+		switch resource.(type) {
+		case *appsv1.Deployment, *appsv1.DaemonSet:
+			rendered.Insert(fmt.Sprintf("%T/%s", resource, client.ObjectKeyFromObject(resource)))
+		}
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(r.ManagedNamespace), client.MatchingLabels{managedByLabel: managedByValue}); err != nil {
+		return fmt.Errorf("unable to list operand Deployments: %w", err)
+	}
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, daemonSets, client.InNamespace(r.ManagedNamespace), client.MatchingLabels{managedByLabel: managedByValue}); err != nil {
+		return fmt.Errorf("unable to list operand DaemonSets: %w", err)
+	}
+	existing := []client.Object{}
+	for i := range deployments.Items {
+		existing = append(existing, &deployments.Items[i])
+	}
+	for i := range daemonSets.Items {
+		existing = append(existing, &daemonSets.Items[i])
+	}
+
+	for _, obj := range existing {
+		if rendered.Has(fmt.Sprintf("%T/%s", obj, client.ObjectKeyFromObject(obj))) {
+			continue
+		}
+		klog.Infof("Deleting stale operand %T %s", obj, client.ObjectKeyFromObject(obj))
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete stale operand %s: %w", client.ObjectKeyFromObject(obj), err)
+		}
+	}
 	return nil
 }
 
-// applyResources will apply all resources as-is to the cluster, allowing adding of custom annotations and lables
-func (r *CloudOperatorReconciler) applyResources(ctx context.Context, resources []client.Object) (bool, error) {
-	updated := false
-	var err error
+// deleteCloudNodeManager removes the cloud-node-manager DaemonSets previously deployed by the operator
+// in the managed namespace, once the cloud-node-manager got disabled.
+func (r *CloudOperatorReconciler) deleteCloudNodeManager(ctx context.Context) error {
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, daemonSets, client.InNamespace(r.ManagedNamespace), client.HasLabels{common.CloudNodeManagerCloudProviderLabel}); err != nil {
+		return fmt.Errorf("unable to list cloud-node-manager DaemonSets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[i]
+		klog.Infof("Cloud node manager is disabled, deleting DaemonSet %s", client.ObjectKeyFromObject(daemonSet))
+		if err := r.Delete(ctx, daemonSet); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete DaemonSet %s: %w", client.ObjectKeyFromObject(daemonSet), err)
+		}
+	}
+	return nil
+}
 
+// notReadyDaemonSets returns the names of the applied DaemonSets, such as cloud-node-manager,
+// which do not have the desired number of updated and ready pods yet.
+func (r *CloudOperatorReconciler) notReadyDaemonSets(ctx context.Context, resources []client.Object) ([]string, error) {
+	notReady := []string{}
 	for _, resource := range resources {
-		updated, err = resourceapply.ApplyResource(ctx, r.Client, r.Recorder, resource)
-		if err != nil {
-			return false, err
+		if _, ok := resource.(*appsv1.DaemonSet); !ok {
+			continue
 		}
 
-		if err := r.watcher.Watch(ctx, resource); err != nil {
-			klog.Errorf("Unable to establish watch on object %s '%s': %+v", resource.GetObjectKind().GroupVersionKind(), resource.GetName(), err)
-			r.Recorder.Event(resource, corev1.EventTypeWarning, "Establish watch failed", err.Error())
-			return false, err
+		daemonSet := &appsv1.DaemonSet{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(resource), daemonSet); err != nil {
+			return nil, fmt.Errorf("unable to get DaemonSet %s: %w", client.ObjectKeyFromObject(resource), err)
+		}
+		if !isDaemonSetReady(daemonSet) {
+			notReady = append(notReady, fmt.Sprintf("%s (%d of %d pods ready)", client.ObjectKeyFromObject(daemonSet),
+				daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled))
+		}
+	}
+	return notReady, nil
+}
+
+func isDaemonSetReady(daemonSet *appsv1.DaemonSet) bool {
+	desired := daemonSet.Status.DesiredNumberScheduled
+	return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.UpdatedNumberScheduled >= desired &&
+		daemonSet.Status.NumberReady >= desired
+}
+
+// applyResources will apply all resources as-is to the cluster, allowing adding of custom annotations and lables.
+// Each resource is retried with backoff on transient errors. A resource which could not be applied does not
+// prevent the remaining ones from being applied; all failures are reported in a single aggregated error.
+// Resources are applied in stages, see applyStage, and resources within a single stage are applied concurrently.
+func (r *CloudOperatorReconciler) applyResources(ctx context.Context, resources []client.Object) (bool, error) {
+	outcomes := make([]resourceApplyOutcome, len(resources))
+
+	for _, stage := range applyStages(resources) {
+		group := errgroup.Group{}
+		group.SetLimit(applyConcurrency)
+		for _, i := range stage {
+			group.Go(func() error {
+				outcomes[i] = r.applyResource(ctx, resources[i])
+				return nil
+			})
 		}
+		_ = group.Wait()
+	}
+
+	updated := false
+	errs := []error{}
+	results := make([]applyResult, 0, len(resources))
+	for _, outcome := range outcomes {
+		updated = updated || outcome.updated
+		errs = append(errs, outcome.errs...)
+		results = append(results, outcome.result)
+	}
+
+	if err := r.recordApplyResults(ctx, results); err != nil {
+		klog.Errorf("Unable to record apply results: %v", err)
+	}
+
+	if len(errs) > 0 {
+		return updated, fmt.Errorf("failed to apply %d of %d resources: %w", len(errs), len(resources), utilerrors.NewAggregate(errs))
 	}
 
 	if len(resources) > 0 {
@@ -178,6 +514,107 @@ func (r *CloudOperatorReconciler) applyResources(ctx context.Context, resources
 	return updated, nil
 }
 
+// resourceApplyOutcome holds the outcome of applying a single resource.
+type resourceApplyOutcome struct {
+	updated bool
+	result  applyResult
+	errs    []error
+}
+
+// applyResource applies a single resource, retrying on transient errors, and establishes a watch on it.
+func (r *CloudOperatorReconciler) applyResource(ctx context.Context, resource client.Object) resourceApplyOutcome {
+	existed := r.resourceExists(ctx, resource)
+	resourceUpdated := false
+	err := retry.OnError(applyBackoff, isRetriableApplyError, func() error {
+		var err error
+		start := r.Clock.Now()
+		resourceUpdated, err = resourceapply.ApplyResource(ctx, r.Client, r.Recorder, resource)
+		observeApplyAttempt(resource, r.Clock.Since(start), err)
+		return err
+	})
+	if err != nil {
+		observeApplyFailure(resource)
+		klog.Errorf("Unable to apply object %s: %v", describeResource(resource), err)
+		return resourceApplyOutcome{
+			result: newApplyResult(resource, applyOutcomeFailed, err),
+			errs:   []error{fmt.Errorf("%s: %w", describeResource(resource), err)},
+		}
+	}
+
+	outcome := resourceApplyOutcome{updated: resourceUpdated}
+	switch {
+	case !resourceUpdated:
+		outcome.result = newApplyResult(resource, applyOutcomeUnchanged, nil)
+	case !existed:
+		outcome.result = newApplyResult(resource, applyOutcomeCreated, nil)
+	default:
+		outcome.result = newApplyResult(resource, applyOutcomeUpdated, nil)
+	}
+	if resourceUpdated {
+		r.rollouts.start(resource, r.Clock.Now())
+	}
+
+	if err := r.watcher.Watch(ctx, resource); err != nil {
+		klog.Errorf("Unable to establish watch on object %s '%s': %+v", resource.GetObjectKind().GroupVersionKind(), resource.GetName(), err)
+		r.Recorder.Event(resource, corev1.EventTypeWarning, "Establish watch failed", err.Error())
+		outcome.errs = append(outcome.errs, fmt.Errorf("%s: unable to establish watch: %w", describeResource(resource), err))
+	}
+
+	return outcome
+}
+
+// applyStage returns the position of the resource in the apply order. Resources of the same stage do not
+// depend on each other and are applied concurrently. Stages are applied one after another, so configuration
+// and RBAC objects are in place before the workloads which are using them.
+func applyStage(resource client.Object) int {
+	switch resource.(type) {
+	case *corev1.ConfigMap, *rbacv1.Role, *rbacv1.ClusterRole, *admissionregistrationv1.ValidatingAdmissionPolicy:
+		return 0
+	case *rbacv1.RoleBinding, *rbacv1.ClusterRoleBinding, *admissionregistrationv1.ValidatingAdmissionPolicyBinding,
+		*corev1.Service, *policyv1.PodDisruptionBudget:
+		return 1
+	case *appsv1.Deployment, *appsv1.DaemonSet:
+		return 2
+	default:
+		// Kinds unknown to the operator might depend on anything else, so they go last.
+		return 3
+	}
+}
+
+// applyStages groups indexes of the resources by their apply stage, in the order the stages should be applied.
+// The relative order of the resources within each stage is preserved.
+func applyStages(resources []client.Object) [][]int {
+	stages := [][]int{}
+	for i, resource := range resources {
+		stage := applyStage(resource)
+		for len(stages) <= stage {
+			stages = append(stages, []int{})
+		}
+		stages[stage] = append(stages[stage], i)
+	}
+	return stages
+}
+
+// isRetriableApplyError returns true for errors which are expected to go away on their own,
+// such as conflicts with concurrent writers or a temporarily unavailable API server.
+func isRetriableApplyError(err error) bool {
+	if _, isStatus := err.(errors.APIStatus); !isStatus {
+		// Not an API error, most likely a transport level failure.
+		return true
+	}
+	return errors.IsConflict(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsTooManyRequests(err) ||
+		errors.IsInternalError(err) ||
+		errors.IsServiceUnavailable(err)
+}
+
+// describeResource returns a human readable reference to the resource, e.g. "Deployment openshift-cloud-controller-manager/aws-cloud-controller-manager".
+func describeResource(resource client.Object) string {
+	return fmt.Sprintf("%s %s", resource.GetObjectKind().GroupVersionKind().Kind, client.ObjectKeyFromObject(resource))
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *CloudOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	watcher, err := NewObjectWatcher(WatcherOptions{
@@ -194,6 +631,13 @@ func (r *CloudOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&configv1.Infrastructure{},
 			handler.EnqueueRequestsFromMapFunc(toClusterOperator),
 			builder.WithPredicates(infrastructurePredicates())).
+		// Proxy changes are rendered into the operand containers, which rolls them.
+		Watches(&configv1.Proxy{},
+			handler.EnqueueRequestsFromMapFunc(toClusterOperator),
+			builder.WithPredicates(proxyPredicates())).
+		Watches(&configv1.Network{},
+			handler.EnqueueRequestsFromMapFunc(toClusterOperator),
+			builder.WithPredicates(networkPredicates())).
 		Watches(&configv1.FeatureGate{},
 			handler.EnqueueRequestsFromMapFunc(toClusterOperator),
 			builder.WithPredicates(featureGatePredicates())).
@@ -201,7 +645,9 @@ func (r *CloudOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(toClusterOperator),
 			builder.WithPredicates(kcmPredicates())).
 		WatchesRawSource(source.Channel(watcher.EventStream(), handler.EnqueueRequestsFromMapFunc(toClusterOperator))).
-		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(toClusterOperator)).
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(toClusterOperator),
+			builder.WithPredicates(applyResultsConfigMapPredicates())).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(toClusterOperator))
 
 	return build.Complete(r)
@@ -222,12 +668,23 @@ func (r *CloudOperatorReconciler) provisioningAllowed(ctx context.Context, infra
 	}
 
 	if r.isPlatformExternal(infra.Status.PlatformStatus) {
-		klog.V(3).Info("'External' platform type is detected, do nothing.")
-		if err := r.setStatusAvailable(ctx, conditionOverrides); err != nil {
-			klog.Errorf("Unable to sync cluster operator status: %s", err)
+		externalManifests, err := r.getExternalManifests(ctx, infra)
+		if err != nil {
+			klog.Errorf("Unable to retrieve external cloud controller manager manifests: %v", err)
+			if err := r.setStatusDegraded(ctx, err, conditionOverrides); err != nil {
+				klog.Errorf("Error syncing ClusterOperatorStatus: %v", err)
+				return false, fmt.Errorf("error syncing ClusterOperatorStatus: %v", err)
+			}
 			return false, err
 		}
-		return false, nil
+		if !cloud.IsExternalPlatformSupported(infra.Status.PlatformStatus, config.GetExternalPlatformName(infra), len(externalManifests) > 0) {
+			klog.V(3).Info("'External' platform type is detected, do nothing.")
+			if err := r.setStatusNoCloudProvider(ctx, infra.Status.PlatformStatus.Type, conditionOverrides); err != nil {
+				klog.Errorf("Unable to sync cluster operator status: %s", err)
+				return false, err
+			}
+			return false, nil
+		}
 	}
 
 	// If CCM already owns cloud controllers, then provision is allowed by default
@@ -251,7 +708,7 @@ func (r *CloudOperatorReconciler) provisioningAllowed(ctx context.Context, infra
 	} else if !external {
 		klog.Infof("Platform does not require an external cloud provider. Skipping...")
 
-		if err := r.setStatusAvailable(ctx, conditionOverrides); err != nil {
+		if err := r.setStatusNoCloudProvider(ctx, infra.Status.PlatformStatus.Type, conditionOverrides); err != nil {
 			klog.Errorf("Unable to sync cluster operator status: %s", err)
 			return false, err
 		}
@@ -317,6 +774,98 @@ func (r *CloudOperatorReconciler) checkControllerConditions(ctx context.Context)
 	return cloudConfigControllerAvailable && trustedCABundleControllerAvailable, nil
 }
 
+// getExternalManifests returns the user-supplied cloud controller manager manifests for the External platform type,
+// see externalManifestsConfigMapName. It returns nil on other platforms, or if no manifests were supplied.
+func (r *CloudOperatorReconciler) getExternalManifests(ctx context.Context, infra *configv1.Infrastructure) (map[string]string, error) {
+	if !r.isPlatformExternal(infra.Status.PlatformStatus) {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.ManagedNamespace, Name: externalManifestsConfigMapName}, cm)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get ConfigMap %s/%s: %w", r.ManagedNamespace, externalManifestsConfigMapName, err)
+	}
+	return cm.Data, nil
+}
+
+// getCloudControllerManagerArgs returns the admin-provided cloud controller manager flags, see
+// ccmArgsConfigMapName. It returns nil if no flags were provided.
+func (r *CloudOperatorReconciler) getCloudControllerManagerArgs(ctx context.Context) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.ManagedNamespace, Name: ccmArgsConfigMapName}, cm)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get ConfigMap %s/%s: %w", r.ManagedNamespace, ccmArgsConfigMapName, err)
+	}
+	return cm.Data, nil
+}
+
+// getContainerResources returns the admin-provided resource requests and limits of the operand containers, see
+// ccmResourcesConfigMapName. It returns nil if no resources were provided.
+func (r *CloudOperatorReconciler) getContainerResources(ctx context.Context) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.ManagedNamespace, Name: ccmResourcesConfigMapName}, cm)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get ConfigMap %s/%s: %w", r.ManagedNamespace, ccmResourcesConfigMapName, err)
+	}
+	return cm.Data, nil
+}
+
+// getClusterID returns the unique identifier of the cluster from the ClusterVersion, or an empty string if there is
+// no ClusterVersion, e.g. on clusters whose version is not managed by the cluster-version-operator.
+func (r *CloudOperatorReconciler) getClusterID(ctx context.Context) (string, error) {
+	clusterVersion := &configv1.ClusterVersion{}
+	err := r.Get(ctx, client.ObjectKey{Name: clusterVersionName}, clusterVersion)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("unable to get ClusterVersion %s: %w", clusterVersionName, err)
+	}
+	return string(clusterVersion.Spec.ClusterID), nil
+}
+
+// getBaseDomain returns the base domain of the cluster from the DNS config, or an empty string if there is none.
+func (r *CloudOperatorReconciler) getBaseDomain(ctx context.Context) (string, error) {
+	dns := &configv1.DNS{}
+	err := r.Get(ctx, client.ObjectKey{Name: dnsResourceName}, dns)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("unable to get DNS %s: %w", dnsResourceName, err)
+	}
+	return dns.Spec.BaseDomain, nil
+}
+
+// getClusterCIDRs returns the pod network CIDRs of the cluster from the Network config. The status is preferred, as
+// it reflects the networks in use, and the spec is used until the network operator populated it. It returns nil if
+// there is no Network config.
+func (r *CloudOperatorReconciler) getClusterCIDRs(ctx context.Context) ([]string, error) {
+	network := &configv1.Network{}
+	err := r.Get(ctx, client.ObjectKey{Name: networkResourceName}, network)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get Network %s: %w", networkResourceName, err)
+	}
+
+	var cidrs []string
+	for _, clusterNetwork := range network.Status.ClusterNetwork {
+		cidrs = append(cidrs, clusterNetwork.CIDR)
+	}
+	if len(cidrs) == 0 {
+		for _, clusterNetwork := range network.Spec.ClusterNetwork {
+			cidrs = append(cidrs, clusterNetwork.CIDR)
+		}
+	}
+	return cidrs, nil
+}
+
 func (r *CloudOperatorReconciler) isPlatformExternal(platformStatus *configv1.PlatformStatus) bool {
 	return platformStatus.Type == configv1.ExternalPlatformType
 }
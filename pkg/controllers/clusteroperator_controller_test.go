@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -10,8 +11,10 @@ import (
 	"github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	clocktesting "k8s.io/utils/clock/testing"
@@ -236,6 +239,45 @@ var _ = Describe("toClusterOperator mapping is targeting requests to 'cloud-cont
 	})
 })
 
+var _ = Describe("setOperandsManagedBy", func() {
+	It("Should label all resources without dropping their labels or setting an owner", func() {
+		deployment := &appsv1.Deployment{}
+		deployment.SetLabels(map[string]string{"k8s-app": "foo"})
+		clusterRole := &rbacv1.ClusterRole{}
+
+		setOperandsManagedBy([]client.Object{deployment, clusterRole})
+
+		Expect(deployment.GetLabels()).To(Equal(map[string]string{"k8s-app": "foo", managedByLabel: managedByValue}))
+		Expect(clusterRole.GetLabels()).To(Equal(map[string]string{managedByLabel: managedByValue}))
+		Expect(deployment.GetOwnerReferences()).To(BeEmpty())
+		Expect(clusterRole.GetOwnerReferences()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("applyStages", func() {
+	It("Should group resources by their apply stage and keep their relative order", func() {
+		resources := []client.Object{
+			&appsv1.Deployment{},
+			&corev1.Service{},
+			&corev1.ConfigMap{},
+			&appsv1.DaemonSet{},
+			&rbacv1.Role{},
+			&rbacv1.RoleBinding{},
+		}
+
+		Expect(applyStages(resources)).To(Equal([][]int{{2, 4}, {1, 5}, {0, 3}}))
+	})
+
+	It("Should put unknown kinds last", func() {
+		resources := []client.Object{
+			&unstructured.Unstructured{},
+			&corev1.ConfigMap{},
+		}
+
+		Expect(applyStages(resources)).To(Equal([][]int{{1}, {}, {}, {0}}))
+	})
+})
+
 var _ = Describe("Apply resources should", func() {
 	var resources []client.Object
 	var reconciler *CloudOperatorReconciler
@@ -278,7 +320,8 @@ var _ = Describe("Apply resources should", func() {
 					CloudNodeManagerAzure:           "quay.io/openshift/origin-azure-cloud-node-manager",
 					CloudControllerManagerOpenStack: "registry.ci.openshift.org/openshift:openstack-cloud-controller-manager",
 				},
-				PlatformStatus: status,
+				PlatformStatus:     status,
+				InfrastructureName: "my-cluster",
 			}
 		}
 	})
@@ -340,8 +383,29 @@ var _ = Describe("Apply resources should", func() {
 
 		updated, err := reconciler.applyResources(context.TODO(), objects)
 		Expect(err).Should(HaveOccurred())
-		Expect(updated).To(BeFalse())
+		Expect(err.Error()).To(ContainSubstring(describeResource(objects[0])))
+		// Remaining resources are still applied
+		Expect(updated).To(BeTrue())
 		Eventually(recorder.Events).Should(Receive(ContainSubstring(resourceapply.ResourceCreateFailedEvent)))
+		for _, obj := range objects[1:] {
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))).To(Succeed())
+		}
+	})
+
+	It("Expect all failed resources to be reported", func() {
+		operatorConfig := getConfigForPlatform(&configv1.PlatformStatus{Type: configv1.AWSPlatformType})
+		objects, err := cloud.GetResources(operatorConfig)
+		Expect(err).To(Succeed())
+		Expect(len(objects)).To(BeNumerically(">", 1))
+
+		objects[0].SetNamespace("non-existent")
+		objects[1].SetNamespace("non-existent")
+
+		_, err = reconciler.applyResources(context.TODO(), objects)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("failed to apply 2 of %d resources", len(objects))))
+		Expect(err.Error()).To(ContainSubstring(describeResource(objects[0])))
+		Expect(err.Error()).To(ContainSubstring(describeResource(objects[1])))
 	})
 
 	It("Expect no update when resources are applied twice", func() {
@@ -597,6 +661,41 @@ var _ = Describe("Apply resources should", func() {
 		Expect(dep.Labels[common.CloudControllerManagerProviderLabel]).To(Equal("AWS"))
 	})
 
+	It("Expect operands which are not rendered anymore to be deleted", func() {
+		reconciler.ManagedNamespace = DefaultManagedNamespace
+		operatorConfig := getConfigForPlatform(&configv1.PlatformStatus{Type: configv1.AWSPlatformType})
+		awsResources, err := cloud.GetResources(operatorConfig)
+		Expect(err).To(Succeed())
+		setOperandsManagedBy(awsResources)
+
+		_, err = reconciler.applyResources(context.TODO(), awsResources)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var stale *appsv1.Deployment
+		for _, res := range awsResources {
+			if deployment, ok := res.(*appsv1.Deployment); ok {
+				stale = deployment
+				continue
+			}
+			resources = append(resources, res)
+		}
+		Expect(stale).NotTo(BeNil())
+
+		unmanaged := stale.DeepCopy()
+		unmanaged.SetName("unmanaged")
+		unmanaged.SetResourceVersion("")
+		delete(unmanaged.Labels, managedByLabel)
+		Expect(cl.Create(context.TODO(), unmanaged)).To(Succeed())
+		resources = append(resources, unmanaged)
+
+		Expect(reconciler.deleteStaleOperands(context.TODO(), resources)).To(Succeed())
+
+		Eventually(func() bool {
+			return apierrors.IsNotFound(cl.Get(context.Background(), client.ObjectKeyFromObject(stale), &appsv1.Deployment{}))
+		}, timeout).Should(BeTrue())
+		Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(unmanaged), &appsv1.Deployment{})).To(Succeed())
+	})
+
 	AfterEach(func() {
 		co := &configv1.ClusterOperator{}
 		err := cl.Get(context.Background(), client.ObjectKey{Name: clusterOperatorName}, co)
@@ -10,5 +10,37 @@ const (
 
 	syncedCloudConfigMapName = "cloud-conf"
 
+	// externalManifestsConfigMapName is the ConfigMap in the managed namespace holding user-supplied cloud controller
+	// manager manifests for the External platform type. Each key holds one or more manifests, which are deployed
+	// and managed by the operator instead of the assets shipped with it (pass-through mode).
+	externalManifestsConfigMapName = "external-cloud-controller-manager-manifests"
+
+	// ccmArgsConfigMapName is the ConfigMap in the managed namespace holding admin-provided cloud controller manager
+	// flags, keyed by their name without leading dashes. Only flags allowed on the platform are accepted.
+	ccmArgsConfigMapName = "cloud-controller-manager-args"
+
+	// ccmResourcesConfigMapName is the ConfigMap in the managed namespace holding admin-provided resource requests
+	// and limits of the operand containers, keyed by "<container>.<requests|limits>.<cpu|memory>".
+	ccmResourcesConfigMapName = "cloud-controller-manager-resources"
+
+	// openstackOctaviaConfigMapName is the user-facing ConfigMap in the openshift-config namespace holding
+	// Octavia options, which are merged into the [LoadBalancer] section of the OpenStack cloud.conf.
+	openstackOctaviaConfigMapName = "openstack-octavia-config"
+
+	// gcpSharedVPCConfigMapName is the user-facing ConfigMap in the openshift-config namespace holding
+	// Shared VPC (XPN) options, which are merged into the [global] section of the GCP cloud.conf.
+	gcpSharedVPCConfigMapName = "gcp-shared-vpc-config"
+
+	// cloudConfigOverridesConfigMapName is the user-facing ConfigMap in the openshift-config namespace holding
+	// a partial cloud config, which is deep-merged on top of the transformed cloud config of any platform.
+	cloudConfigOverridesConfigMapName = "ccm-cloud-config-overrides"
+
+	// cloudConfigSecretName is the user-facing Secret in the openshift-config namespace holding a cloud config
+	// intertwined with credentials. It is used as the source of the synced cloud config when it exists, and the
+	// keys the provider declares as sensitive are stripped from it.
+	cloudConfigSecretName = "ccm-cloud-config"
+
 	proxyResourceName = "cluster"
+
+	imageConfigResourceName = "cluster"
 )
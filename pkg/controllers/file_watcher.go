@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// fileWatcher sends an event for its object each time the content of a file changes on disk, e.g. the system trust
+// bundle when the node ships updated CAs. It implements manager.Runnable, so it runs along with the manager.
+type fileWatcher struct {
+	path      string
+	object    client.Object
+	eventChan chan event.GenericEvent
+}
+
+func newFileWatcher(path string, object client.Object) *fileWatcher {
+	return &fileWatcher{
+		path:      path,
+		object:    object,
+		eventChan: make(chan event.GenericEvent),
+	}
+}
+
+func (w *fileWatcher) EventStream() <-chan event.GenericEvent {
+	return w.eventChan
+}
+
+// Start watches the file until the context is done. Failing to set up the watch is not fatal, changes of the file
+// are then picked up on the next resync only.
+func (w *fileWatcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		klog.Errorf("failed to create file watcher for %s, its changes will be picked up on resync only: %v", w.path, err)
+		return nil
+	}
+	defer watcher.Close()
+
+	// The parent directory is watched rather than the file itself, files are usually replaced rather than written
+	// in place, e.g. by update-ca-trust or on ConfigMap volume updates, which drops a watch set on the file.
+	dir := filepath.Dir(w.path)
+	if err := watcher.Add(dir); err != nil {
+		klog.Errorf("failed to watch %s, changes of %s will be picked up on resync only: %v", dir, w.path, err)
+		return nil
+	}
+	klog.V(1).Infof("watching %s for changes", w.path)
+
+	content, _ := os.ReadFile(w.path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			klog.Errorf("error watching %s: %v", w.path, err)
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// Any event of the directory may replace the file, e.g. a symlink swap, only content changes matter.
+			newContent, err := os.ReadFile(w.path)
+			if err != nil || bytes.Equal(content, newContent) {
+				continue
+			}
+			content = newContent
+			klog.Infof("%s changed on disk", w.path)
+			select {
+			case w.eventChan <- event.GenericEvent{Object: w.object}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
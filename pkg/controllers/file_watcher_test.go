@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("File watcher", func() {
+	var path string
+	var object *corev1.ConfigMap
+	var watcher *fileWatcher
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "tls-ca-bundle.pem")
+		Expect(os.WriteFile(path, []byte("bundle"), 0o644)).To(Succeed())
+
+		object = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: trustedCAConfigMapName, Namespace: testManagedNamespace}}
+		watcher = newFileWatcher(path, object)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(ctx)).To(Succeed())
+		}()
+		// Let the watch be set up before the file is changed.
+		time.Sleep(200 * time.Millisecond)
+	})
+
+	It("Should send an event when the file is replaced with a new content", func() {
+		tmpPath := filepath.Join(filepath.Dir(path), "tls-ca-bundle.pem.tmp")
+		Expect(os.WriteFile(tmpPath, []byte("updated bundle"), 0o644)).To(Succeed())
+		Expect(os.Rename(tmpPath, path)).To(Succeed())
+
+		Eventually(watcher.EventStream(), timeout).Should(Receive(HaveField("Object", object)))
+	})
+
+	It("Should not send an event when the file is written with the same content", func() {
+		Expect(os.WriteFile(path, []byte("bundle"), 0o644)).To(Succeed())
+
+		Consistently(watcher.EventStream(), time.Second).ShouldNot(Receive())
+	})
+})
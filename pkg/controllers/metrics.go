@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsSubsystem = "cluster_cloud_controller_manager_operator"
+
+var (
+	// rolloutDurationBuckets cover rollouts from a few seconds up to an hour, which is
+	// the range a CCM rollout is expected to fall into during installs and upgrades.
+	rolloutDurationBuckets = []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600}
+
+	operandRolloutDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "operand_rollout_duration_seconds",
+		Help:      "Time from the operator applying a change to an operand workload until the workload is fully available.",
+		Buckets:   rolloutDurationBuckets,
+	}, []string{"kind", "namespace", "name"})
+
+	clusterOperatorProgressingDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "progressing_duration_seconds",
+		Help:      "Time the cloud-controller-manager ClusterOperator spent with the Progressing condition set to True.",
+		Buckets:   rolloutDurationBuckets,
+	})
+
+	resourceApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "resource_apply_duration_seconds",
+		Help:      "Time taken by a single attempt to apply an operand resource, by resource group, version and kind.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"group", "version", "kind"})
+
+	resourceApplyConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "resource_apply_conflicts_total",
+		Help:      "Number of operand resource apply attempts which failed with a conflict, by resource group, version and kind.",
+	}, []string{"group", "version", "kind"})
+
+	resourceApplyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "resource_apply_failures_total",
+		Help:      "Number of operand resources which could not be applied after all retries, by resource group, version and kind.",
+	}, []string{"group", "version", "kind"})
+
+	trustBundleEarliestExpiry = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ccm_trust_bundle_earliest_expiry_timestamp",
+		Help: "Expiration time, in seconds since the epoch, of the certificate of the merged trusted CA bundle of the cloud controller manager which expires first.",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		operandRolloutDuration,
+		clusterOperatorProgressingDuration,
+		resourceApplyDuration,
+		resourceApplyConflicts,
+		resourceApplyFailures,
+		trustBundleEarliestExpiry,
+	)
+}
+
+// rolloutTracker keeps track of operand workloads which were changed by the operator and
+// records how long it took for them to become fully available.
+type rolloutTracker struct {
+	lock    sync.Mutex
+	started map[client.ObjectKey]trackedRollout
+}
+
+type trackedRollout struct {
+	kind      string
+	startTime time.Time
+}
+
+// start begins tracking the rollout of the given object, if it is a workload. Rollouts which are
+// already tracked keep their original start time, so that the duration covers all changes applied
+// to the workload until it became available.
+func (t *rolloutTracker) start(obj client.Object, now time.Time) {
+	kind := workloadKind(obj)
+	if kind == "" {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.started == nil {
+		t.started = map[client.ObjectKey]trackedRollout{}
+	}
+	key := client.ObjectKeyFromObject(obj)
+	if _, ok := t.started[key]; ok {
+		return
+	}
+	t.started[key] = trackedRollout{kind: kind, startTime: now}
+}
+
+// observe checks all tracked rollouts and records the duration for the ones which became available.
+// Workloads which no longer exist are dropped without recording anything.
+func (t *rolloutTracker) observe(ctx context.Context, c client.Client, clk clock.PassiveClock) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for key, rollout := range t.started {
+		available, err := isWorkloadAvailable(ctx, c, rollout.kind, key)
+		if errors.IsNotFound(err) {
+			delete(t.started, key)
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !available {
+			continue
+		}
+
+		duration := clk.Since(rollout.startTime)
+		klog.V(2).Infof("%s %s rollout completed in %s", rollout.kind, key, duration)
+		operandRolloutDuration.WithLabelValues(rollout.kind, key.Namespace, key.Name).Observe(duration.Seconds())
+		delete(t.started, key)
+	}
+
+	return nil
+}
+
+// gvkLabelValues returns the group, version and kind label values for the given object.
+func gvkLabelValues(obj client.Object) []string {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return []string{gvk.Group, gvk.Version, gvk.Kind}
+}
+
+// observeApplyAttempt records the duration of a single apply attempt of the given object, and counts conflicts.
+func observeApplyAttempt(obj client.Object, duration time.Duration, err error) {
+	labels := gvkLabelValues(obj)
+	resourceApplyDuration.WithLabelValues(labels...).Observe(duration.Seconds())
+	if errors.IsConflict(err) {
+		resourceApplyConflicts.WithLabelValues(labels...).Inc()
+	}
+}
+
+// observeApplyFailure counts objects which could not be applied.
+func observeApplyFailure(obj client.Object) {
+	resourceApplyFailures.WithLabelValues(gvkLabelValues(obj)...).Inc()
+}
+
+// observeTrustBundleExpiry records the expiration time of the certificate of the trust bundle which expires first.
+// A zero time, i.e. an empty bundle, is not recorded.
+func observeTrustBundleExpiry(earliest time.Time) {
+	if earliest.IsZero() {
+		return
+	}
+	trustBundleEarliestExpiry.Set(float64(earliest.Unix()))
+}
+
+func workloadKind(obj client.Object) string {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.DaemonSet:
+		return "DaemonSet"
+	default:
+		return ""
+	}
+}
+
+// isWorkloadAvailable returns true once the workload controller observed the latest spec and all
+// the replicas are updated and available.
+func isWorkloadAvailable(ctx context.Context, c client.Client, kind string, key client.ObjectKey) (bool, error) {
+	switch kind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, key, deployment); err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == replicas &&
+			deployment.Status.AvailableReplicas == replicas, nil
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		if err := c.Get(ctx, key, daemonSet); err != nil {
+			return false, err
+		}
+		desired := daemonSet.Status.DesiredNumberScheduled
+		return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+			daemonSet.Status.UpdatedNumberScheduled == desired &&
+			daemonSet.Status.NumberAvailable == desired, nil
+	default:
+		return false, fmt.Errorf("unsupported workload kind %q", kind)
+	}
+}
+
+// observeProgressingDuration records how long the ClusterOperator has been Progressing, if the
+// Progressing condition is currently True. It is expected to be called right before the condition
+// is set back to False.
+func observeProgressingDuration(co *configv1.ClusterOperator, clk clock.PassiveClock) {
+	progressing := v1helpers.FindStatusCondition(co.Status.Conditions, configv1.OperatorProgressing)
+	if progressing == nil || progressing.Status != configv1.ConditionTrue {
+		return
+	}
+	clusterOperatorProgressingDuration.Observe(clk.Since(progressing.LastTransitionTime.Time).Seconds())
+}
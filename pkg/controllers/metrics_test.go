@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func histogramSampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+	metric := &dto.Metric{}
+	assert.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestRolloutTracker(t *testing.T) {
+	startTime := time.Now()
+
+	deployment := func(name string, available bool) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultManagedNamespace, Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1, AvailableReplicas: 2},
+		}
+		if available {
+			d.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+		}
+		return d
+	}
+
+	tc := []struct {
+		name           string
+		existing       []client.Object
+		started        []client.Object
+		expectTracked  []string
+		expectObserved map[string]uint64
+	}{{
+		name:     "Non workload objects are not tracked",
+		existing: []client.Object{},
+		started: []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: DefaultManagedNamespace}},
+		},
+	}, {
+		name:           "Available deployment rollout is recorded",
+		existing:       []client.Object{deployment("available", true)},
+		started:        []client.Object{deployment("available", true)},
+		expectObserved: map[string]uint64{"available": 1},
+	}, {
+		name:           "Progressing deployment rollout stays tracked",
+		existing:       []client.Object{deployment("progressing", false)},
+		started:        []client.Object{deployment("progressing", false)},
+		expectTracked:  []string{"progressing"},
+		expectObserved: map[string]uint64{"progressing": 0},
+	}, {
+		name:     "Deleted deployment is dropped",
+		existing: []client.Object{},
+		started:  []client.Object{deployment("deleted", true)},
+	}, {
+		name: "Available daemonset rollout is recorded",
+		existing: []client.Object{&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: DefaultManagedNamespace, Generation: 1},
+			Status:     appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+		}},
+		started: []client.Object{&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: DefaultManagedNamespace},
+		}},
+		expectObserved: map[string]uint64{"ds": 1},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			operandRolloutDuration.Reset()
+			fakeClock := clocktesting.NewFakePassiveClock(startTime)
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.existing...).Build()
+
+			tracker := &rolloutTracker{}
+			for _, obj := range tc.started {
+				tracker.start(obj, fakeClock.Now())
+			}
+
+			fakeClock.SetTime(startTime.Add(time.Minute))
+			assert.NoError(t, tracker.observe(context.TODO(), cl, fakeClock))
+
+			var tracked []string
+			for key := range tracker.started {
+				tracked = append(tracked, key.Name)
+			}
+			assert.ElementsMatch(t, tc.expectTracked, tracked)
+
+			for name, count := range tc.expectObserved {
+				kind := "Deployment"
+				if name == "ds" {
+					kind = "DaemonSet"
+				}
+				observer := operandRolloutDuration.WithLabelValues(kind, DefaultManagedNamespace, name)
+				assert.Equal(t, count, histogramSampleCount(t, observer))
+			}
+		})
+	}
+}
+
+func TestRolloutTrackerKeepsFirstStartTime(t *testing.T) {
+	startTime := time.Now()
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: DefaultManagedNamespace}}
+
+	tracker := &rolloutTracker{}
+	tracker.start(d, startTime)
+	tracker.start(d, startTime.Add(time.Minute))
+
+	assert.Equal(t, startTime, tracker.started[client.ObjectKeyFromObject(d)].startTime)
+}
+
+func TestObserveProgressingDuration(t *testing.T) {
+	startTime := time.Now()
+	fakeClock := clocktesting.NewFakePassiveClock(startTime.Add(time.Minute))
+
+	tc := []struct {
+		name        string
+		conditions  []configv1.ClusterOperatorStatusCondition
+		expectCount uint64
+	}{{
+		name: "No progressing condition is not recorded",
+	}, {
+		name: "Progressing False is not recorded",
+		conditions: []configv1.ClusterOperatorStatusCondition{{
+			Type:               configv1.OperatorProgressing,
+			Status:             configv1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(startTime),
+		}},
+	}, {
+		name: "Progressing True is recorded",
+		conditions: []configv1.ClusterOperatorStatusCondition{{
+			Type:               configv1.OperatorProgressing,
+			Status:             configv1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(startTime),
+		}},
+		expectCount: 1,
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			before := histogramSampleCount(t, clusterOperatorProgressingDuration)
+
+			co := &configv1.ClusterOperator{Status: configv1.ClusterOperatorStatus{Conditions: tc.conditions}}
+			observeProgressingDuration(co, fakeClock)
+
+			assert.Equal(t, tc.expectCount, histogramSampleCount(t, clusterOperatorProgressingDuration)-before)
+		})
+	}
+}
+
+func TestObserveApplyAttempt(t *testing.T) {
+	counterValue := func(t *testing.T, counter prometheus.Counter) float64 {
+		t.Helper()
+		metric := &dto.Metric{}
+		assert.NoError(t, counter.Write(metric))
+		return metric.GetCounter().GetValue()
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: DefaultManagedNamespace},
+	}
+	conflict := apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "foo", fmt.Errorf("stale"))
+
+	tc := []struct {
+		name            string
+		err             error
+		expectConflicts float64
+	}{{
+		name: "Successful attempt",
+	}, {
+		name:            "Conflicting attempt",
+		err:             conflict,
+		expectConflicts: 1,
+	}, {
+		name: "Failed attempt",
+		err:  fmt.Errorf("boom"),
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			resourceApplyDuration.Reset()
+			resourceApplyConflicts.Reset()
+
+			observeApplyAttempt(deployment, time.Second, tc.err)
+
+			assert.Equal(t, uint64(1), histogramSampleCount(t, resourceApplyDuration.WithLabelValues("apps", "v1", "Deployment")))
+			assert.Equal(t, tc.expectConflicts, counterValue(t, resourceApplyConflicts.WithLabelValues("apps", "v1", "Deployment")))
+		})
+	}
+
+	resourceApplyFailures.Reset()
+	observeApplyFailure(deployment)
+	assert.Equal(t, float64(1), counterValue(t, resourceApplyFailures.WithLabelValues("apps", "v1", "Deployment")))
+}
+
+func TestCheckCertificateExpiry(t *testing.T) {
+	gaugeValue := func(t *testing.T) float64 {
+		t.Helper()
+		metric := &dto.Metric{}
+		assert.NoError(t, trustBundleEarliestExpiry.Write(metric))
+		return metric.GetGauge().GetValue()
+	}
+
+	systemCA, err := os.ReadFile(systemCAValid)
+	assert.NoError(t, err)
+	awsCA, err := os.ReadFile(additionalAmazonCAPemPath)
+	assert.NoError(t, err)
+	trustBundle := append(append(append([]byte{}, awsCA...), '\n'), systemCA...)
+	earliestExpiry := time.Date(2038, time.January, 18, 23, 59, 59, 0, time.UTC)
+
+	tc := []struct {
+		name         string
+		now          time.Time
+		window       time.Duration
+		expectEvents int
+	}{{
+		name:   "No certificate expires within the window",
+		now:    time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC),
+		window: DefaultCertificateExpiryWarningWindow,
+	}, {
+		name:         "Certificates expire within the window",
+		now:          time.Date(2038, time.January, 1, 0, 0, 0, 0, time.UTC),
+		window:       DefaultCertificateExpiryWarningWindow,
+		expectEvents: 1,
+	}, {
+		name: "Warning is disabled",
+		now:  time.Date(2038, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			trustBundleEarliestExpiry.Set(0)
+			recorder := record.NewFakeRecorder(32)
+			reconciler := &TrustedCABundleReconciler{
+				ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+					Client:   fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+					Recorder: recorder,
+					Clock:    clocktesting.NewFakePassiveClock(tc.now),
+				},
+				CertificateExpiryWarningWindow: tc.window,
+			}
+
+			assert.NoError(t, reconciler.checkCertificateExpiry(context.TODO(), trustBundle))
+			assert.Equal(t, float64(earliestExpiry.Unix()), gaugeValue(t))
+			assert.Len(t, recorder.Events, tc.expectEvents)
+			if tc.expectEvents > 0 {
+				event := <-recorder.Events
+				assert.Contains(t, event, "TrustedCACertificateExpiring")
+				assert.Contains(t, event, "expires on 2038-01-18T23:59:59Z")
+				assert.NotContains(t, event, "Amazon")
+			}
+
+			// The event is not recorded again while the expiring certificates are unchanged.
+			assert.NoError(t, reconciler.checkCertificateExpiry(context.TODO(), trustBundle))
+			assert.Len(t, recorder.Events, 0)
+		})
+	}
+}
@@ -121,8 +121,11 @@ func calculateRelatedConfigsHash(ctx context.Context, cl runtimeclient.Client, n
 }
 
 // annotatePodSpecWithRelatedConfigsHash annotates pod template spec with a hash of related config maps and secrets content.
-func annotatePodSpecWithRelatedConfigsHash(ctx context.Context, cl runtimeclient.Client, ns string, spec *corev1.PodTemplateSpec) error {
+// Configs the operand reloads on its own are left out of the hash, so their changes do not roll the workload out.
+func annotatePodSpecWithRelatedConfigsHash(ctx context.Context, cl runtimeclient.Client, ns string, spec *corev1.PodTemplateSpec, reloadable sets.Set[string]) error {
 	sources := collectRelatedConfigSources(spec)
+	sources.ConfigMaps = sources.ConfigMaps.Difference(reloadable)
+	sources.Secrets = sources.Secrets.Difference(reloadable)
 	hash, err := calculateRelatedConfigsHash(ctx, cl, ns, sources)
 	if err != nil {
 		return fmt.Errorf("error calculating configuration hash: %w", err)
@@ -0,0 +1,77 @@
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReloadConfigsAnnotation can be set on a platform asset whose operand reloads some of its configuration on its own,
+// e.g. when the mounted cloud config file changes. The value is a comma separated list of names of config maps
+// and secrets referenced by the pod template. Changes of the listed configs do not roll the workload out,
+// the running pods are annotated with the hash of their content instead, which makes the kubelet refresh
+// the mounted volumes right away.
+const ReloadConfigsAnnotation = "operator.openshift.io/reload-configs"
+
+const reloadConfigHashAnnotation = "operator.openshift.io/reload-config-hash"
+
+// reloadableConfigs returns the names of the configs listed in the ReloadConfigsAnnotation of the object.
+func reloadableConfigs(obj runtimeclient.Object) sets.Set[string] {
+	names := sets.Set[string]{}
+	for _, name := range strings.Split(obj.GetAnnotations()[ReloadConfigsAnnotation], ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names.Insert(name)
+		}
+	}
+	return names
+}
+
+// signalConfigsReload annotates the running pods of the workload with a hash of the content of its reloadable
+// configs, so a change of these configs reaches the pods without a rollout.
+func signalConfigsReload(ctx context.Context, cl runtimeclient.Client, workload runtimeclient.Object, selector *metav1.LabelSelector, spec *corev1.PodTemplateSpec) error {
+	reloadable := reloadableConfigs(workload)
+	if reloadable.Len() == 0 {
+		return nil
+	}
+
+	sources := collectRelatedConfigSources(spec)
+	sources.ConfigMaps = sources.ConfigMaps.Intersection(reloadable)
+	sources.Secrets = sources.Secrets.Intersection(reloadable)
+	hash, err := calculateRelatedConfigsHash(ctx, cl, workload.GetNamespace(), sources)
+	if err != nil {
+		return fmt.Errorf("error calculating reloadable configuration hash: %w", err)
+	}
+
+	podSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("invalid pod selector: %w", err)
+	}
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, runtimeclient.InNamespace(workload.GetNamespace()), runtimeclient.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+		return fmt.Errorf("unable to list pods: %w", err)
+	}
+
+	var errList []error
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Annotations[reloadConfigHashAnnotation] == hash {
+			continue
+		}
+		patch := runtimeclient.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[reloadConfigHashAnnotation] = hash
+		if err := cl.Patch(ctx, pod, patch); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return errors.NewAggregate(errList)
+}
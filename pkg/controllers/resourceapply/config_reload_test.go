@@ -0,0 +1,126 @@
+package resourceapply
+
+import (
+	"context"
+	"testing"
+
+	gmg "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReloadableConfigs(t *testing.T) {
+	g := gmg.NewWithT(t)
+
+	obj := &appsv1.Deployment{}
+	g.Expect(reloadableConfigs(obj).Len()).To(gmg.Equal(0))
+
+	obj.Annotations = map[string]string{ReloadConfigsAnnotation: " cloud-conf, ,trusted-ca,"}
+	g.Expect(reloadableConfigs(obj)).To(gmg.Equal(sets.New("cloud-conf", "trusted-ca")))
+}
+
+func TestSignalConfigsReload(t *testing.T) {
+	labels := map[string]string{"app": "ccm"}
+
+	deployment := func(annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "ccm",
+				Namespace:   "test",
+				Annotations: annotations,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-conf"},
+							}}},
+							{Name: "ca", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "trusted-ca"},
+							}}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newObjects := func() []runtimeclient.Object {
+		return []runtimeclient.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cloud-conf", Namespace: "test"},
+				Data:       map[string]string{"cloud.conf": "foo"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "trusted-ca", Namespace: "test"},
+				Data:       map[string]string{"ca-bundle.crt": "bar"},
+			},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ccm-1", Namespace: "test", Labels: labels}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test"}},
+		}
+	}
+
+	getPod := func(g *gmg.WithT, cl runtimeclient.Client, name string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		g.Expect(cl.Get(context.TODO(), runtimeclient.ObjectKey{Namespace: "test", Name: name}, pod)).To(gmg.Succeed())
+		return pod
+	}
+
+	t.Run("pods are not annotated without reloadable configs", func(t *testing.T) {
+		g := gmg.NewWithT(t)
+		cl := fake.NewClientBuilder().WithObjects(newObjects()...).Build()
+
+		w := deployment(nil)
+		g.Expect(signalConfigsReload(context.TODO(), cl, w, w.Spec.Selector, &w.Spec.Template)).To(gmg.Succeed())
+		g.Expect(getPod(g, cl, "ccm-1").Annotations).NotTo(gmg.HaveKey(reloadConfigHashAnnotation))
+	})
+
+	t.Run("pods of the workload are annotated on reloadable config change", func(t *testing.T) {
+		g := gmg.NewWithT(t)
+		cl := fake.NewClientBuilder().WithObjects(newObjects()...).Build()
+
+		w := deployment(map[string]string{ReloadConfigsAnnotation: "cloud-conf"})
+		g.Expect(signalConfigsReload(context.TODO(), cl, w, w.Spec.Selector, &w.Spec.Template)).To(gmg.Succeed())
+		hash := getPod(g, cl, "ccm-1").Annotations[reloadConfigHashAnnotation]
+		g.Expect(hash).NotTo(gmg.BeEmpty())
+		g.Expect(getPod(g, cl, "other").Annotations).NotTo(gmg.HaveKey(reloadConfigHashAnnotation))
+
+		// Changes of the configs which are not reloadable are left to the rollout.
+		ca := &corev1.ConfigMap{}
+		g.Expect(cl.Get(context.TODO(), runtimeclient.ObjectKey{Namespace: "test", Name: "trusted-ca"}, ca)).To(gmg.Succeed())
+		ca.Data["ca-bundle.crt"] = "baz"
+		g.Expect(cl.Update(context.TODO(), ca)).To(gmg.Succeed())
+		g.Expect(signalConfigsReload(context.TODO(), cl, w, w.Spec.Selector, &w.Spec.Template)).To(gmg.Succeed())
+		g.Expect(getPod(g, cl, "ccm-1").Annotations[reloadConfigHashAnnotation]).To(gmg.Equal(hash))
+
+		config := &corev1.ConfigMap{}
+		g.Expect(cl.Get(context.TODO(), runtimeclient.ObjectKey{Namespace: "test", Name: "cloud-conf"}, config)).To(gmg.Succeed())
+		config.Data["cloud.conf"] = "baz"
+		g.Expect(cl.Update(context.TODO(), config)).To(gmg.Succeed())
+		g.Expect(signalConfigsReload(context.TODO(), cl, w, w.Spec.Selector, &w.Spec.Template)).To(gmg.Succeed())
+		g.Expect(getPod(g, cl, "ccm-1").Annotations[reloadConfigHashAnnotation]).NotTo(gmg.Equal(hash))
+	})
+
+	t.Run("reloadable configs are left out of the rollout hash", func(t *testing.T) {
+		g := gmg.NewWithT(t)
+		cl := fake.NewClientBuilder().WithObjects(newObjects()...).Build()
+
+		w := deployment(nil)
+		g.Expect(annotatePodSpecWithRelatedConfigsHash(context.TODO(), cl, "test", &w.Spec.Template, sets.New("cloud-conf"))).To(gmg.Succeed())
+		hash := w.Spec.Template.Annotations[configHashAnnotation]
+
+		config := &corev1.ConfigMap{}
+		g.Expect(cl.Get(context.TODO(), runtimeclient.ObjectKey{Namespace: "test", Name: "cloud-conf"}, config)).To(gmg.Succeed())
+		config.Data["cloud.conf"] = "baz"
+		g.Expect(cl.Update(context.TODO(), config)).To(gmg.Succeed())
+		g.Expect(annotatePodSpecWithRelatedConfigsHash(context.TODO(), cl, "test", &w.Spec.Template, sets.New("cloud-conf"))).To(gmg.Succeed())
+		g.Expect(w.Spec.Template.Annotations[configHashAnnotation]).To(gmg.Equal(hash))
+	})
+}
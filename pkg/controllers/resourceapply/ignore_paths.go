@@ -0,0 +1,76 @@
+package resourceapply
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IgnorePathsAnnotation can be set on a platform asset to list fields the operator should not fight over,
+// e.g. replicas managed by an external autoscaler. The value is a comma separated list of dot separated
+// field paths, such as "spec.replicas". Values of the listed fields are only set on creation, afterwards
+// whatever is set on the existing object is preserved. Paths can not point into lists.
+const IgnorePathsAnnotation = "operator.openshift.io/ignore-paths"
+
+// ignoredPaths returns the field paths listed in the IgnorePathsAnnotation of the object.
+func ignoredPaths(obj runtimeclient.Object) [][]string {
+	value := obj.GetAnnotations()[IgnorePathsAnnotation]
+	paths := [][]string{}
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(path, "."))
+	}
+	return paths
+}
+
+// preserveIgnoredPaths copies the values of the ignored fields from the existing object into the required one,
+// so these fields are neither considered a drift nor overwritten on update. Fields missing on the existing
+// object are removed from the required one.
+func preserveIgnoredPaths(existing, required runtimeclient.Object) error {
+	paths := ignoredPaths(required)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	existingContent, err := toUnstructuredContent(existing)
+	if err != nil {
+		return err
+	}
+	requiredContent, err := toUnstructuredContent(required)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		value, found, err := unstructured.NestedFieldNoCopy(existingContent, path...)
+		if err != nil {
+			return fmt.Errorf("unable to read ignored path %q: %w", strings.Join(path, "."), err)
+		}
+		if !found {
+			unstructured.RemoveNestedField(requiredContent, path...)
+			continue
+		}
+		if err := unstructured.SetNestedField(requiredContent, runtime.DeepCopyJSONValue(value), path...); err != nil {
+			return fmt.Errorf("unable to set ignored path %q: %w", strings.Join(path, "."), err)
+		}
+	}
+
+	if u, ok := required.(*unstructured.Unstructured); ok {
+		u.Object = requiredContent
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(requiredContent, required)
+}
+
+func toUnstructuredContent(obj runtimeclient.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return runtime.DeepCopyJSON(u.Object), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
@@ -0,0 +1,135 @@
+package resourceapply
+
+import (
+	"testing"
+
+	gmg "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestIgnoredPaths(t *testing.T) {
+	tcs := []struct {
+		name          string
+		annotations   map[string]string
+		expectedPaths [][]string
+	}{
+		{
+			name:          "no annotation",
+			expectedPaths: [][]string{},
+		},
+		{
+			name:          "single path",
+			annotations:   map[string]string{IgnorePathsAnnotation: "spec.replicas"},
+			expectedPaths: [][]string{{"spec", "replicas"}},
+		},
+		{
+			name:          "multiple paths with spaces and empty entries",
+			annotations:   map[string]string{IgnorePathsAnnotation: " spec.replicas, ,metadata.labels.foo,"},
+			expectedPaths: [][]string{{"spec", "replicas"}, {"metadata", "labels", "foo"}},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gmg.NewWithT(t)
+			obj := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			g.Expect(ignoredPaths(obj)).To(gmg.Equal(tc.expectedPaths))
+		})
+	}
+}
+
+func TestPreserveIgnoredPaths(t *testing.T) {
+	deployment := func(replicas *int32, paths string) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas:        replicas,
+				MinReadySeconds: 5,
+			},
+		}
+		if paths != "" {
+			d.Annotations = map[string]string{IgnorePathsAnnotation: paths}
+		}
+		return d
+	}
+
+	unstructuredObj := func(value interface{}, paths string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "ServiceMonitor",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "bar",
+			},
+			"spec": map[string]interface{}{
+				"jobLabel": "app",
+			},
+		}}
+		if value != nil {
+			g := gmg.NewWithT(t)
+			g.Expect(unstructured.SetNestedField(u.Object, value, "spec", "sampleLimit")).To(gmg.Succeed())
+		}
+		if paths != "" {
+			u.SetAnnotations(map[string]string{IgnorePathsAnnotation: paths})
+		}
+		return u
+	}
+
+	tcs := []struct {
+		name          string
+		existing      runtimeclient.Object
+		required      runtimeclient.Object
+		expected      runtimeclient.Object
+		expectedError string
+	}{
+		{
+			name:     "no ignored paths keeps required as is",
+			existing: deployment(ptr.To[int32](5), ""),
+			required: deployment(ptr.To[int32](2), ""),
+			expected: deployment(ptr.To[int32](2), ""),
+		},
+		{
+			name:     "existing value of ignored path is preserved",
+			existing: deployment(ptr.To[int32](5), ""),
+			required: deployment(ptr.To[int32](2), "spec.replicas"),
+			expected: deployment(ptr.To[int32](5), "spec.replicas"),
+		},
+		{
+			name:     "ignored path missing on existing object is removed",
+			existing: deployment(nil, ""),
+			required: deployment(ptr.To[int32](2), "spec.replicas"),
+			expected: deployment(nil, "spec.replicas"),
+		},
+		{
+			name:     "existing value of ignored path is preserved on unstructured object",
+			existing: unstructuredObj(int64(100), ""),
+			required: unstructuredObj(int64(10), "spec.sampleLimit"),
+			expected: unstructuredObj(int64(100), "spec.sampleLimit"),
+		},
+		{
+			name:          "ignored path pointing into a non map field fails",
+			existing:      deployment(ptr.To[int32](5), ""),
+			required:      deployment(ptr.To[int32](2), "spec.replicas.foo"),
+			expectedError: "unable to read ignored path \"spec.replicas.foo\": .spec.replicas.foo accessor error: 5 is of the type int64, expected map[string]interface{}",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gmg.NewWithT(t)
+
+			err := preserveIgnoredPaths(tc.existing, tc.required)
+			if tc.expectedError != "" {
+				g.Expect(err).To(gmg.MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(gmg.HaveOccurred())
+			g.Expect(tc.required).To(gmg.Equal(tc.expected))
+		})
+	}
+}
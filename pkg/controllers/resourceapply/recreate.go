@@ -0,0 +1,71 @@
+package resourceapply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	coreclientv1 "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const immutableFieldMessage = "field is immutable"
+
+// isImmutableFieldError returns true if the error was caused by an attempt to change an immutable field.
+// Such an update will never succeed, the object has to be recreated instead.
+func isImmutableFieldError(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if strings.Contains(cause.Message, immutableFieldMessage) {
+				return true
+			}
+		}
+	}
+	return strings.Contains(err.Error(), immutableFieldMessage)
+}
+
+// recreateResource deletes the existing object of the given kind and creates the required one in its place.
+// The required object is validated with a dry run creation first, so a malformed object
+// does not result in the existing one being removed.
+func recreateResource(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder,
+	kind string, existing, required coreclientv1.Object, reason string) (bool, error) {
+	klog.Infof("%s %s needs to be recreated: %s", kind, coreclientv1.ObjectKeyFromObject(required), reason)
+	recorder.Event(
+		existing, corev1.EventTypeNormal,
+		ResourceRecreatingEvent, fmt.Sprintf("Delete existing %s to recreate it with new parameters: %s", kind, reason),
+	)
+
+	// Perform dry run creation in order to validate the new object before deleting the existing one
+	requiredCopy := required.DeepCopyObject().(coreclientv1.Object)
+	requiredCopy.SetName(fmt.Sprintf("%s-dry-run", requiredCopy.GetName()))
+	requiredCopy.SetResourceVersion("")
+	dryRunOpts := &coreclientv1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
+	if err := client.Create(ctx, requiredCopy, dryRunOpts); err != nil {
+		recorder.Event(existing, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
+		return false, fmt.Errorf("new resource validation prior to old resource deletion failed: %v", err)
+	}
+
+	if err := client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+		recorder.Event(existing, corev1.EventTypeWarning, ResourceDeleteFailedEvent, err.Error())
+		return false, fmt.Errorf("old resource deletion failed: %v", err)
+	}
+
+	toCreate := required.DeepCopyObject().(coreclientv1.Object)
+	toCreate.SetResourceVersion("")
+	if err := client.Create(ctx, toCreate); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
+		return false, fmt.Errorf("%s recreation failed: %v", kind, err)
+	}
+	recorder.Event(required, corev1.EventTypeNormal, RecreateSuccessEvent, "Resource was successfully recreated")
+	return true, nil
+}
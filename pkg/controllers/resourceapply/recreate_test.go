@@ -0,0 +1,95 @@
+package resourceapply
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gmg "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsImmutableFieldError(t *testing.T) {
+	serviceGK := schema.GroupKind{Kind: "Service"}
+
+	tcs := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "nil error",
+		},
+		{
+			name: "not an invalid error",
+			err:  fmt.Errorf("field is immutable"),
+		},
+		{
+			name: "invalid error without immutable cause",
+			err: apierrors.NewInvalid(serviceGK, "foo", field.ErrorList{
+				field.Required(field.NewPath("spec", "ports"), ""),
+			}),
+		},
+		{
+			name: "invalid error with immutable cause",
+			err: apierrors.NewInvalid(serviceGK, "foo", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "clusterIP"), "None", "field is immutable"),
+			}),
+			expected: true,
+		},
+		{
+			name: "wrapped invalid error with immutable cause",
+			err: fmt.Errorf("update failed: %w", apierrors.NewInvalid(serviceGK, "foo", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+			})),
+			expected: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gmg.NewWithT(t)
+			g.Expect(isImmutableFieldError(tc.err)).To(gmg.Equal(tc.expected))
+		})
+	}
+}
+
+func TestRecreateResource(t *testing.T) {
+	g := gmg.NewWithT(t)
+
+	existing := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+		Spec:       corev1.ServiceSpec{ClusterIP: "None"},
+	}
+	required := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+		Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+	}
+
+	cl := fake.NewClientBuilder().WithObjects(existing).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	recreated, err := recreateResource(context.TODO(), cl, recorder, "service", existing, required, "field is immutable")
+	g.Expect(err).NotTo(gmg.HaveOccurred())
+	g.Expect(recreated).To(gmg.BeTrue())
+
+	got := &corev1.Service{}
+	g.Expect(cl.Get(context.TODO(), runtimeclient.ObjectKeyFromObject(required), got)).To(gmg.Succeed())
+	g.Expect(got.Spec.ClusterIP).To(gmg.Equal("10.0.0.1"))
+
+	// The dry run object must not be persisted
+	g.Expect(apierrors.IsNotFound(cl.Get(context.TODO(), runtimeclient.ObjectKeyFromObject(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-dry-run", Namespace: "bar"},
+	}), &corev1.Service{}))).To(gmg.BeTrue())
+
+	g.Expect(recorder.Events).To(gmg.Receive(gmg.ContainSubstring(ResourceRecreatingEvent)))
+	g.Expect(recorder.Events).To(gmg.Receive(gmg.ContainSubstring(RecreateSuccessEvent)))
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -30,10 +32,13 @@ import (
 // Inspired by https://github.com/openshift/library-go/tree/master/pkg/operator/resource/resourceapply
 
 const (
-	specHashAnnotation   = "operator.openshift.io/spec-hash"
+	specHashAnnotation = "operator.openshift.io/spec-hash"
+	// generationAnnotation was used to track the last written generation of workloads.
+	// It is no longer written and gets removed from existing objects on update.
 	generationAnnotation = "operator.openshift.io/generation"
 
-	ConfigCheckFailedEvent = "ConfigurationCheckFailed"
+	ConfigCheckFailedEvent  = "ConfigurationCheckFailed"
+	ConfigReloadFailedEvent = "ConfigurationReloadFailed"
 
 	ResourceCreateSuccessEvent = "ResourceCreateSuccess"
 	ResourceCreateFailedEvent  = "ResourceCreateFailed"
@@ -49,20 +54,23 @@ const (
 	ResourceDeleteFailedEvent = "ResourceDeleteFailed"
 )
 
-// setSpecHashAnnotation computes the hash of the provided spec and sets an annotation of the
-// hash on the provided ObjectMeta. This method is used internally by Apply<type> methods, and
-// is exposed to support testing with fake clients that need to know the mutated form of the
-// resource resulting from an Apply<type> call.
-func setSpecHashAnnotation(objMeta *metav1.ObjectMeta, spec interface{}) error {
-	jsonBytes, err := json.Marshal(spec)
+// setSpecHashAnnotation computes the hash of the provided desired content, e.g. a spec or the data
+// of a ConfigMap, and sets an annotation of the hash on the provided object. As the annotation is
+// a part of the required metadata, Apply<type> methods detect a change of the desired content by
+// comparing it with the hash stamped on the existing object. This method is exposed to support
+// testing with fake clients that need to know the mutated form of the resource resulting from an
+// Apply<type> call.
+func setSpecHashAnnotation(obj metav1.Object, content interface{}) error {
+	jsonBytes, err := json.Marshal(content)
 	if err != nil {
 		return err
 	}
-	specHash := fmt.Sprintf("%x", sha256.Sum256(jsonBytes))
-	if objMeta.Annotations == nil {
-		objMeta.Annotations = map[string]string{}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
-	objMeta.Annotations[specHashAnnotation] = specHash
+	annotations[specHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(jsonBytes))
+	obj.SetAnnotations(annotations)
 	return nil
 }
 
@@ -91,6 +99,8 @@ func ApplyResource(ctx context.Context, client coreclientv1.Client, recorder rec
 		return applyValidatingAdmissionPolicyBinding(ctx, client, recorder, t)
 	case *corev1.Service:
 		return applyService(ctx, client, recorder, t)
+	case *unstructured.Unstructured:
+		return applyUnstructured(ctx, client, recorder, t)
 	default:
 		return false, fmt.Errorf("unhandled type %T", resource)
 	}
@@ -98,6 +108,11 @@ func ApplyResource(ctx context.Context, client coreclientv1.Client, recorder rec
 
 func applyConfigMap(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *corev1.ConfigMap) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, []interface{}{required.Data, required.BinaryData}); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
+
 	existing := &corev1.ConfigMap{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(requiredOriginal), existing)
 	if apierrors.IsNotFound(err) {
@@ -162,10 +177,14 @@ func applyConfigMap(ctx context.Context, client coreclientv1.Client, recorder re
 
 func applyDeployment(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *appsv1.Deployment) (bool, error) {
 	required := requiredOriginal.DeepCopy()
-	if err := annotatePodSpecWithRelatedConfigsHash(ctx, client, required.Namespace, &required.Spec.Template); err != nil {
+	if err := annotatePodSpecWithRelatedConfigsHash(ctx, client, required.Namespace, &required.Spec.Template, reloadableConfigs(required)); err != nil {
 		klog.V(3).Infof("Can not check related configs for %s/%s: %v", required.GetObjectKind(), required.GetName(), err)
 		recorder.Event(required, corev1.EventTypeWarning, ConfigCheckFailedEvent, err.Error())
 	}
+	if err := signalConfigsReload(ctx, client, required, required.Spec.Selector, &required.Spec.Template); err != nil {
+		klog.V(3).Infof("Can not signal configs reload for %s/%s: %v", required.GetObjectKind(), required.GetName(), err)
+		recorder.Event(required, corev1.EventTypeWarning, ConfigReloadFailedEvent, err.Error())
+	}
 	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Spec); err != nil {
 		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
 		return false, err
@@ -174,7 +193,6 @@ func applyDeployment(ctx context.Context, client coreclientv1.Client, recorder r
 	existing := &appsv1.Deployment{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
 	if apierrors.IsNotFound(err) {
-		required.Annotations[generationAnnotation] = "1"
 		if err := client.Create(ctx, required); err != nil {
 			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
 			return false, err
@@ -187,16 +205,20 @@ func applyDeployment(ctx context.Context, client coreclientv1.Client, recorder r
 		return false, err
 	}
 
+	if err := preserveIgnoredPaths(existing, required); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
+		return false, err
+	}
+
 	modified := ptr.To[bool](false)
 	existingCopy := existing.DeepCopy()
 
-	expectedGeneration := ""
-	if _, ok := existingCopy.Annotations[generationAnnotation]; ok {
-		expectedGeneration = existingCopy.Annotations[generationAnnotation]
-	}
-
+	// The spec hash annotation is a part of the required metadata, so any change of the desired spec,
+	// including the related configs hashes, is caught here by comparing it with the hash stamped on
+	// the existing object. The specs are not compared, fields defaulted by the API server, e.g. host
+	// ports of host network pods, would otherwise be reported as changes on every apply.
 	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, required.ObjectMeta)
-	if !*modified && expectedGeneration == fmt.Sprintf("%x", existingCopy.GetGeneration()) {
+	if !*modified {
 		return false, nil
 	}
 
@@ -207,41 +229,17 @@ func applyDeployment(ctx context.Context, client coreclientv1.Client, recorder r
 		needRecreate = true
 	}
 	if needRecreate {
-		klog.Infof("Deployment need to be recreated with new parameters")
-		recorder.Event(
-			existing, corev1.EventTypeNormal,
-			ResourceRecreatingEvent, "Delete existing deployment to recreate it with new parameters",
-		)
-		// Perform dry run creation in order to validate deployment before deleting existing one
-		requiredCopy := required.DeepCopy()
-		requiredCopy.Name = fmt.Sprintf("%s-dry-run", requiredCopy.Name)
-		dryRunOpts := &coreclientv1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
-		if err := client.Create(ctx, requiredCopy, dryRunOpts); err != nil {
-			recorder.Event(existing, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
-			return false, fmt.Errorf("new resource validation prior to old resource deletion failed: %v", err)
-		}
-
-		if err := client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
-			recorder.Event(existing, corev1.EventTypeWarning, ResourceDeleteFailedEvent, err.Error())
-			return false, fmt.Errorf("old resource deletion failed: %v", err)
-		}
-
-		required.Annotations[generationAnnotation] = "1"
-		if err := client.Create(ctx, required); err != nil {
-			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
-			return false, fmt.Errorf("deployment recreation failed: %v", err)
-		}
-		recorder.Event(required, corev1.EventTypeNormal, RecreateSuccessEvent, "Resource was successfully recreated")
-		return true, nil
+		return recreateResource(ctx, client, recorder, "deployment", existing, required, "pod selector was changed")
 	}
 
 	// at this point we know that we're going to perform a write.  We're just trying to get the object correct
 	toWrite := existingCopy // shallow copy so the code reads easier
 	toWrite.Spec = *required.Spec.DeepCopy()
+	delete(toWrite.Annotations, generationAnnotation)
 
-	toWrite.Annotations[generationAnnotation] = fmt.Sprintf("%x", existingCopy.GetGeneration()+1)
-
-	if err := client.Update(ctx, toWrite); err != nil {
+	if err := client.Update(ctx, toWrite); isImmutableFieldError(err) {
+		return recreateResource(ctx, client, recorder, "deployment", existing, required, err.Error())
+	} else if err != nil {
 		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
 		return false, err
 	}
@@ -251,10 +249,14 @@ func applyDeployment(ctx context.Context, client coreclientv1.Client, recorder r
 
 func applyDaemonSet(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *appsv1.DaemonSet) (bool, error) {
 	required := requiredOriginal.DeepCopy()
-	if err := annotatePodSpecWithRelatedConfigsHash(ctx, client, required.Namespace, &required.Spec.Template); err != nil {
+	if err := annotatePodSpecWithRelatedConfigsHash(ctx, client, required.Namespace, &required.Spec.Template, reloadableConfigs(required)); err != nil {
 		klog.V(3).Infof("Can not check related configs for %s/%s: %v", required.GetObjectKind(), required.GetName(), err)
 		recorder.Event(required, corev1.EventTypeWarning, ConfigCheckFailedEvent, err.Error())
 	}
+	if err := signalConfigsReload(ctx, client, required, required.Spec.Selector, &required.Spec.Template); err != nil {
+		klog.V(3).Infof("Can not signal configs reload for %s/%s: %v", required.GetObjectKind(), required.GetName(), err)
+		recorder.Event(required, corev1.EventTypeWarning, ConfigReloadFailedEvent, err.Error())
+	}
 	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Spec); err != nil {
 		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
 		return false, err
@@ -263,7 +265,6 @@ func applyDaemonSet(ctx context.Context, client coreclientv1.Client, recorder re
 	existing := &appsv1.DaemonSet{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
 	if apierrors.IsNotFound(err) {
-		required.Annotations[generationAnnotation] = "1"
 		if err := client.Create(ctx, required); err != nil {
 			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
 			return false, err
@@ -276,16 +277,20 @@ func applyDaemonSet(ctx context.Context, client coreclientv1.Client, recorder re
 		return false, err
 	}
 
+	if err := preserveIgnoredPaths(existing, required); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
+		return false, err
+	}
+
 	modified := ptr.To[bool](false)
 	existingCopy := existing.DeepCopy()
 
-	expectedGeneration := ""
-	if _, ok := existingCopy.Annotations[generationAnnotation]; ok {
-		expectedGeneration = existingCopy.Annotations[generationAnnotation]
-	}
-
+	// The spec hash annotation is a part of the required metadata, so any change of the desired spec,
+	// including the related configs hashes, is caught here by comparing it with the hash stamped on
+	// the existing object. The specs are not compared, fields defaulted by the API server, e.g. host
+	// ports of host network pods, would otherwise be reported as changes on every apply.
 	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, required.ObjectMeta)
-	if !*modified && expectedGeneration == fmt.Sprintf("%x", existingCopy.GetGeneration()) {
+	if !*modified {
 		return false, nil
 	}
 
@@ -296,41 +301,17 @@ func applyDaemonSet(ctx context.Context, client coreclientv1.Client, recorder re
 		needRecreate = true
 	}
 	if needRecreate {
-		klog.Infof("DaemonSet need to be recreated with new parameters")
-		recorder.Event(
-			existing, corev1.EventTypeNormal,
-			ResourceRecreatingEvent, "Delete existing daemonset to recreate it with new parameters",
-		)
-		// Perform dry run creation in order to validate ds before deleting existing one
-		requiredCopy := required.DeepCopy()
-		requiredCopy.Name = fmt.Sprintf("%s-dry-run", requiredCopy.Name)
-		dryRunOpts := &coreclientv1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
-		if err := client.Create(ctx, requiredCopy, dryRunOpts); err != nil {
-			recorder.Event(existing, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
-			return false, fmt.Errorf("new resource validation prior to old resource deletion failed: %v", err)
-		}
-
-		if err := client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
-			recorder.Event(existing, corev1.EventTypeWarning, ResourceDeleteFailedEvent, err.Error())
-			return false, fmt.Errorf("old resource deletion failed: %v", err)
-		}
-
-		required.Annotations[generationAnnotation] = "1"
-		if err := client.Create(ctx, required); err != nil {
-			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
-			return false, fmt.Errorf("ds recreation failed: %v", err)
-		}
-		recorder.Event(required, corev1.EventTypeNormal, RecreateSuccessEvent, "Resource was successfully recreated")
-		return true, nil
+		return recreateResource(ctx, client, recorder, "daemonset", existing, required, "pod selector was changed")
 	}
 
 	// at this point we know that we're going to perform a write.  We're just trying to get the object correct
 	toWrite := existingCopy // shallow copy so the code reads easier
 	toWrite.Spec = *required.Spec.DeepCopy()
+	delete(toWrite.Annotations, generationAnnotation)
 
-	toWrite.Annotations[generationAnnotation] = fmt.Sprintf("%x", existingCopy.GetGeneration()+1)
-
-	if err := client.Update(ctx, toWrite); err != nil {
+	if err := client.Update(ctx, toWrite); isImmutableFieldError(err) {
+		return recreateResource(ctx, client, recorder, "daemonset", existing, required, err.Error())
+	} else if err != nil {
 		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
 		return false, err
 	}
@@ -340,6 +321,10 @@ func applyDaemonSet(ctx context.Context, client coreclientv1.Client, recorder re
 
 func applyPodDisruptionBudget(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *policyv1.PodDisruptionBudget) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Spec); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &policyv1.PodDisruptionBudget{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
@@ -380,6 +365,10 @@ func applyPodDisruptionBudget(ctx context.Context, client coreclientv1.Client, r
 
 func applyRole(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *rbacv1.Role) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Rules); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &rbacv1.Role{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
@@ -418,6 +407,10 @@ func applyRole(ctx context.Context, client coreclientv1.Client, recorder record.
 
 func applyClusterRole(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *rbacv1.ClusterRole) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Rules); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &rbacv1.ClusterRole{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
@@ -457,6 +450,10 @@ func applyClusterRole(ctx context.Context, client coreclientv1.Client, recorder
 
 func applyRoleBinding(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *rbacv1.RoleBinding) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, []interface{}{required.Subjects, required.RoleRef}); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &rbacv1.RoleBinding{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
@@ -514,6 +511,10 @@ func applyRoleBinding(ctx context.Context, client coreclientv1.Client, recorder
 
 func applyClusterRoleBinding(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder, requiredOriginal *rbacv1.ClusterRoleBinding) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, []interface{}{required.Subjects, required.RoleRef}); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &rbacv1.ClusterRoleBinding{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
@@ -572,11 +573,14 @@ func applyClusterRoleBinding(ctx context.Context, client coreclientv1.Client, re
 func applyValidatingAdmissionPolicy(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder,
 	requiredOriginal *admissionregistrationv1.ValidatingAdmissionPolicy) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Spec); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &admissionregistrationv1.ValidatingAdmissionPolicy{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(requiredOriginal), existing)
 	if apierrors.IsNotFound(err) {
-		required := requiredOriginal.DeepCopy()
 		if err := client.Create(ctx, required); err != nil {
 			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
 			return false, fmt.Errorf("validatingadmissionpolicy creation failed: %v", err)
@@ -614,11 +618,14 @@ func applyValidatingAdmissionPolicy(ctx context.Context, client coreclientv1.Cli
 func applyValidatingAdmissionPolicyBinding(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder,
 	requiredOriginal *admissionregistrationv1.ValidatingAdmissionPolicyBinding) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Spec); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(requiredOriginal), existing)
 	if apierrors.IsNotFound(err) {
-		required := requiredOriginal.DeepCopy()
 		if err := client.Create(ctx, required); err != nil {
 			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
 			return false, fmt.Errorf("validatingadmissionpolicybinding creation failed: %v", err)
@@ -656,11 +663,14 @@ func applyValidatingAdmissionPolicyBinding(ctx context.Context, client coreclien
 func applyService(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder,
 	requiredOriginal *corev1.Service) (bool, error) {
 	required := requiredOriginal.DeepCopy()
+	if err := setSpecHashAnnotation(&required.ObjectMeta, required.Spec); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
 
 	existing := &corev1.Service{}
 	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(requiredOriginal), existing)
 	if apierrors.IsNotFound(err) {
-		required := requiredOriginal.DeepCopy()
 		if err := client.Create(ctx, required); err != nil {
 			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
 			return false, fmt.Errorf("service creation failed: %v", err)
@@ -697,7 +707,98 @@ func applyService(ctx context.Context, client coreclientv1.Client, recorder reco
 
 	klog.V(2).Infof("Service %q changes: %v", required.GetNamespace()+"/"+required.GetName(), resourceapply.JSONPatchNoError(existing, toWrite))
 
-	if err := client.Update(ctx, existingCopy); err != nil {
+	if err := client.Update(ctx, existingCopy); isImmutableFieldError(err) {
+		return recreateResource(ctx, client, recorder, "service", existing, required, err.Error())
+	} else if err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
+		return false, err
+	}
+	recorder.Event(required, corev1.EventTypeNormal, ResourceUpdateSuccessEvent, "Resource was successfully updated")
+
+	return true, nil
+}
+
+// unstructuredNonContentFields lists top level fields of unstructured objects which are not
+// considered as a desired content during apply.
+var unstructuredNonContentFields = map[string]struct{}{
+	"apiVersion": {},
+	"kind":       {},
+	"metadata":   {},
+	"status":     {},
+}
+
+// unstructuredContent returns top level fields of the unstructured object which are considered as a desired content.
+func unstructuredContent(obj *unstructured.Unstructured) map[string]interface{} {
+	content := map[string]interface{}{}
+	for field, value := range obj.Object {
+		if _, ok := unstructuredNonContentFields[field]; !ok {
+			content[field] = value
+		}
+	}
+	return content
+}
+
+// applyUnstructured applies objects of kinds which are not known to the operator scheme, such as
+// ServiceMonitors or provider specific custom resources. All top level fields, except metadata and
+// status, are treated as desired content, and fields which are set only on the existing object are left
+// untouched.
+func applyUnstructured(ctx context.Context, client coreclientv1.Client, recorder record.EventRecorder,
+	requiredOriginal *unstructured.Unstructured) (bool, error) {
+	required := requiredOriginal.DeepCopy()
+	kind := required.GroupVersionKind().Kind
+	if err := setSpecHashAnnotation(required, unstructuredContent(required)); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceCreateOrUpdateFailedEvent, err.Error())
+		return false, err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(required.GroupVersionKind())
+	err := client.Get(ctx, coreclientv1.ObjectKeyFromObject(required), existing)
+	if apierrors.IsNotFound(err) {
+		if err := client.Create(ctx, required); err != nil {
+			recorder.Event(required, corev1.EventTypeWarning, ResourceCreateFailedEvent, err.Error())
+			return false, fmt.Errorf("%s creation failed: %v", kind, err)
+		}
+		recorder.Event(required, corev1.EventTypeNormal, ResourceCreateSuccessEvent, "Resource was successfully created")
+		return true, nil
+	} else if err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
+		return false, fmt.Errorf("failed to get %s for update: %v", kind, err)
+	}
+
+	if err := preserveIgnoredPaths(existing, required); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
+		return false, err
+	}
+
+	modified := false
+	existingCopy := existing.DeepCopy()
+
+	if err := resourcemerge.EnsureObjectMetaForUnstructured(&modified, existingCopy, required); err != nil {
+		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
+		return false, fmt.Errorf("failed to merge %s metadata: %v", kind, err)
+	}
+
+	contentSame := true
+	for field, requiredValue := range required.Object {
+		if _, ok := unstructuredNonContentFields[field]; ok {
+			continue
+		}
+		if !equality.Semantic.DeepDerivative(requiredValue, existingCopy.Object[field]) {
+			contentSame = false
+			existingCopy.Object[field] = requiredValue
+		}
+	}
+
+	if contentSame && !modified {
+		return false, nil
+	}
+
+	klog.V(2).Infof("%s %q changes: %v", kind, required.GetNamespace()+"/"+required.GetName(), resourceapply.JSONPatchNoError(existing, existingCopy))
+
+	if err := client.Update(ctx, existingCopy); isImmutableFieldError(err) {
+		return recreateResource(ctx, client, recorder, strings.ToLower(kind), existing, required, err.Error())
+	} else if err != nil {
 		recorder.Event(required, corev1.EventTypeWarning, ResourceUpdateFailedEvent, err.Error())
 		return false, err
 	}
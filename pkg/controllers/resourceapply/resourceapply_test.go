@@ -13,6 +13,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
@@ -71,12 +72,12 @@ var _ = Describe("applyConfigMap", func() {
 		),
 		Entry("When an extra label is present it is not updated",
 			applyConfigMapArguments{
-				existing: &corev1.ConfigMap{
+				existing: configMapWithSpecHash(&corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:   "foo",
 						Labels: map[string]string{"extra": "leave-alone"},
 					},
-				},
+				}),
 				input: &corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "foo",
@@ -146,9 +147,36 @@ var _ = Describe("applyConfigMap", func() {
 				expectModified: true,
 			},
 		),
+		Entry("When the data hash is stale it is updated",
+			applyConfigMapArguments{
+				existing: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "foo",
+						Annotations: map[string]string{specHashAnnotation: "stale"},
+					},
+					Data: map[string]string{
+						"configmap": "value",
+					},
+				},
+				input: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+					Data: map[string]string{
+						"configmap": "value",
+					},
+				},
+				expectModified: true,
+			},
+		),
 	)
 })
 
+func configMapWithSpecHash(cm *corev1.ConfigMap) *corev1.ConfigMap {
+	_ = setSpecHashAnnotation(&cm.ObjectMeta, []interface{}{cm.Data, cm.BinaryData})
+	return cm
+}
+
 type deploymentSupplier func(context.Context, appsclientv1.Client, string) *appsv1.Deployment
 
 type applyDeploymentArguments struct {
@@ -241,7 +269,7 @@ var _ = Describe("applyDeployment", func() {
 				expectedFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
 					w := workloadDeployment(ctx, client, namespace)
 					w.Spec.Template.Finalizers = []string{"newFinalizer"}
-					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template)
+					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template, nil)
 					_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
 					return w
 				},
@@ -266,6 +294,59 @@ var _ = Describe("applyDeployment", func() {
 				expectUpdate: true,
 			},
 		),
+		Entry("When the deployment spec hash is stale it is updated",
+			applyDeploymentArguments{
+				desiredFn: workloadDeployment,
+				actualFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
+					w := workloadDeployment(ctx, client, namespace)
+					w.Spec.Replicas = ptr.To[int32](5)
+					_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
+					return w
+				},
+				expectedFn:   workloadDeploymentWithDefaultSpecHash,
+				expectError:  false,
+				expectUpdate: true,
+			},
+		),
+		Entry("When the deployment spec hash is up to date it is not updated",
+			applyDeploymentArguments{
+				desiredFn: workloadDeployment,
+				actualFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
+					w := workloadDeploymentWithDefaultSpecHash(ctx, client, namespace)
+					w.Spec.Replicas = ptr.To[int32](5)
+					return w
+				},
+				expectedFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
+					w := workloadDeploymentWithDefaultSpecHash(ctx, client, namespace)
+					w.Spec.Replicas = ptr.To[int32](5)
+					return w
+				},
+				expectError:  false,
+				expectUpdate: false,
+			},
+		),
+		Entry("When the deployment spec drifted only in ignored paths it is not updated",
+			applyDeploymentArguments{
+				desiredFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
+					w := workloadDeployment(ctx, client, namespace)
+					w.Annotations[IgnorePathsAnnotation] = "spec.replicas"
+					return w
+				},
+				actualFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
+					w := workloadDeploymentWithDefaultSpecHash(ctx, client, namespace)
+					w.Annotations[IgnorePathsAnnotation] = "spec.replicas"
+					w.Spec.Replicas = ptr.To[int32](5)
+					return w
+				},
+				expectedFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
+					w := workloadDeploymentWithDefaultSpecHash(ctx, client, namespace)
+					w.Spec.Replicas = ptr.To[int32](5)
+					return w
+				},
+				expectError:  false,
+				expectUpdate: false,
+			},
+		),
 		Entry("When the deployment is updated due to a change in the Annotations field",
 			applyDeploymentArguments{
 				desiredFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
@@ -285,6 +366,28 @@ var _ = Describe("applyDeployment", func() {
 		),
 	)
 
+	It("Does not update a host network deployment defaulted by the API server when applied again", func() {
+		eventRecorder := record.NewFakeRecorder(1000)
+		desiredDeployment := workloadDeployment(ctx, k8sClient, namespaceName)
+		desiredDeployment.Spec.Template.Spec.HostNetwork = true
+		desiredDeployment.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{{
+			Name:          "https",
+			ContainerPort: 10258,
+		}}
+
+		updated, err := applyDeployment(ctx, k8sClient, eventRecorder, desiredDeployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated).To(BeTrue(), "expect deployment to be created")
+
+		createdDeployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, appsclientv1.ObjectKeyFromObject(desiredDeployment), createdDeployment)).To(Succeed())
+		Expect(createdDeployment.Spec.Template.Spec.Containers[0].Ports[0].HostPort).To(BeEquivalentTo(10258), "expect the host port to be defaulted")
+
+		updated, err = applyDeployment(ctx, k8sClient, eventRecorder, desiredDeployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated).To(BeFalse(), "expect deployment not to be updated")
+	})
+
 	DescribeTable("Recreates deployment after selector change when expected",
 		func(args applyDeploymentArguments) {
 			eventRecorder := record.NewFakeRecorder(1000)
@@ -342,7 +445,7 @@ var _ = Describe("applyDeployment", func() {
 						},
 					}
 					w.Spec.Template.Labels = map[string]string{"bar": "baz"}
-					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template)
+					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template, nil)
 					_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
 					return w
 				},
@@ -552,7 +655,7 @@ var _ = Describe("applyDaemonSet", func() {
 				expectedFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.DaemonSet {
 					w := workloadDaemonSet(ctx, client, namespace)
 					w.Spec.Template.Finalizers = []string{"newFinalizer"}
-					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template)
+					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template, nil)
 					_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
 					return w
 				},
@@ -577,6 +680,20 @@ var _ = Describe("applyDaemonSet", func() {
 				expectUpdate: true,
 			},
 		),
+		Entry("When its spec hash is stale it is updated",
+			applyDaemonSetArguments{
+				desiredFn: workloadDaemonSet,
+				actualFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.DaemonSet {
+					w := workloadDaemonSet(ctx, client, namespace)
+					w.Spec.Template.Spec.Containers[0].Image = "docker-registry/old-img"
+					_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
+					return w
+				},
+				expectedFn:   workloadDaemonSetWithDefaultSpecHash,
+				expectError:  false,
+				expectUpdate: true,
+			},
+		),
 		Entry("When there is a change in the annotations field it is updated",
 			applyDaemonSetArguments{
 				desiredFn: func(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.DaemonSet {
@@ -653,7 +770,7 @@ var _ = Describe("applyDaemonSet", func() {
 						},
 					}
 					w.Spec.Template.Labels = map[string]string{"bar": "baz"}
-					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template)
+					_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template, nil)
 					_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
 					return w
 				},
@@ -824,6 +941,7 @@ var _ = Describe("applyPodDisruptionBudget", func() {
 				existingFn: func(namespace string) *policyv1.PodDisruptionBudget {
 					pdb := podDisruptionBudget(namespace)
 					pdb.Labels = map[string]string{"bar": "baz"}
+					_ = setSpecHashAnnotation(&pdb.ObjectMeta, pdb.Spec)
 					return pdb
 				},
 				expectModified: false,
@@ -856,9 +974,146 @@ var _ = Describe("applyPodDisruptionBudget", func() {
 				expectModified: true,
 			},
 		),
+		Entry("When the spec hash is stale it is updated",
+			applyPodDisruptionBudgetArguments{
+				inputFn: podDisruptionBudget,
+				existingFn: func(namespace string) *policyv1.PodDisruptionBudget {
+					pdb := podDisruptionBudget(namespace)
+					pdb.Annotations = map[string]string{specHashAnnotation: "stale"}
+					return pdb
+				},
+				expectModified: true,
+			},
+		),
 	)
 })
 
+type unstructuredSupplier func(string) *unstructured.Unstructured
+
+type applyUnstructuredArguments struct {
+	inputFn        unstructuredSupplier
+	existingFn     unstructuredSupplier
+	expectModified bool
+}
+
+var _ = Describe("applyUnstructured", func() {
+	var namespaceName string
+
+	BeforeEach(func() {
+		By("Setting up a namespace for the test")
+		ns := &corev1.Namespace{}
+		ns.SetGenerateName(namespaceNamePrefix)
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		namespaceName = ns.GetName()
+	})
+
+	AfterEach(func() {
+		testutils.CleanupResources(Default, ctx, cfg, k8sClient, namespaceName,
+			&corev1.ConfigMap{},
+		)
+	})
+
+	DescribeTable("Updates unstructured object when expected",
+		func(args applyUnstructuredArguments) {
+			recorder := record.NewFakeRecorder(1000)
+
+			if args.existingFn != nil {
+				existing := args.existingFn(namespaceName)
+				Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			}
+
+			input := args.inputFn(namespaceName)
+			actualModified, err := ApplyResource(ctx, k8sClient, recorder, input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args.expectModified).To(BeEquivalentTo(actualModified), "Resource was modified")
+
+			applied := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, appsclientv1.ObjectKeyFromObject(input), applied)).To(Succeed())
+			Expect(applied.Data).To(HaveKeyWithValue("foo", "bar"))
+		},
+		Entry("When it does not exist it is created",
+			applyUnstructuredArguments{
+				inputFn:        unstructuredConfigMap,
+				existingFn:     nil,
+				expectModified: true,
+			},
+		),
+		Entry("When it is up to date it is not updated",
+			applyUnstructuredArguments{
+				inputFn:        unstructuredConfigMap,
+				existingFn:     unstructuredConfigMapWithSpecHash,
+				expectModified: false,
+			},
+		),
+		Entry("When there is an extra field on the existing object it is not updated",
+			applyUnstructuredArguments{
+				inputFn: unstructuredConfigMap,
+				existingFn: func(namespace string) *unstructured.Unstructured {
+					u := unstructuredConfigMapWithSpecHash(namespace)
+					Expect(unstructured.SetNestedField(u.Object, "baz", "data", "bar")).To(Succeed())
+					return u
+				},
+				expectModified: false,
+			},
+		),
+		Entry("When there is a content mismatch it is updated",
+			applyUnstructuredArguments{
+				inputFn: unstructuredConfigMap,
+				existingFn: func(namespace string) *unstructured.Unstructured {
+					u := unstructuredConfigMap(namespace)
+					Expect(unstructured.SetNestedField(u.Object, "baz", "data", "foo")).To(Succeed())
+					return u
+				},
+				expectModified: true,
+			},
+		),
+		Entry("When a label is missing it is updated",
+			applyUnstructuredArguments{
+				inputFn: func(namespace string) *unstructured.Unstructured {
+					u := unstructuredConfigMap(namespace)
+					u.SetLabels(map[string]string{"new": "merge"})
+					return u
+				},
+				existingFn:     unstructuredConfigMap,
+				expectModified: true,
+			},
+		),
+		Entry("When the content hash is stale it is updated",
+			applyUnstructuredArguments{
+				inputFn: unstructuredConfigMap,
+				existingFn: func(namespace string) *unstructured.Unstructured {
+					u := unstructuredConfigMap(namespace)
+					u.SetAnnotations(map[string]string{specHashAnnotation: "stale"})
+					return u
+				},
+				expectModified: true,
+			},
+		),
+	)
+})
+
+func unstructuredConfigMap(namespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": namespace,
+			},
+			"data": map[string]interface{}{
+				"foo": "bar",
+			},
+		},
+	}
+}
+
+func unstructuredConfigMapWithSpecHash(namespace string) *unstructured.Unstructured {
+	u := unstructuredConfigMap(namespace)
+	_ = setSpecHashAnnotation(u, unstructuredContent(u))
+	return u
+}
+
 func workloadDeployment(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -866,13 +1121,11 @@ func workloadDeployment(ctx context.Context, client appsclientv1.Client, namespa
 			APIVersion: "apps/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "apiserver",
-			Namespace: namespace,
-			Labels:    map[string]string{},
-			Annotations: map[string]string{
-				generationAnnotation: "1",
-			},
-			Generation: 1,
+			Name:        "apiserver",
+			Namespace:   namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+			Generation:  1,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: ptr.To[int32](3),
@@ -904,7 +1157,7 @@ func workloadDeployment(ctx context.Context, client appsclientv1.Client, namespa
 func workloadDeploymentWithDefaultSpecHash(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.Deployment {
 	w := workloadDeployment(ctx, client, namespace)
 	// Apply the same hash calculation logic used in production code
-	_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template)
+	_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template, nil)
 	_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
 	return w
 }
@@ -960,13 +1213,11 @@ func workloadDaemonSet(ctx context.Context, client appsclientv1.Client, namespac
 			APIVersion: "apps/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "apiserver",
-			Namespace: namespace,
-			Labels:    map[string]string{},
-			Annotations: map[string]string{
-				generationAnnotation: "1",
-			},
-			Generation: 1,
+			Name:        "apiserver",
+			Namespace:   namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+			Generation:  1,
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{
@@ -995,7 +1246,7 @@ func workloadDaemonSet(ctx context.Context, client appsclientv1.Client, namespac
 func workloadDaemonSetWithDefaultSpecHash(ctx context.Context, client appsclientv1.Client, namespace string) *appsv1.DaemonSet {
 	w := workloadDaemonSet(ctx, client, namespace)
 	// Apply the same hash calculation logic used in production code
-	_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template)
+	_ = annotatePodSpecWithRelatedConfigsHash(ctx, client, w.Namespace, &w.Spec.Template, nil)
 	_ = setSpecHashAnnotation(&w.ObjectMeta, w.Spec)
 	return w
 }
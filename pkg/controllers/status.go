@@ -26,6 +26,13 @@ const (
 	ReasonSyncing             = "SyncingResources"
 	ReasonSyncFailed          = "SyncingFailed"
 	ReasonPlatformTechPreview = "PlatformTechPreview"
+	ReasonPaused              = "Paused"
+	ReasonNoCloudProvider     = "NoCloudProvider"
+	ReasonSyncDisabled        = "SyncDisabled"
+	ReasonCredentialsRemoved  = "PlaintextCredentialsRemoved"
+	ReasonCloudConfigLint     = "CloudConfigLintWarnings"
+	// ReasonTrustedCASourcesSkipped is used when the trusted CA bundle is synced without some of its sources.
+	ReasonTrustedCASourcesSkipped = "TrustedCASourcesSkipped"
 )
 
 const (
@@ -126,11 +133,55 @@ func (r *ClusterOperatorStatusClient) setStatusAvailable(ctx context.Context, ov
 		newClusterOperatorStatusCondition(configv1.OperatorUpgradeable, configv1.ConditionTrue, ReasonAsExpected, ""),
 	}
 
+	observeProgressingDuration(co, r.Clock)
+
 	co.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: r.ReleaseVersion}}
 	klog.V(2).Info("Syncing status: available")
 	return r.syncStatus(ctx, co, conds, overrides)
 }
 
+// setStatusPaused reports the operator as Available while operand management is paused.
+// Upgradeable is set to False as operands are not being brought to the desired state,
+// and operand versions are left as they are.
+func (r *ClusterOperatorStatusClient) setStatusPaused(ctx context.Context, overrides []configv1.ClusterOperatorStatusCondition) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Operand management is paused by the %q annotation, operands are not being reconciled", pausedAnnotation)
+	conds := []configv1.ClusterOperatorStatusCondition{
+		newClusterOperatorStatusCondition(configv1.OperatorAvailable, configv1.ConditionTrue, ReasonPaused, message),
+		newClusterOperatorStatusCondition(configv1.OperatorProgressing, configv1.ConditionFalse, ReasonPaused, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorDegraded, configv1.ConditionFalse, ReasonPaused, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorUpgradeable, configv1.ConditionFalse, ReasonPaused, message),
+	}
+
+	klog.V(2).Info("Syncing status: paused")
+	return r.syncStatus(ctx, co, conds, overrides)
+}
+
+// setStatusNoCloudProvider reports the operator as Available when no cloud controller manager
+// is deployed for the platform, either because it does not require one or no provider is known for it.
+func (r *ClusterOperatorStatusClient) setStatusNoCloudProvider(ctx context.Context, platformType configv1.PlatformType, overrides []configv1.ClusterOperatorStatusCondition) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("No cloud controller manager is deployed by the operator for the %q platform", platformType)
+	conds := []configv1.ClusterOperatorStatusCondition{
+		newClusterOperatorStatusCondition(configv1.OperatorAvailable, configv1.ConditionTrue, ReasonNoCloudProvider, message),
+		newClusterOperatorStatusCondition(configv1.OperatorProgressing, configv1.ConditionFalse, ReasonAsExpected, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorDegraded, configv1.ConditionFalse, ReasonAsExpected, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorUpgradeable, configv1.ConditionTrue, ReasonAsExpected, ""),
+	}
+
+	co.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: r.ReleaseVersion}}
+	klog.V(2).Infof("Syncing status: available, no cloud provider for %q platform", platformType)
+	return r.syncStatus(ctx, co, conds, overrides)
+}
+
 // clearCloudControllerOwnerCondition clears the CloudControllerOwner condition. This condition
 // is not used for OpenShift version 4.16 and later as all cloud controllers are external by
 // default, and cannot be rolled back to in-tree.
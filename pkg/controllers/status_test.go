@@ -8,6 +8,7 @@ import (
 
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -439,3 +440,194 @@ func TestOperatorSetStatusAvailable(t *testing.T) {
 			"test-case %v expected equal version for ClusterOperator to %v, got %v", i, desiredVersion, gotCO.Status.Versions)
 	}
 }
+
+func TestOperatorSetStatusPaused(t *testing.T) {
+	optr := CloudOperatorReconciler{
+		ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+			Clock:          clocktesting.NewFakePassiveClock(time.Now()),
+			Recorder:       record.NewFakeRecorder(32),
+			ReleaseVersion: "2.0",
+		},
+		Scheme: scheme.Scheme,
+	}
+
+	operator := &configv1.ClusterOperator{}
+	operator.SetName(clusterOperatorName)
+	operator.SetAnnotations(map[string]string{pausedAnnotation: "true"})
+	operator.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: "1.0"}}
+	optr.Client = fake.NewClientBuilder().WithStatusSubresource(&configv1.ClusterOperator{}).WithObjects(operator).Build()
+
+	paused, err := optr.isPaused(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, paused)
+
+	assert.NoError(t, optr.setStatusPaused(context.TODO(), nil))
+
+	gotCO, err := optr.getOrCreateClusterOperator(context.TODO())
+	assert.NoError(t, err)
+
+	expectedConditions := []configv1.ClusterOperatorStatusCondition{
+		newClusterOperatorStatusCondition(configv1.OperatorAvailable, configv1.ConditionTrue, ReasonPaused, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorProgressing, configv1.ConditionFalse, ReasonPaused, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorDegraded, configv1.ConditionFalse, ReasonPaused, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorUpgradeable, configv1.ConditionFalse, ReasonPaused, ""),
+	}
+	assert.Len(t, gotCO.Status.Conditions, len(expectedConditions))
+	for _, expectedCondition := range expectedConditions {
+		condition := v1helpers.FindStatusCondition(gotCO.Status.Conditions, expectedCondition.Type)
+		if assert.NotNil(t, condition, "condition %s is missing", expectedCondition.Type) {
+			assert.Equal(t, expectedCondition.Status, condition.Status)
+			assert.Equal(t, expectedCondition.Reason, condition.Reason)
+		}
+	}
+
+	// Operand versions are not bumped while paused
+	assert.Equal(t, []configv1.OperandVersion{{Name: operatorVersionKey, Version: "1.0"}}, gotCO.Status.Versions)
+}
+
+func TestOperatorSetStatusNoCloudProvider(t *testing.T) {
+	optr := CloudOperatorReconciler{
+		ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+			Clock:          clocktesting.NewFakePassiveClock(time.Now()),
+			Recorder:       record.NewFakeRecorder(32),
+			ReleaseVersion: "2.0",
+		},
+		Scheme: scheme.Scheme,
+	}
+
+	operator := &configv1.ClusterOperator{}
+	operator.SetName(clusterOperatorName)
+	operator.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: "1.0"}}
+	optr.Client = fake.NewClientBuilder().WithStatusSubresource(&configv1.ClusterOperator{}).WithObjects(operator).Build()
+
+	assert.NoError(t, optr.setStatusNoCloudProvider(context.TODO(), configv1.BareMetalPlatformType, nil))
+
+	gotCO, err := optr.getOrCreateClusterOperator(context.TODO())
+	assert.NoError(t, err)
+
+	expectedConditions := []configv1.ClusterOperatorStatusCondition{
+		newClusterOperatorStatusCondition(configv1.OperatorAvailable, configv1.ConditionTrue, ReasonNoCloudProvider, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorProgressing, configv1.ConditionFalse, ReasonAsExpected, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorDegraded, configv1.ConditionFalse, ReasonAsExpected, ""),
+		newClusterOperatorStatusCondition(configv1.OperatorUpgradeable, configv1.ConditionTrue, ReasonAsExpected, ""),
+	}
+	assert.Len(t, gotCO.Status.Conditions, len(expectedConditions))
+	for _, expectedCondition := range expectedConditions {
+		condition := v1helpers.FindStatusCondition(gotCO.Status.Conditions, expectedCondition.Type)
+		if assert.NotNil(t, condition, "condition %s is missing", expectedCondition.Type) {
+			assert.Equal(t, expectedCondition.Status, condition.Status)
+			assert.Equal(t, expectedCondition.Reason, condition.Reason)
+		}
+	}
+	available := v1helpers.FindStatusCondition(gotCO.Status.Conditions, configv1.OperatorAvailable)
+	assert.Contains(t, available.Message, string(configv1.BareMetalPlatformType))
+
+	// The operator has nothing to roll out, so it is reported at the desired version
+	assert.Equal(t, []configv1.OperandVersion{{Name: operatorVersionKey, Version: "2.0"}}, gotCO.Status.Versions)
+}
+
+func TestIsPaused(t *testing.T) {
+	tc := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{{
+		name: "No annotations",
+	}, {
+		name:        "Paused annotation set to true",
+		annotations: map[string]string{pausedAnnotation: "true"},
+		expected:    true,
+	}, {
+		name:        "Paused annotation set to false",
+		annotations: map[string]string{pausedAnnotation: "false"},
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			operator := &configv1.ClusterOperator{}
+			operator.SetName(clusterOperatorName)
+			operator.SetAnnotations(tc.annotations)
+
+			optr := CloudOperatorReconciler{
+				ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+					Client: fake.NewClientBuilder().WithObjects(operator).Build(),
+				},
+			}
+
+			paused, err := optr.isPaused(context.TODO())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, paused)
+		})
+	}
+}
+
+func TestNotReadyDaemonSets(t *testing.T) {
+	daemonSet := func(name string, status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DefaultManagedNamespace, Generation: 2},
+			Status:     status,
+		}
+	}
+
+	tc := []struct {
+		name             string
+		existing         []client.Object
+		resources        []client.Object
+		expectedNotReady []string
+		expectedError    string
+	}{{
+		name:             "No daemonsets",
+		resources:        []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "ccm", Namespace: DefaultManagedNamespace}}},
+		expectedNotReady: []string{},
+	}, {
+		name: "Ready daemonset",
+		existing: []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{
+			ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3,
+		})},
+		resources:        []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{})},
+		expectedNotReady: []string{},
+	}, {
+		name: "Daemonset with not ready pods",
+		existing: []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{
+			ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 1,
+		})},
+		resources:        []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{})},
+		expectedNotReady: []string{"openshift-cloud-controller-manager/cnm (1 of 3 pods ready)"},
+	}, {
+		name: "Daemonset with outdated pods",
+		existing: []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{
+			ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberReady: 3,
+		})},
+		resources:        []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{})},
+		expectedNotReady: []string{"openshift-cloud-controller-manager/cnm (3 of 3 pods ready)"},
+	}, {
+		name: "Daemonset not observed by its controller yet",
+		existing: []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{
+			ObservedGeneration: 1,
+		})},
+		resources:        []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{})},
+		expectedNotReady: []string{"openshift-cloud-controller-manager/cnm (0 of 0 pods ready)"},
+	}, {
+		name:          "Missing daemonset",
+		resources:     []client.Object{daemonSet("cnm", appsv1.DaemonSetStatus{})},
+		expectedError: "unable to get DaemonSet openshift-cloud-controller-manager/cnm: daemonsets.apps \"cnm\" not found",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			optr := CloudOperatorReconciler{
+				ClusterOperatorStatusClient: ClusterOperatorStatusClient{
+					Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.existing...).Build(),
+				},
+			}
+
+			notReady, err := optr.notReadyDaemonSets(context.TODO(), tc.resources)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedNotReady, notReady)
+		})
+	}
+}
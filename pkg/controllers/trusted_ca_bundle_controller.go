@@ -3,17 +3,24 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/openshift/api/annotations"
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -21,7 +28,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/openstack"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/config"
 	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/util"
 )
 
@@ -32,17 +43,106 @@ const (
 	// https://github.com/openshift/installer/blob/master/pkg/asset/manifests/cloudproviderconfig.go#L41
 	// https://github.com/openshift/installer/blob/master/pkg/asset/manifests/cloudproviderconfig.go#L99
 	cloudProviderConfigCABundleConfigMapKey = "ca-bundle.pem"
-	systemTrustBundlePath                   = "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem"
+	// DefaultSystemTrustBundlePath is where the system trust bundle is read from, unless configured otherwise.
+	DefaultSystemTrustBundlePath = "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem"
+	// systemTrustBundlePathEnvVariableName overrides the default system trust bundle path, e.g. on base images
+	// keeping the CAs in another location.
+	systemTrustBundlePathEnvVariableName = "SYSTEM_TRUST_BUNDLE_PATH"
+
+	// Annotations of the merged trust bundle ConfigMap holding the hash of the source bundles merged into it.
+	systemCABundleHashAnnotation      = "ccm.openshift.io/system-ca-bundle-hash"
+	proxyCABundleHashAnnotation       = "ccm.openshift.io/proxy-ca-bundle-hash"
+	cloudConfigCABundleHashAnnotation = "ccm.openshift.io/cloud-config-ca-bundle-hash"
+
+	// Sources of the merged bundle which can be disabled, e.g. to tell which one breaks trust when debugging.
+	SystemCABundleSource      = "system"
+	ProxyCABundleSource       = "proxy"
+	CloudConfigCABundleSource = "cloud-config"
+
+	// injectTrustedCABundleLabel marks the ConfigMaps of the target namespaces the merged bundle is injected into,
+	// e.g. for provider specific components or sidecars consuming the same trust store.
+	injectTrustedCABundleLabel = "ccm.openshift.io/inject-trusted-cabundle"
+
+	// DefaultCertificateExpiryWarningWindow is how long before a certificate of the merged bundle expires
+	// a warning event is recorded, unless configured otherwise.
+	DefaultCertificateExpiryWarningWindow = 30 * 24 * time.Hour
+
+	// maxTrustBundleSize is the size limit of the data of a ConfigMap, hence of the merged bundle. Once the bundle
+	// grows past the warning threshold, it is compacted and a warning event is recorded, so the limit is noticed
+	// before writes of the ConfigMap start failing.
+	maxTrustBundleSize              = 1 << 20
+	trustBundleSizeWarningThreshold = maxTrustBundleSize * 9 / 10
+
+	// Backoff applied when the merged bundle ConfigMap keeps being overwritten by another writer, which would make
+	// both controllers hot-loop. Overwrites are tolerated up to the threshold, then syncs are delayed exponentially
+	// from the base delay up to the max one. The count is reset once no overwrite happened for the reset period.
+	overwriteBackoffThreshold = 3
+	overwriteBackoffBase      = 10 * time.Second
+	overwriteBackoffMax       = 5 * time.Minute
+	overwriteBackoffReset     = 10 * time.Minute
 
 	// Controller conditions for the Cluster Operator resource
 	trustedCABundleControllerAvailableCondition = "TrustedCABundleControllerControllerAvailable"
 	trustedCABundleControllerDegradedCondition  = "TrustedCABundleControllerControllerDegraded"
+	// trustedCABundleDegradedCondition is True while the merged bundle can not be synced, or while some of its
+	// configured sources are skipped, e.g. an unreadable proxy trustedCA or an unparsable bundle, so trust issues
+	// show up in the ClusterOperator status rather than in logs only. Optional sources which do not exist are not
+	// reported.
+	trustedCABundleDegradedCondition = "TrustedCABundleDegraded"
+	// maxSourcesDegradedMessageLength caps the message of the trustedCABundleDegradedCondition listing the skipped
+	// sources, the full list is logged.
+	maxSourcesDegradedMessageLength = 1024
 )
 
 type TrustedCABundleReconciler struct {
 	ClusterOperatorStatusClient
-	Scheme          *runtime.Scheme
-	trustBundlePath string
+	Scheme *runtime.Scheme
+	// AdditionalCABundleSources are the ConfigMaps, e.g. a platform specific or an organization wide CA, whose
+	// `ca-bundle.crt` key is merged into the trusted CA bundle along with the proxy and cloud-config ones.
+	AdditionalCABundleSources []client.ObjectKey
+	// CABundleSecretSources are the Secrets, e.g. a corporate CA kept in a Secret for RBAC reasons, whose
+	// `ca-bundle.crt` key is merged into the trusted CA bundle along with the ConfigMap sources.
+	CABundleSecretSources []client.ObjectKey
+	// CertificateExpiryWarningWindow is how long before a certificate of the merged bundle expires a warning event
+	// is recorded. No event is recorded when it is zero.
+	CertificateExpiryWarningWindow time.Duration
+	// DisabledCABundleSources lists the sources left out of the merged bundle, among SystemCABundleSource,
+	// ProxyCABundleSource and CloudConfigCABundleSource.
+	DisabledCABundleSources []string
+	// ExcludedCertificates lists the certificates dropped from the merged bundle whichever source they come from,
+	// e.g. compromised or deprecated roots, by SHA-256 fingerprint, hex encoded with optional colons, or by subject
+	// in its RFC 2253 form, e.g. "CN=Example Root CA,O=Example".
+	ExcludedCertificates []string
+	// TargetNamespaces lists the namespaces, besides the managed one, the merged bundle is synced to, e.g. a hosted
+	// control plane namespace. Each copy is kept in sync independently, a failure to sync one does not prevent the
+	// others from being updated.
+	TargetNamespaces []string
+	// MergeImageRegistryCA merges the ConfigMap referenced by the additionalTrustedCA of the cluster image config,
+	// e.g. when the cloud APIs are served behind the same internal CA as the mirror registry.
+	MergeImageRegistryCA bool
+	// PublishTrustedCASecret mirrors the merged bundle into a Secret named like the ConfigMap in the managed
+	// namespace, for provider components which can only mount Secrets.
+	PublishTrustedCASecret bool
+	// TrustBundlePath is the path of the system trust bundle the other sources are merged with,
+	// DefaultSystemTrustBundlePath is used when it is empty.
+	TrustBundlePath string
+	// lastWrittenBundles holds the bundle last written to each merged bundle ConfigMap, and overwrites the
+	// overwrites of each of them by another writer, see overwriteBackoffDelay.
+	lastWrittenBundles map[client.ObjectKey]string
+	overwrites         map[client.ObjectKey]*overwriteBackoff
+	// lastExpiringCertificates lists the certificates the last expiry warning event was recorded for, so the event is
+	// only recorded again once they change.
+	lastExpiringCertificates string
+}
+
+// sourceProblems collects the problems of the configured sources skipped during a sync, reported through the
+// trustedCABundleDegradedCondition once the sync is done.
+type sourceProblems []string
+
+// report logs a problem of a source which is skipped, and collects it.
+func (p *sourceProblems) report(format string, args ...interface{}) {
+	klog.Warningf(format, args...)
+	*p = append(*p, fmt.Sprintf(format, args...))
 }
 
 // isSpecTrustedCASet returns true if spec.trustedCA of proxyConfig is set.
@@ -64,16 +164,20 @@ func (r *TrustedCABundleReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			}
 			return reconcile.Result{}, nil
 		}
-		if err := r.setDegradedCondition(ctx); err != nil {
+		// Error reading the object - requeue the request.
+		err = fmt.Errorf("failed to get proxy '%s': %v", req.Name, err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 		}
-		// Error reading the object - requeue the request.
-		return reconcile.Result{}, fmt.Errorf("failed to get proxy '%s': %v", req.Name, err)
+		return reconcile.Result{}, err
 	}
 
-	// Check if changed config map in 'openshift-config' namespace is proxy trusted ca.
+	// Check if changed config map in 'openshift-config' namespace is proxy trusted ca or an additional CA source.
 	// If not, return early
-	if req.Namespace == OpenshiftConfigNamespace && proxyConfig.Spec.TrustedCA.Name != req.Name {
+	if req.Namespace == OpenshiftConfigNamespace && proxyConfig.Spec.TrustedCA.Name != req.Name &&
+		!slices.Contains(r.AdditionalCABundleSources, req.NamespacedName) &&
+		!slices.Contains(r.CABundleSecretSources, req.NamespacedName) &&
+		!r.isImageRegistryCASource(ctx, req.NamespacedName) {
 		if err := r.setAvailableCondition(ctx); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 		}
@@ -82,53 +186,232 @@ func (r *TrustedCABundleReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return reconcile.Result{}, nil
 	}
 
+	problems := &sourceProblems{}
 	systemTrustBundle, err := r.getSystemTrustBundle()
 	if err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+		err = fmt.Errorf("failed to get system trust bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	proxyCABundle, mergedTrustBundle, err := r.addProxyCABundle(ctx, problems, proxyConfig, systemTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not check and add proxy CA to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	cloudConfigCABundle, mergedTrustBundle, err := r.addCloudConfigCABundle(ctx, problems, proxyCABundle, mergedTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not check and add cloud-config CA to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	mergedTrustBundle, err = r.addOpenStackCABundle(ctx, problems, mergedTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not check and add OpenStack clouds.yaml CA to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 		}
-		return reconcile.Result{}, fmt.Errorf("failed to get system trust bundle: %v", err)
+		return reconcile.Result{}, err
 	}
 
-	proxyCABundle, mergedTrustBundle, err := r.addProxyCABundle(ctx, proxyConfig, systemTrustBundle)
+	mergedTrustBundle, err = r.addPlatformCABundle(ctx, mergedTrustBundle)
 	if err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+		err = fmt.Errorf("can not check and add platform CA to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 		}
-		return reconcile.Result{}, fmt.Errorf("can not check and add proxy CA to merged bundle: %v", err)
+		return reconcile.Result{}, err
 	}
 
-	_, mergedTrustBundle, err = r.addCloudConfigCABundle(ctx, proxyCABundle, mergedTrustBundle)
+	mergedTrustBundle, err = r.addAdditionalCABundles(ctx, problems, mergedTrustBundle)
 	if err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+		err = fmt.Errorf("can not check and add additional CAs to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 		}
-		return reconcile.Result{}, fmt.Errorf("can not check and add cloud-config CA to merged bundle: %v", err)
+		return reconcile.Result{}, err
 	}
 
-	ccmTrustedConfigMap := r.makeCABundleConfigMap(mergedTrustBundle)
-	if err := r.createOrUpdateConfigMap(ctx, ccmTrustedConfigMap); err != nil {
-		if err := r.setDegradedCondition(ctx); err != nil {
+	mergedTrustBundle, err = r.addSecretCABundles(ctx, problems, mergedTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not check and add Secret CAs to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 		}
-		return reconcile.Result{}, fmt.Errorf("can not update target trust bundle configmap: %v", err)
+		return reconcile.Result{}, err
+	}
+
+	mergedTrustBundle, err = r.addImageRegistryCABundle(ctx, problems, mergedTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not check and add image registry CAs to merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	if len(mergedTrustBundle) == 0 {
+		err = fmt.Errorf("merged trust bundle is empty, its sources are all disabled or skipped")
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	mergedTrustBundle, err = r.removeExcludedCertificates(mergedTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not remove excluded CAs from merged bundle: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	mergedTrustBundle, err = r.compactTrustBundle(ctx, mergedTrustBundle)
+	if err != nil {
+		err = fmt.Errorf("can not fit merged bundle into the ConfigMap size limit: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	requeueAfter, err := r.syncCABundleConfigMaps(ctx, mergedTrustBundle, map[string][]byte{
+		systemCABundleHashAnnotation:      systemTrustBundle,
+		proxyCABundleHashAnnotation:       proxyCABundle,
+		cloudConfigCABundleHashAnnotation: cloudConfigCABundle,
+	})
+	if err != nil {
+		err = fmt.Errorf("can not update target trust bundle configmap: %v", err)
+		if err := r.setDegradedCondition(ctx, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
+		}
+		return reconcile.Result{}, err
+	}
+
+	if err := r.checkCertificateExpiry(ctx, mergedTrustBundle); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check expiry of the merged trust bundle: %v", err)
+	}
+
+	if err := r.setSourcesDegradedCondition(ctx, *problems); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 	}
 
 	if err := r.setAvailableCondition(ctx); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to set conditions for trusted CA bundle controller: %v", err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// checkCertificateExpiry records the expiration time of the certificate of the merged bundle which expires first,
+// and a warning event listing the certificates expiring within the warning window, so they are renewed before the
+// cloud controller manager fails to reach the platform endpoints. The event is only recorded when the expiring
+// certificates change, the metric carries the expiry in between.
+func (r *TrustedCABundleReconciler) checkCertificateExpiry(ctx context.Context, trustBundle []byte) error {
+	deadline := r.Clock.Now().Add(r.CertificateExpiryWarningWindow)
+	var earliest time.Time
+	var expiring []string
+	for cert, err := range util.Certificates(trustBundle) {
+		if err != nil {
+			return err
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+		if r.CertificateExpiryWarningWindow > 0 && cert.NotAfter.Before(deadline) {
+			expiring = append(expiring, fmt.Sprintf("%q expires on %s", cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	observeTrustBundleExpiry(earliest)
+
+	expiringCertificates := strings.Join(expiring, "; ")
+	if expiringCertificates == r.lastExpiringCertificates {
+		return nil
+	}
+	if len(expiring) == 0 {
+		r.lastExpiringCertificates = ""
+		return nil
+	}
+
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+	r.Recorder.Eventf(co, corev1.EventTypeWarning, "TrustedCACertificateExpiring",
+		"Trusted CA bundle contains certificates expiring within %s: %s", r.CertificateExpiryWarningWindow, expiringCertificates)
+	r.lastExpiringCertificates = expiringCertificates
+	return nil
+}
+
+// isSourceDisabled returns true if the given source is left out of the merged bundle.
+func (r *TrustedCABundleReconciler) isSourceDisabled(source string) bool {
+	return slices.Contains(r.DisabledCABundleSources, source)
+}
+
+// ParseDisabledCABundleSources parses a comma separated list of the sources to leave out of the merged bundle.
+func ParseDisabledCABundleSources(value string) ([]string, error) {
+	var sources []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		switch item {
+		case SystemCABundleSource, ProxyCABundleSource, CloudConfigCABundleSource:
+			sources = append(sources, item)
+		default:
+			return nil, fmt.Errorf("unknown CA bundle source %q, expected %s, %s or %s",
+				item, SystemCABundleSource, ProxyCABundleSource, CloudConfigCABundleSource)
+		}
+	}
+	return sources, nil
+}
+
+// ParseCABundleSources parses a comma separated list of ConfigMaps, given as `namespace/name` or as `name` of a ConfigMap
+// in the openshift-config namespace, into the additional CA bundle sources.
+func ParseCABundleSources(value string) ([]client.ObjectKey, error) {
+	var sources []client.ObjectKey
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		source := client.ObjectKey{Namespace: OpenshiftConfigNamespace, Name: item}
+		if namespace, name, found := strings.Cut(item, "/"); found {
+			source = client.ObjectKey{Namespace: namespace, Name: name}
+		}
+		if source.Namespace == "" || source.Name == "" || strings.Contains(source.Name, "/") {
+			return nil, fmt.Errorf("invalid CA bundle source %q, expected namespace/name", item)
+		}
+		if !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	return sources, nil
 }
 
 // addProxyCABundle checks ca bundle referred by Proxy resource and adds it to passed bundle
 // in case if proxy one is valid.
 // This function returns added bundle as first value, result as second and an error if it was occurred.
-func (r *TrustedCABundleReconciler) addProxyCABundle(ctx context.Context, proxyConfig *configv1.Proxy, originalCABundle []byte) ([]byte, []byte, error) {
+func (r *TrustedCABundleReconciler) addProxyCABundle(ctx context.Context, problems *sourceProblems, proxyConfig *configv1.Proxy, originalCABundle []byte) ([]byte, []byte, error) {
+	if r.isSourceDisabled(ProxyCABundleSource) {
+		klog.V(1).Infof("proxy CA bundle source is disabled, it will not be added")
+		return nil, originalCABundle, nil
+	}
 	if isSpecTrustedCASet(&proxyConfig.Spec) {
-		userProxyCABundle, err := r.getUserProxyCABundle(ctx, proxyConfig.Spec.TrustedCA.Name)
+		userProxyCABundle, err := r.getUserProxyCABundle(ctx, problems, proxyConfig.Spec.TrustedCA.Name)
 		if err != nil {
-			klog.Warningf("failed to get user defined proxy trust bundle, system CA will be used: %v", err)
+			problems.report("failed to get user defined proxy trust bundle, system CA will be used: %v", err)
 			return nil, originalCABundle, nil
 		}
 		resultCABundle, err := r.mergeCABundles(userProxyCABundle, originalCABundle)
@@ -144,12 +427,16 @@ func (r *TrustedCABundleReconciler) addProxyCABundle(ctx context.Context, proxyC
 // in case found one is valid.
 // This function returns added bundle as first value, result as second and an error if it was occurred.
 // Note: missed cloud-config not considered an error, because no cloud-config is expected on some platforms (AWS)
-func (r *TrustedCABundleReconciler) addCloudConfigCABundle(ctx context.Context, proxyCABundle []byte, originalCABundle []byte) ([]byte, []byte, error) {
+func (r *TrustedCABundleReconciler) addCloudConfigCABundle(ctx context.Context, problems *sourceProblems, proxyCABundle []byte, originalCABundle []byte) ([]byte, []byte, error) {
 	// Due to installer implementation nuances, 'additionalTrustBundle' does not always end up in Proxy object.
 	// For handling this situation we have to check synced cloud-config for additional CA bundle presence.
 	// See https://github.com/openshift/installer/pull/5251#issuecomment-932622321 and
 	// https://github.com/openshift/installer/pull/5248 for additional context.
 	// However, some platforms might not have cloud-config at all (AWS), so missed cloud config is not an error.
+	if r.isSourceDisabled(CloudConfigCABundleSource) {
+		klog.V(1).Infof("cloud-config CA bundle source is disabled, it will not be added")
+		return nil, originalCABundle, nil
+	}
 	ccmSyncedCloudConfig := &corev1.ConfigMap{}
 	syncedCloudConfigObjectKey := types.NamespacedName{Name: syncedCloudConfigMapName, Namespace: r.ManagedNamespace}
 	if err := r.Get(ctx, syncedCloudConfigObjectKey, ccmSyncedCloudConfig); err != nil {
@@ -160,9 +447,9 @@ func (r *TrustedCABundleReconciler) addCloudConfigCABundle(ctx context.Context,
 	_, found := ccmSyncedCloudConfig.Data[cloudProviderConfigCABundleConfigMapKey]
 	if found {
 		klog.Infof("additional CA bundle key found in cloud-config")
-		_, cloudConfigCABundle, err := r.getCABundleConfigMapData(ccmSyncedCloudConfig, cloudProviderConfigCABundleConfigMapKey)
+		cloudConfigCABundle, err := r.getCABundleConfigMapData(ctx, problems, ccmSyncedCloudConfig, cloudProviderConfigCABundleConfigMapKey)
 		if err != nil {
-			klog.Warningf("failed to parse additional CA bundle from cloud-config, system and proxy CAs will be used: %v", err)
+			problems.report("failed to parse additional CA bundle from cloud-config, system and proxy CAs will be used: %v", err)
 			return nil, originalCABundle, nil
 		}
 		if bytes.Equal(proxyCABundle, cloudConfigCABundle) {
@@ -179,14 +466,293 @@ func (r *TrustedCABundleReconciler) addCloudConfigCABundle(ctx context.Context,
 	return nil, originalCABundle, nil
 }
 
-func (r *TrustedCABundleReconciler) getUserProxyCABundle(ctx context.Context, trustedCA string) ([]byte, error) {
+// addOpenStackCABundle checks the OpenStack clouds.yaml credentials secret for a custom CA bundle and adds it to
+// passed bundle in case found one is valid and not merged yet, so the openstack-cloud-controller-manager trusts
+// the OpenStack endpoints without the CA being configured in the proxy.
+// Note: missed secret is not considered an error, because it only exists on OpenStack.
+func (r *TrustedCABundleReconciler) addOpenStackCABundle(ctx context.Context, problems *sourceProblems, originalCABundle []byte) ([]byte, error) {
+	credentialsSecret := &corev1.Secret{}
+	credentialsSecretKey := types.NamespacedName{Name: openstack.CloudsYAMLSecretName, Namespace: r.ManagedNamespace}
+	if err := r.Get(ctx, credentialsSecretKey, credentialsSecret); apierrors.IsNotFound(err) {
+		return originalCABundle, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	cloudsYAMLCABundle, err := openstack.CloudsYAMLCABundle(credentialsSecret.Data)
+	if err != nil {
+		problems.report("failed to read CA bundle from clouds.yaml, it will not be added: %v", err)
+		return originalCABundle, nil
+	}
+	if cloudsYAMLCABundle == nil {
+		return originalCABundle, nil
+	}
+	cloudsYAMLCABundle, err = r.validCertificateData(ctx, problems, fmt.Sprintf("the clouds.yaml of Secret %s", credentialsSecretKey), cloudsYAMLCABundle)
+	if err != nil {
+		problems.report("failed to parse CA bundle from clouds.yaml, it will not be added: %v", err)
+		return originalCABundle, nil
+	}
+	if bytes.Contains(originalCABundle, cloudsYAMLCABundle) {
+		klog.V(1).Infof("clouds.yaml CA bundle is already merged")
+		return originalCABundle, nil
+	}
+
+	klog.Infof("clouds.yaml CA bundle found, merging")
+	return r.mergeCABundles(cloudsYAMLCABundle, originalCABundle)
+}
+
+// addPlatformCABundle adds the extra root CAs shipped with the assets of the cloud provider of the cluster platform,
+// e.g. for regional cloud endpoints, to passed bundle in case they are not merged yet.
+// Note: missed infrastructure is not considered an error, the platform CAs are added once it is found.
+func (r *TrustedCABundleReconciler) addPlatformCABundle(ctx context.Context, originalCABundle []byte) ([]byte, error) {
+	infra := &configv1.Infrastructure{}
+	if err := r.Get(ctx, client.ObjectKey{Name: infrastructureResourceName}, infra); apierrors.IsNotFound(err) {
+		klog.Infof("infrastructure was not found, platform CA bundle will not be added")
+		return originalCABundle, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if infra.Status.PlatformStatus == nil {
+		return originalCABundle, nil
+	}
+
+	platformCABundle := cloud.GetTrustedCABundle(infra.Status.PlatformStatus, config.GetExternalPlatformName(infra))
+	if len(platformCABundle) == 0 {
+		return originalCABundle, nil
+	}
+	if bytes.Contains(originalCABundle, platformCABundle) {
+		klog.V(1).Infof("%s platform CA bundle is already merged", infra.Status.PlatformStatus.Type)
+		return originalCABundle, nil
+	}
+
+	klog.Infof("%s platform CA bundle found, merging", infra.Status.PlatformStatus.Type)
+	return r.mergeCABundles(platformCABundle, originalCABundle)
+}
+
+// addAdditionalCABundles checks each of the additional CA bundle sources and adds the ones which are valid and not
+// merged yet to passed bundle. Every source is validated on its own: a missed ConfigMap, or one without a parsable
+// bundle, is skipped with a warning and does not prevent the other sources from being added.
+func (r *TrustedCABundleReconciler) addAdditionalCABundles(ctx context.Context, problems *sourceProblems, originalCABundle []byte) ([]byte, error) {
+	mergedCABundle := originalCABundle
+	for _, source := range r.AdditionalCABundleSources {
+		cfgMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, source, cfgMap); apierrors.IsNotFound(err) {
+			klog.Warningf("additional CA bundle ConfigMap %s was not found, it will not be added", source)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get additional CA bundle ConfigMap %s: %v", source, err)
+		}
+
+		caBundle, err := r.getCABundleConfigMapData(ctx, problems, cfgMap, trustedCABundleConfigMapKey)
+		if err != nil {
+			problems.report("failed to parse additional CA bundle from ConfigMap %s, it will not be added: %v", source, err)
+			continue
+		}
+		if bytes.Contains(mergedCABundle, caBundle) {
+			klog.V(1).Infof("additional CA bundle from ConfigMap %s is already merged", source)
+			continue
+		}
+
+		klog.Infof("additional CA bundle from ConfigMap %s found, merging", source)
+		mergedCABundle, err = r.mergeCABundles(caBundle, mergedCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("can not merge additional trust bundle from ConfigMap %s: %v", source, err)
+		}
+	}
+	return mergedCABundle, nil
+}
+
+// addSecretCABundles checks each of the Secret CA bundle sources and adds the ones which are valid and not merged
+// yet to passed bundle. Like the additional ConfigMap sources, every source is validated on its own.
+func (r *TrustedCABundleReconciler) addSecretCABundles(ctx context.Context, problems *sourceProblems, originalCABundle []byte) ([]byte, error) {
+	mergedCABundle := originalCABundle
+	for _, source := range r.CABundleSecretSources {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, source, secret); apierrors.IsNotFound(err) {
+			klog.Warningf("CA bundle Secret %s was not found, it will not be added", source)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get CA bundle Secret %s: %v", source, err)
+		}
+
+		bundleData := secret.Data[trustedCABundleConfigMapKey]
+		if len(bundleData) == 0 {
+			problems.report("CA bundle Secret %s has no %q key, it will not be added", source, trustedCABundleConfigMapKey)
+			continue
+		}
+		caBundle, err := r.validCertificateData(ctx, problems, fmt.Sprintf("key %q of Secret %s", trustedCABundleConfigMapKey, source), bundleData)
+		if err != nil {
+			problems.report("failed to parse CA bundle from Secret %s, it will not be added: %v", source, err)
+			continue
+		}
+		if bytes.Contains(mergedCABundle, caBundle) {
+			klog.V(1).Infof("CA bundle from Secret %s is already merged", source)
+			continue
+		}
+
+		klog.Infof("CA bundle from Secret %s found, merging", source)
+		mergedCABundle, err = r.mergeCABundles(caBundle, mergedCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("can not merge trust bundle from Secret %s: %v", source, err)
+		}
+	}
+	return mergedCABundle, nil
+}
+
+// addImageRegistryCABundle adds the CAs of the ConfigMap referenced by the additionalTrustedCA of the cluster image
+// config to passed bundle when enabled, in case they are not merged yet. The ConfigMap holds a bundle per registry,
+// keyed by its hostname, each of them is validated on its own like the additional ConfigMap sources.
+// Note: missed image config, or one without additionalTrustedCA, is not considered an error.
+func (r *TrustedCABundleReconciler) addImageRegistryCABundle(ctx context.Context, problems *sourceProblems, originalCABundle []byte) ([]byte, error) {
+	if !r.MergeImageRegistryCA {
+		return originalCABundle, nil
+	}
+	name, err := r.getImageRegistryCAConfigMapName(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return originalCABundle, nil
+	}
+
+	source := client.ObjectKey{Namespace: OpenshiftConfigNamespace, Name: name}
+	cfgMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, source, cfgMap); apierrors.IsNotFound(err) {
+		klog.Warningf("image registry CA ConfigMap %s was not found, it will not be added", source)
+		return originalCABundle, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get image registry CA ConfigMap %s: %v", source, err)
+	}
+
+	mergedCABundle := originalCABundle
+	registries := slices.Sorted(maps.Keys(cfgMap.Data))
+	for _, registry := range registries {
+		caBundle, err := r.validCertificateData(ctx, problems, fmt.Sprintf("key %q of ConfigMap %s", registry, source), []byte(cfgMap.Data[registry]))
+		if err != nil {
+			problems.report("failed to parse image registry CA of %s from ConfigMap %s, it will not be added: %v", registry, source, err)
+			continue
+		}
+		if bytes.Contains(mergedCABundle, caBundle) {
+			klog.V(1).Infof("image registry CA of %s from ConfigMap %s is already merged", registry, source)
+			continue
+		}
+
+		klog.Infof("image registry CA of %s from ConfigMap %s found, merging", registry, source)
+		mergedCABundle, err = r.mergeCABundles(caBundle, mergedCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("can not merge image registry CA of %s from ConfigMap %s: %v", registry, source, err)
+		}
+	}
+	return mergedCABundle, nil
+}
+
+// getImageRegistryCAConfigMapName returns the name of the ConfigMap in openshift-config referenced by the
+// additionalTrustedCA of the cluster image config, or an empty name if it is not set.
+func (r *TrustedCABundleReconciler) getImageRegistryCAConfigMapName(ctx context.Context) (string, error) {
+	image := &configv1.Image{}
+	if err := r.Get(ctx, client.ObjectKey{Name: imageConfigResourceName}, image); apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get image config: %v", err)
+	}
+	return image.Spec.AdditionalTrustedCA.Name, nil
+}
+
+// isImageRegistryCASource returns true if the image registry CA is merged and the given ConfigMap holds it. The
+// ConfigMap is considered a source when the image config can not be read, so a change is not missed.
+func (r *TrustedCABundleReconciler) isImageRegistryCASource(ctx context.Context, key client.ObjectKey) bool {
+	if !r.MergeImageRegistryCA || key.Namespace != OpenshiftConfigNamespace {
+		return false
+	}
+	name, err := r.getImageRegistryCAConfigMapName(ctx)
+	if err != nil {
+		klog.Warningf("failed to check if ConfigMap %s holds the image registry CA: %v", key, err)
+		return true
+	}
+	return name == key.Name
+}
+
+// removeExcludedCertificates drops the excluded certificates from passed bundle. It is applied to the merged bundle,
+// so a certificate is excluded consistently whether it comes from the system, proxy, cloud-config or another source.
+func (r *TrustedCABundleReconciler) removeExcludedCertificates(caBundle []byte) ([]byte, error) {
+	if len(r.ExcludedCertificates) == 0 {
+		return caBundle, nil
+	}
+
+	excluded := map[string]bool{}
+	for _, entry := range r.ExcludedCertificates {
+		excluded[normalizeFingerprint(entry)] = true
+		excluded[entry] = true
+	}
+	result, err := util.FilterCertificates(caBundle, func(cert *x509.Certificate) bool {
+		if excluded[util.CertificateFingerprint(cert)] || excluded[cert.Subject.String()] {
+			klog.V(1).Infof("excluding certificate %q from the merged bundle", cert.Subject.String())
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("all certificates of the merged bundle are excluded")
+	}
+	return result, nil
+}
+
+// compactTrustBundle drops the duplicated and expired certificates of passed bundle once its size gets close to
+// maxTrustBundleSize, and records a warning event about it, since the bundle is about to stop fitting in a ConfigMap.
+// An error is returned if the bundle is still too large once compacted.
+func (r *TrustedCABundleReconciler) compactTrustBundle(ctx context.Context, trustBundle []byte) ([]byte, error) {
+	if len(trustBundle) < trustBundleSizeWarningThreshold {
+		return trustBundle, nil
+	}
+
+	now := r.Clock.Now()
+	seen := map[string]bool{}
+	compacted, err := util.FilterCertificates(trustBundle, func(cert *x509.Certificate) bool {
+		fingerprint := util.CertificateFingerprint(cert)
+		if seen[fingerprint] || cert.NotAfter.Before(now) {
+			return false
+		}
+		seen[fingerprint] = true
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(compacted) > maxTrustBundleSize {
+		r.Recorder.Eventf(co, corev1.EventTypeWarning, "TrustedCABundleTooLarge",
+			"Merged trust bundle is %d bytes once duplicated and expired certificates are dropped, above the ConfigMap limit of %d bytes: remove certificates from its sources or exclude them",
+			len(compacted), maxTrustBundleSize)
+		return nil, fmt.Errorf("merged trust bundle is %d bytes, above the limit of %d bytes", len(compacted), maxTrustBundleSize)
+	}
+	klog.Warningf("merged trust bundle is %d bytes, compacted to %d bytes", len(trustBundle), len(compacted))
+	r.Recorder.Eventf(co, corev1.EventTypeWarning, "TrustedCABundleNearSizeLimit",
+		"Merged trust bundle is %d bytes, close to the ConfigMap limit of %d bytes: duplicated and expired certificates are dropped, %d bytes are left",
+		len(trustBundle), maxTrustBundleSize, len(compacted))
+	return compacted, nil
+}
+
+// normalizeFingerprint returns the given fingerprint lower cased and without colons, as util.CertificateFingerprint
+// formats it, e.g. for fingerprints copied from openssl.
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
+
+func (r *TrustedCABundleReconciler) getUserProxyCABundle(ctx context.Context, problems *sourceProblems, trustedCA string) ([]byte, error) {
 	cfgMap, err := r.getUserCABundleConfigMap(ctx, trustedCA)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate configmap reference for proxy trustedCA '%s': %v",
 			trustedCA, err)
 	}
 
-	_, bundleData, err := r.getCABundleConfigMapData(cfgMap, trustedCABundleConfigMapKey)
+	bundleData, err := r.getCABundleConfigMapData(ctx, problems, cfgMap, trustedCABundleConfigMapKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate trust bundle for proxy trustedCA '%s': %v",
 			trustedCA, err)
@@ -204,23 +770,242 @@ func (r *TrustedCABundleReconciler) getUserCABundleConfigMap(ctx context.Context
 	return cfgMap, nil
 }
 
-func (r *TrustedCABundleReconciler) getCABundleConfigMapData(cfgMap *corev1.ConfigMap, caBundleKey string) ([]*x509.Certificate, []byte, error) {
-	certBundle, bundleData, err := util.TrustBundleConfigMap(cfgMap, caBundleKey)
+// getCABundleConfigMapData returns the valid certificates held by the caBundleKey key of cfgMap. Invalid ones are
+// skipped, see validCertificateData.
+func (r *TrustedCABundleReconciler) getCABundleConfigMapData(ctx context.Context, problems *sourceProblems, cfgMap *corev1.ConfigMap, caBundleKey string) ([]byte, error) {
+	bundleData, ok := cfgMap.Data[caBundleKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %q is missing %q", cfgMap.Name, caBundleKey)
+	}
+	if len(bundleData) == 0 {
+		return nil, fmt.Errorf("data key %q is empty from ConfigMap %q", caBundleKey, cfgMap.Name)
+	}
+	return r.validCertificateData(ctx, problems, fmt.Sprintf("key %q of ConfigMap %s/%s", caBundleKey, cfgMap.Namespace, cfgMap.Name), []byte(bundleData))
+}
+
+// validCertificateData returns the valid certificates of the given source bundle, so a malformed block does not
+// prevent the rest of the source from being merged. A warning event naming the skipped blocks is recorded on the
+// ClusterOperator. An error is returned only if the source holds no valid certificate at all.
+func (r *TrustedCABundleReconciler) validCertificateData(ctx context.Context, problems *sourceProblems, source string, bundleData []byte) ([]byte, error) {
+	validData, invalid := util.ValidCertificateData(bundleData)
+	if len(validData) == 0 {
+		return nil, fmt.Errorf("failed parsing certificate data from %s: %v", source, utilerrors.NewAggregate(invalid))
+	}
+	if len(invalid) == 0 {
+		return validData, nil
+	}
+
+	problems.report("skipping invalid certificates of %s: %v", source, utilerrors.NewAggregate(invalid))
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		klog.Errorf("failed to record invalid certificates event: %v", err)
+		return validData, nil
+	}
+	r.Recorder.Eventf(co, corev1.EventTypeWarning, "TrustedCAInvalidCertificates",
+		"Skipped invalid certificates of %s: %v", source, utilerrors.NewAggregate(invalid))
+	return validData, nil
+}
+
+// trustedCASecretKeys returns the key of the Secret mirroring the merged bundle when it is published, so it is
+// restored when changed or deleted.
+func (r *TrustedCABundleReconciler) trustedCASecretKeys() []client.ObjectKey {
+	if !r.PublishTrustedCASecret {
+		return nil
+	}
+	return []client.ObjectKey{{Namespace: r.ManagedNamespace, Name: trustedCAConfigMapName}}
+}
+
+// targetNamespaces returns the namespaces the merged bundle is synced to, starting with the managed one.
+func (r *TrustedCABundleReconciler) targetNamespaces() []string {
+	namespaces := []string{r.ManagedNamespace}
+	for _, namespace := range r.TargetNamespaces {
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// syncCABundleConfigMaps writes the merged trust bundle to every target namespace, and to its Secret mirror when
+// enabled. Namespaces are synced independently, the errors of the ones which failed are returned together once all
+// of them were tried. Namespaces whose ConfigMap is backed off from are skipped, the returned delay is the one
+// after which the first of them is due.
+func (r *TrustedCABundleReconciler) syncCABundleConfigMaps(ctx context.Context, trustBundle []byte, sourceBundles map[string][]byte) (time.Duration, error) {
+	var errs []error
+	var requeueAfter time.Duration
+	for _, namespace := range r.targetNamespaces() {
+		cm := r.makeCABundleConfigMap(namespace, trustBundle, sourceBundles)
+		delay, err := r.overwriteBackoffDelay(ctx, cm)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", namespace, err))
+			continue
+		}
+		if delay > 0 {
+			if requeueAfter == 0 || delay < requeueAfter {
+				requeueAfter = delay
+			}
+			continue
+		}
+		if err := r.createOrUpdateConfigMap(ctx, cm); err != nil {
+			klog.Errorf("failed to sync %s ConfigMap to namespace %s: %v", trustedCAConfigMapName, namespace, err)
+			errs = append(errs, fmt.Errorf("namespace %s: %w", namespace, err))
+			continue
+		}
+		if r.lastWrittenBundles == nil {
+			r.lastWrittenBundles = map[client.ObjectKey]string{}
+		}
+		r.lastWrittenBundles[client.ObjectKeyFromObject(cm)] = cm.Data[trustedCABundleConfigMapKey]
+		if err := r.injectCABundle(ctx, namespace, trustBundle); err != nil {
+			klog.Errorf("failed to inject trust bundle into ConfigMaps of namespace %s: %v", namespace, err)
+			errs = append(errs, fmt.Errorf("injected ConfigMaps in namespace %s: %w", namespace, err))
+		}
+		if namespace != r.ManagedNamespace || !r.PublishTrustedCASecret {
+			continue
+		}
+		if err := r.createOrUpdateSecret(ctx, makeCABundleSecret(cm)); err != nil {
+			klog.Errorf("failed to sync %s Secret to namespace %s: %v", trustedCAConfigMapName, namespace, err)
+			errs = append(errs, fmt.Errorf("secret in namespace %s: %w", namespace, err))
+		}
+	}
+	return requeueAfter, utilerrors.NewAggregate(errs)
+}
+
+// injectCABundle writes the merged trust bundle to the ca-bundle.crt key of the ConfigMaps of the given namespace
+// labeled for injection. Their other keys are kept, and the ones already holding the bundle are not updated.
+func (r *TrustedCABundleReconciler) injectCABundle(ctx context.Context, namespace string, trustBundle []byte) error {
+	cmList := &corev1.ConfigMapList{}
+	if err := r.List(ctx, cmList, client.InNamespace(namespace), client.MatchingLabels{injectTrustedCABundleLabel: "true"}); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range cmList.Items {
+		cm := &cmList.Items[i]
+		if cm.Name == trustedCAConfigMapName || cm.Data[trustedCABundleConfigMapKey] == string(trustBundle) {
+			continue
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[trustedCABundleConfigMapKey] = string(trustBundle)
+		if err := r.Update(ctx, cm); err != nil {
+			errs = append(errs, fmt.Errorf("ConfigMap %s: %w", cm.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// overwriteBackoff tracks the overwrites of a merged bundle ConfigMap by another writer.
+type overwriteBackoff struct {
+	count     int
+	lastSeen  time.Time
+	notBefore time.Time
+}
+
+// overwriteBackoffDelay returns how long the sync of the given merged bundle ConfigMap has to be delayed, because
+// another writer, e.g. an external controller, keeps overwriting it. An overwrite is told apart from a change of the
+// sources by the bundle found differing from the one last written. A warning event naming the field manager of the
+// overwrite is recorded when the backoff starts or grows.
+func (r *TrustedCABundleReconciler) overwriteBackoffDelay(ctx context.Context, cm *corev1.ConfigMap) (time.Duration, error) {
+	key := client.ObjectKeyFromObject(cm)
+	now := r.Clock.Now()
+	backoff, ok := r.overwrites[key]
+	if ok && now.Before(backoff.notBefore) {
+		return backoff.notBefore.Sub(now), nil
+	}
+
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, key, existing); apierrors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	lastWritten, written := r.lastWrittenBundles[key]
+	existingBundle := existing.Data[trustedCABundleConfigMapKey]
+	if !written || existingBundle == lastWritten || existingBundle == cm.Data[trustedCABundleConfigMapKey] {
+		return 0, nil
+	}
+
+	if !ok || now.Sub(backoff.lastSeen) > overwriteBackoffReset {
+		backoff = &overwriteBackoff{}
+		if r.overwrites == nil {
+			r.overwrites = map[client.ObjectKey]*overwriteBackoff{}
+		}
+		r.overwrites[key] = backoff
+	}
+	backoff.count++
+	backoff.lastSeen = now
+	if backoff.count < overwriteBackoffThreshold {
+		return 0, nil
+	}
+
+	delay := overwriteBackoffBase << (backoff.count - overwriteBackoffThreshold)
+	if delay > overwriteBackoffMax || delay <= 0 {
+		delay = overwriteBackoffMax
+	}
+	backoff.notBefore = now.Add(delay)
+
+	manager := lastFieldManager(existing)
+	klog.Warningf("ConfigMap %s was overwritten %d times by %q, delaying its sync by %s", key, backoff.count, manager, delay)
+	co, err := r.getOrCreateClusterOperator(ctx)
 	if err != nil {
-		return nil, nil, err
+		return 0, err
 	}
+	r.Recorder.Eventf(co, corev1.EventTypeWarning, "TrustedCAConfigMapOverwritten",
+		"ConfigMap %s is repeatedly overwritten by field manager %q, delaying its sync by %s: stop the conflicting writer, changes to it are reverted",
+		key, manager, delay)
+	return delay, nil
+}
+
+// lastFieldManager returns the field manager of the most recent write of obj, or "unknown" when it is not tracked.
+func lastFieldManager(obj metav1.Object) string {
+	manager := "unknown"
+	var lastTime time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && !entry.Time.Time.Before(lastTime) {
+			lastTime = entry.Time.Time
+			manager = entry.Manager
+		}
+	}
+	return manager
+}
 
-	return certBundle, bundleData, nil
+// makeCABundleSecret returns the Secret mirroring the given merged trust bundle ConfigMap, with the same name,
+// annotations and data, so both stay in lockstep.
+func makeCABundleSecret(cm *corev1.ConfigMap) *corev1.Secret {
+	data := make(map[string][]byte, len(cm.Data))
+	for key, value := range cm.Data {
+		data[key] = []byte(value)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cm.Name,
+			Namespace:   cm.Namespace,
+			Annotations: cm.Annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
 }
 
-func (r *TrustedCABundleReconciler) makeCABundleConfigMap(trustBundle []byte) *corev1.ConfigMap {
+// makeCABundleConfigMap returns the ConfigMap holding the merged trust bundle in the given namespace, annotated with
+// the SHA-256 hash of each of the given source bundles which was merged into it, so the source which changed can be
+// told when debugging trust issues.
+func (r *TrustedCABundleReconciler) makeCABundleConfigMap(namespace string, trustBundle []byte, sourceBundles map[string][]byte) *corev1.ConfigMap {
+	cmAnnotations := map[string]string{
+		annotations.OpenShiftComponent: "Cloud Compute / Cloud Controller Manager",
+	}
+	for annotation, bundle := range sourceBundles {
+		if len(bundle) > 0 {
+			cmAnnotations[annotation] = fmt.Sprintf("%x", sha256.Sum256(bundle))
+		}
+	}
+
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      trustedCAConfigMapName,
-			Namespace: r.ManagedNamespace,
-			Annotations: map[string]string{
-				annotations.OpenShiftComponent: "Cloud Compute / Cloud Controller Manager",
-			},
+			Name:        trustedCAConfigMapName,
+			Namespace:   namespace,
+			Annotations: cmAnnotations,
 		},
 		Data: map[string]string{
 			trustedCABundleConfigMapKey: string(trustBundle),
@@ -240,32 +1025,55 @@ func (r *TrustedCABundleReconciler) createOrUpdateConfigMap(ctx context.Context,
 	return r.Update(ctx, cm)
 }
 
-// for test purposes only, normally it returns value from 'trustBundlePath' constant in this module
+func (r *TrustedCABundleReconciler) createOrUpdateSecret(ctx context.Context, secret *corev1.Secret) error {
+	// check if target secret exists, create if not
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.Create(ctx, secret)
+	} else if err != nil {
+		return err
+	}
+
+	return r.Update(ctx, secret)
+}
+
 func (r *TrustedCABundleReconciler) getTrustBundlePath() string {
-	if r.trustBundlePath != "" {
-		return r.trustBundlePath
+	if r.TrustBundlePath != "" {
+		return r.TrustBundlePath
 	}
-	return systemTrustBundlePath
+	return DefaultSystemTrustBundlePath
+}
+
+// GetSystemTrustBundlePath gets the system trust bundle path from the env, falling back to the default one.
+func GetSystemTrustBundlePath() string {
+	if path := os.Getenv(systemTrustBundlePathEnvVariableName); path != "" {
+		return path
+	}
+	return DefaultSystemTrustBundlePath
 }
 
 func (r *TrustedCABundleReconciler) getSystemTrustBundle() ([]byte, error) {
+	if r.isSourceDisabled(SystemCABundleSource) {
+		klog.V(1).Infof("system trust bundle source is disabled, it will not be added")
+		return nil, nil
+	}
 	bundleData, err := os.ReadFile(r.getTrustBundlePath())
 	if err != nil {
 		return nil, err
 	}
-	_, err = util.CertificateData(bundleData)
-	if err != nil {
+	if err := util.ValidateCertificateData(bundleData); err != nil {
 		return nil, err
 	}
 
 	return bundleData, nil
 }
 
+// mergeCABundles prepends the additional bundle to the system one, dropping the certificates the result holds twice.
 func (r *TrustedCABundleReconciler) mergeCABundles(additionalData, systemData []byte) ([]byte, error) {
 	if len(additionalData) == 0 {
 		return nil, fmt.Errorf("failed to merge ca bundles, additional trust bundle is empty")
 	}
-	if len(systemData) == 0 {
+	if len(systemData) == 0 && !r.isSourceDisabled(SystemCABundleSource) {
 		return nil, fmt.Errorf("failed to merge ca bundles, system trust bundle is empty")
 	}
 
@@ -274,15 +1082,20 @@ func (r *TrustedCABundleReconciler) mergeCABundles(additionalData, systemData []
 	combinedTrustData = append(combinedTrustData, []byte("\n")...)
 	combinedTrustData = append(combinedTrustData, systemData...)
 
-	if _, err := util.CertificateData(combinedTrustData); err != nil {
-		return nil, err
-	}
-
-	return combinedTrustData, nil
+	// Bundles often overlap, e.g. the proxy and cloud-config CAs, keep every certificate once.
+	return util.DeduplicateCertificates(combinedTrustData)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TrustedCABundleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// The system trust bundle is refreshed on the node, e.g. by RHCOS updates, without the pod being restarted.
+	systemTrustBundleWatcher := newFileWatcher(r.getTrustBundlePath(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: trustedCAConfigMapName, Namespace: r.ManagedNamespace},
+	})
+	if err := mgr.Add(systemTrustBundleWatcher); err != nil {
+		return err
+	}
+
 	build := ctrl.NewControllerManagedBy(mgr).
 		Named("TrustedCABundleController").
 		For(
@@ -290,15 +1103,41 @@ func (r *TrustedCABundleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			builder.WithPredicates(
 				predicate.Or(
 					openshiftConfigNamespacedPredicate(),
-					ccmTrustedCABundleConfigMapPredicates(r.ManagedNamespace),
+					ccmTrustedCABundleConfigMapPredicates(r.targetNamespaces()),
 					ownCloudConfigPredicate(r.ManagedNamespace),
+					configMapKeysPredicate(r.AdditionalCABundleSources),
+					injectTrustedCABundleConfigMapPredicates(r.targetNamespaces()),
 				),
 			),
 		).
 		Watches(
 			&configv1.Proxy{},
 			&handler.EnqueueRequestForObject{},
+		).
+		Watches(
+			&corev1.Secret{},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(
+				predicate.Or(
+					openstackCredentialsSecretPredicates(r.ManagedNamespace),
+					secretKeysPredicate(r.CABundleSecretSources),
+					secretKeysPredicate(r.trustedCASecretKeys()),
+				),
+			),
+		).
+		Watches(
+			&configv1.Infrastructure{},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(infrastructurePredicates()),
+		).
+		WatchesRawSource(source.Channel(systemTrustBundleWatcher.EventStream(), &handler.EnqueueRequestForObject{}))
+
+	if r.MergeImageRegistryCA {
+		build = build.Watches(
+			&configv1.Image{},
+			&handler.EnqueueRequestForObject{},
 		)
+	}
 
 	return build.Complete(r)
 }
@@ -321,7 +1160,7 @@ func (r *TrustedCABundleReconciler) setAvailableCondition(ctx context.Context) e
 	return r.syncStatus(ctx, co, conds, nil)
 }
 
-func (r *TrustedCABundleReconciler) setDegradedCondition(ctx context.Context) error {
+func (r *TrustedCABundleReconciler) setDegradedCondition(ctx context.Context, syncErr error) error {
 	co, err := r.getOrCreateClusterOperator(ctx)
 	if err != nil {
 		return err
@@ -332,9 +1171,38 @@ func (r *TrustedCABundleReconciler) setDegradedCondition(ctx context.Context) er
 			"Trusted CA Bundle Controller failed to sync cloud config"),
 		newClusterOperatorStatusCondition(trustedCABundleControllerDegradedCondition, configv1.ConditionTrue, ReasonSyncFailed,
 			"Trusted CA Bundle Controller failed to sync cloud config"),
+		newClusterOperatorStatusCondition(trustedCABundleDegradedCondition, configv1.ConditionTrue, ReasonSyncFailed,
+			fmt.Sprintf("Trusted CA bundle failed to sync: %v", syncErr)),
 	}
 
 	co.Status.Versions = []configv1.OperandVersion{{Name: operatorVersionKey, Version: r.ReleaseVersion}}
 	klog.Info("Trusted CA Bundle Controller is degraded")
 	return r.syncStatus(ctx, co, conds, nil)
 }
+
+// setSourcesDegradedCondition sets the trustedCABundleDegradedCondition to True with the problems of the sources
+// skipped during the sync, or to False when there are none. The message is capped at
+// maxSourcesDegradedMessageLength, so a lot of broken sources do not bloat the cluster operator. The cluster operator
+// is only updated when the condition changes.
+func (r *TrustedCABundleReconciler) setSourcesDegradedCondition(ctx context.Context, problems sourceProblems) error {
+	co, err := r.getOrCreateClusterOperator(ctx)
+	if err != nil {
+		return err
+	}
+
+	cond := newClusterOperatorStatusCondition(trustedCABundleDegradedCondition, configv1.ConditionFalse, ReasonAsExpected,
+		"Trusted CA bundle is synced from all its sources")
+	if len(problems) > 0 {
+		message := fmt.Sprintf("Trusted CA bundle is synced without some of its sources: %s", strings.Join(problems, "; "))
+		if len(message) > maxSourcesDegradedMessageLength {
+			message = message[:maxSourcesDegradedMessageLength-len("...")] + "..."
+		}
+		cond = newClusterOperatorStatusCondition(trustedCABundleDegradedCondition, configv1.ConditionTrue, ReasonTrustedCASourcesSkipped, message)
+	}
+
+	if existing := v1helpers.FindStatusCondition(co.Status.Conditions, cond.Type); existing != nil &&
+		existing.Status == cond.Status && existing.Message == cond.Message {
+		return nil
+	}
+	return r.syncStatus(ctx, co, []configv1.ClusterOperatorStatusCondition{cond}, nil)
+}
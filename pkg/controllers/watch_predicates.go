@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"slices"
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -11,6 +12,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/azure"
+	"github.com/openshift/cluster-cloud-controller-manager-operator/pkg/cloud/openstack"
 )
 
 func clusterOperatorPredicates() predicate.Funcs {
@@ -27,6 +31,25 @@ func clusterOperatorPredicates() predicate.Funcs {
 	}
 }
 
+// clusterOperatorAnnotationPredicates only passes ClusterOperator events changing the given annotation,
+// so controllers reporting their status in the ClusterOperator are not triggered by their own updates.
+func clusterOperatorAnnotationPredicates(annotation string) predicate.Funcs {
+	isClusterOperator := func(obj runtime.Object) bool {
+		clusterOperator, ok := obj.(*configv1.ClusterOperator)
+		return ok && clusterOperator.GetName() == clusterOperatorName
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isClusterOperator(e.ObjectNew) &&
+				e.ObjectOld.GetAnnotations()[annotation] != e.ObjectNew.GetAnnotations()[annotation]
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+	}
+}
+
 func toClusterOperator(context.Context, client.Object) []reconcile.Request {
 	return []reconcile.Request{{
 		NamespacedName: client.ObjectKey{Name: clusterOperatorName},
@@ -39,6 +62,14 @@ func toManagedConfigMap(context.Context, client.Object) []reconcile.Request {
 	}}
 }
 
+// applyResultsConfigMapPredicates filters out the apply results ConfigMap, which is written by the operator
+// itself on every sync.
+func applyResultsConfigMapPredicates() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() != applyResultsConfigMapName
+	})
+}
+
 func infrastructurePredicates() predicate.Funcs {
 	isInfrastructureCluster := func(obj runtime.Object) bool {
 		infra, ok := obj.(*configv1.Infrastructure)
@@ -53,6 +84,34 @@ func infrastructurePredicates() predicate.Funcs {
 	}
 }
 
+func proxyPredicates() predicate.Funcs {
+	isProxyCluster := func(obj runtime.Object) bool {
+		proxy, ok := obj.(*configv1.Proxy)
+		return ok && proxy.GetName() == proxyResourceName
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isProxyCluster(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isProxyCluster(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isProxyCluster(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isProxyCluster(e.Object) },
+	}
+}
+
+func networkPredicates() predicate.Funcs {
+	isNetworkCluster := func(obj runtime.Object) bool {
+		network, ok := obj.(*configv1.Network)
+		return ok && network.GetName() == networkResourceName
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isNetworkCluster(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isNetworkCluster(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isNetworkCluster(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isNetworkCluster(e.Object) },
+	}
+}
+
 func featureGatePredicates() predicate.Funcs {
 	isFeatureGateCluster := func(obj runtime.Object) bool {
 		featureGate, ok := obj.(*configv1.FeatureGate)
@@ -95,7 +154,9 @@ func ownCloudConfigPredicate(targetNamespace string) predicate.Funcs {
 	}
 }
 
-func openshiftCloudConfigMapPredicates() predicate.Funcs {
+// openshiftCloudConfigMapPredicates matches the ConfigMaps of the openshift-config namespace, or of the given source
+// namespace if not empty, and the managed cloud config.
+func openshiftCloudConfigMapPredicates(sourceNamespace string) predicate.Funcs {
 	isCloudConfigMap := func(obj runtime.Object) bool {
 		configMap, ok := obj.(*corev1.ConfigMap)
 
@@ -104,9 +165,10 @@ func openshiftCloudConfigMapPredicates() predicate.Funcs {
 		}
 
 		isOpenshiftConfigNamespace := configMap.GetNamespace() == OpenshiftConfigNamespace
+		isSourceNamespace := sourceNamespace != "" && configMap.GetNamespace() == sourceNamespace
 		isManagedCloudConfig := configMap.GetName() == managedCloudConfigMapName && configMap.GetNamespace() == OpenshiftManagedConfigNamespace
 
-		return isOpenshiftConfigNamespace || isManagedCloudConfig
+		return isOpenshiftConfigNamespace || isSourceNamespace || isManagedCloudConfig
 	}
 
 	return predicate.Funcs{
@@ -117,10 +179,52 @@ func openshiftCloudConfigMapPredicates() predicate.Funcs {
 	}
 }
 
-func ccmTrustedCABundleConfigMapPredicates(targetNamespace string) predicate.Funcs {
+func openstackCredentialsSecretPredicates(targetNamespace string) predicate.Funcs {
+	isCredentialsSecret := func(obj runtime.Object) bool {
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.GetNamespace() == targetNamespace && secret.GetName() == openstack.CloudsYAMLSecretName
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isCredentialsSecret(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isCredentialsSecret(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isCredentialsSecret(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isCredentialsSecret(e.Object) },
+	}
+}
+
+func azureCredentialsSecretPredicates(targetNamespace string) predicate.Funcs {
+	isCredentialsSecret := func(obj runtime.Object) bool {
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.GetNamespace() == targetNamespace && secret.GetName() == azure.CredentialsSecretName
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isCredentialsSecret(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isCredentialsSecret(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isCredentialsSecret(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isCredentialsSecret(e.Object) },
+	}
+}
+
+func cloudConfigSecretPredicates() predicate.Funcs {
+	isCloudConfigSecret := func(obj runtime.Object) bool {
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.GetNamespace() == OpenshiftConfigNamespace && secret.GetName() == cloudConfigSecretName
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isCloudConfigSecret(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isCloudConfigSecret(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isCloudConfigSecret(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isCloudConfigSecret(e.Object) },
+	}
+}
+
+func ccmTrustedCABundleConfigMapPredicates(targetNamespaces []string) predicate.Funcs {
 	isTrustedCaConfigMap := func(obj runtime.Object) bool {
 		configMap, ok := obj.(*corev1.ConfigMap)
-		return ok && configMap.GetNamespace() == targetNamespace && configMap.GetName() == trustedCAConfigMapName
+		return ok && slices.Contains(targetNamespaces, configMap.GetNamespace()) && configMap.GetName() == trustedCAConfigMapName
 	}
 	return predicate.Funcs{
 		CreateFunc:  func(e event.CreateEvent) bool { return isTrustedCaConfigMap(e.Object) },
@@ -130,6 +234,50 @@ func ccmTrustedCABundleConfigMapPredicates(targetNamespace string) predicate.Fun
 	}
 }
 
+// injectTrustedCABundleConfigMapPredicates matches the ConfigMaps of the target namespaces labeled for the merged
+// trust bundle to be injected into them. Deletions are ignored, there is nothing left to inject into.
+func injectTrustedCABundleConfigMapPredicates(targetNamespaces []string) predicate.Funcs {
+	isInjectedConfigMap := func(obj runtime.Object) bool {
+		configMap, ok := obj.(*corev1.ConfigMap)
+		return ok && slices.Contains(targetNamespaces, configMap.GetNamespace()) &&
+			configMap.GetLabels()[injectTrustedCABundleLabel] == "true"
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isInjectedConfigMap(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isInjectedConfigMap(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isInjectedConfigMap(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+	}
+}
+
+// configMapKeysPredicate matches the ConfigMaps with the given namespaced names, e.g. the additional CA bundle sources.
+func configMapKeysPredicate(keys []client.ObjectKey) predicate.Funcs {
+	isListedConfigMap := func(obj runtime.Object) bool {
+		configMap, ok := obj.(*corev1.ConfigMap)
+		return ok && slices.Contains(keys, client.ObjectKeyFromObject(configMap))
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isListedConfigMap(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isListedConfigMap(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isListedConfigMap(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isListedConfigMap(e.Object) },
+	}
+}
+
+// secretKeysPredicate matches the Secrets with the given namespaced names, e.g. the Secret CA bundle sources.
+func secretKeysPredicate(keys []client.ObjectKey) predicate.Funcs {
+	isListedSecret := func(obj runtime.Object) bool {
+		secret, ok := obj.(*corev1.Secret)
+		return ok && slices.Contains(keys, client.ObjectKeyFromObject(secret))
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isListedSecret(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isListedSecret(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isListedSecret(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isListedSecret(e.Object) },
+	}
+}
+
 // Config maps from 'openshift-config' namespace
 func openshiftConfigNamespacedPredicate() predicate.Funcs {
 	isTrustedCaConfigMap := func(obj runtime.Object) bool {
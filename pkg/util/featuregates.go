@@ -2,8 +2,11 @@ package util
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/api/features"
 	"github.com/openshift/library-go/pkg/operator/configobserver/featuregates"
 	upstreamfeature "k8s.io/component-base/featuregate"
 	cloudfeatures "k8s.io/controller-manager/pkg/features"
@@ -17,6 +20,10 @@ func GetEnabledDisabledFeatures(features featuregates.FeatureGate, filter []stri
 	var enabled []string
 	var disabled []string
 
+	if features == nil {
+		return enabled, disabled
+	}
+
 	for _, feature := range features.KnownFeatures() {
 		if features.Enabled(feature) {
 			enabled = append(enabled, string(feature))
@@ -36,16 +43,20 @@ func GetEnabledDisabledFeatures(features featuregates.FeatureGate, filter []stri
 // BuildFeatureGateString takes slices of enabled and disabled feature gates and returns a string
 // that can be passed as a cmd param "--feature-gates=" to the Cloud Provider. Returned string
 // will be formated in a way that it can be passed as-is, i.e. enabled features will get "=true"
-// suffix and disabled "=false". E.g. "ChocobombStrawberry=true,ChocobombBanana=false".
+// suffix and disabled "=false". E.g. "ChocobombBanana=false,ChocobombStrawberry=true".
+// Features are sorted by name, so the string only changes along with the feature gates and does not roll
+// out the operands needlessly.
 func BuildFeatureGateString(enabled, disabled []string) string {
-	for i, x := range enabled {
-		enabled[i] = x + "=true"
+	gates := make([]string, 0, len(enabled)+len(disabled))
+	for _, x := range enabled {
+		gates = append(gates, x+"=true")
 	}
-	for i, x := range disabled {
-		disabled[i] = x + "=false"
+	for _, x := range disabled {
+		gates = append(gates, x+"=false")
 	}
+	slices.Sort(gates)
 
-	return strings.Join(append(enabled, disabled...), ",")
+	return strings.Join(gates, ",")
 }
 
 // GetUpstreamCloudFeatureGates returns a list of feature gates that are allowed to be used in the
@@ -65,6 +76,97 @@ func GetUpstreamCloudFeatureGates() ([]string, error) {
 	return result, nil
 }
 
+// FeatureGatesFromObject builds a FeatureGate from the cluster FeatureGate object. Detailed
+// per-version status lists are preferred when they are populated for the desired version,
+// since they reflect what the rest of the cluster has observed. When the status is missing or
+// only partially initialized, the enabled and disabled gates are derived from the spec featureSet
+// instead, so that the result does not depend on how far the FeatureGate status has been rendered.
+// The featureSet is resolved for the given cluster profile, since gates differ between profiles.
+func FeatureGatesFromObject(featureGate *configv1.FeatureGate, desiredVersion string, clusterProfile features.ClusterProfileName) (featuregates.FeatureGate, error) {
+	if featureGate == nil {
+		return nil, fmt.Errorf("featuregate is nil")
+	}
+
+	if enabled, disabled, found := featureGatesFromStatus(featureGate.Status, desiredVersion); found {
+		return featuregates.NewFeatureGate(enabled, disabled), nil
+	}
+
+	enabled, disabled, err := featureGatesFromSpec(featureGate.Spec, clusterProfile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine feature gates for version %q: %w", desiredVersion, err)
+	}
+	return featuregates.NewFeatureGate(enabled, disabled), nil
+}
+
+// featureGatesFromStatus looks up the status-style gate lists for the desired version.
+// Entries with empty names are skipped, and a version entry without any named gates
+// is treated as not yet populated.
+func featureGatesFromStatus(status configv1.FeatureGateStatus, desiredVersion string) ([]configv1.FeatureGateName, []configv1.FeatureGateName, bool) {
+	for _, details := range status.FeatureGates {
+		if details.Version != desiredVersion {
+			continue
+		}
+
+		enabled := attributeNames(details.Enabled)
+		disabled := attributeNames(details.Disabled)
+		if len(enabled) == 0 && len(disabled) == 0 {
+			return nil, nil, false
+		}
+		return enabled, disabled, true
+	}
+	return nil, nil, false
+}
+
+// featureGatesFromSpec resolves the enabled and disabled gates from the featureSet in the spec
+// for the given cluster profile. For CustomNoUpgrade the explicit lists from the spec are layered on top of the Default set.
+func featureGatesFromSpec(spec configv1.FeatureGateSpec, clusterProfile features.ClusterProfileName) ([]configv1.FeatureGateName, []configv1.FeatureGateName, error) {
+	featureSet := spec.FeatureSet
+	if featureSet == configv1.CustomNoUpgrade {
+		featureSet = configv1.Default
+	}
+
+	known, err := features.FeatureSets(clusterProfile, featureSet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var enabled, disabled []configv1.FeatureGateName
+	for _, description := range known.Enabled {
+		enabled = append(enabled, description.FeatureGateAttributes.Name)
+	}
+	for _, description := range known.Disabled {
+		disabled = append(disabled, description.FeatureGateAttributes.Name)
+	}
+
+	if spec.FeatureSet == configv1.CustomNoUpgrade && spec.CustomNoUpgrade != nil {
+		for _, name := range spec.CustomNoUpgrade.Enabled {
+			disabled = slices.DeleteFunc(disabled, func(n configv1.FeatureGateName) bool { return n == name })
+			if !slices.Contains(enabled, name) {
+				enabled = append(enabled, name)
+			}
+		}
+		for _, name := range spec.CustomNoUpgrade.Disabled {
+			enabled = slices.DeleteFunc(enabled, func(n configv1.FeatureGateName) bool { return n == name })
+			if !slices.Contains(disabled, name) {
+				disabled = append(disabled, name)
+			}
+		}
+	}
+
+	return enabled, disabled, nil
+}
+
+func attributeNames(attributes []configv1.FeatureGateAttributes) []configv1.FeatureGateName {
+	var names []configv1.FeatureGateName
+	for _, attribute := range attributes {
+		if attribute.Name == "" {
+			continue
+		}
+		names = append(names, attribute.Name)
+	}
+	return names
+}
+
 func filterStringsByNames(features []string, filter []string) []string {
 	var result []string
 	for _, feature := range features {
@@ -0,0 +1,167 @@
+package util
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/api/features"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureGatesFromObject(t *testing.T) {
+	const desiredVersion = "4.99.0"
+
+	defaultSet, err := features.FeatureSets(features.SelfManaged, configv1.Default)
+	assert.NoError(t, err)
+	defaultEnabled := defaultSet.Enabled[0].FeatureGateAttributes.Name
+	defaultDisabled := defaultSet.Disabled[0].FeatureGateAttributes.Name
+
+	hypershiftSet, err := features.FeatureSets(features.Hypershift, configv1.Default)
+	assert.NoError(t, err)
+	hypershiftEnabled := hypershiftSet.Enabled[0].FeatureGateAttributes.Name
+	hypershiftDisabled := hypershiftSet.Disabled[0].FeatureGateAttributes.Name
+
+	tc := []struct {
+		name            string
+		featureGate     *configv1.FeatureGate
+		clusterProfile  features.ClusterProfileName
+		expectEnabled   []configv1.FeatureGateName
+		expectDisabled  []configv1.FeatureGateName
+		expectKnownSize int
+		expectError     string
+	}{{
+		name:        "Nil featuregate is rejected",
+		expectError: "featuregate is nil",
+	}, {
+		name: "Status for the desired version is used",
+		featureGate: &configv1.FeatureGate{
+			Status: configv1.FeatureGateStatus{
+				FeatureGates: []configv1.FeatureGateDetails{{
+					Version:  "4.98.0",
+					Enabled:  []configv1.FeatureGateAttributes{{Name: "ChocobombBanana"}},
+					Disabled: []configv1.FeatureGateAttributes{{Name: "ChocobombVanilla"}},
+				}, {
+					Version:  desiredVersion,
+					Enabled:  []configv1.FeatureGateAttributes{{Name: "ChocobombVanilla"}},
+					Disabled: []configv1.FeatureGateAttributes{{Name: "ChocobombBanana"}},
+				}},
+			},
+		},
+		expectEnabled:   []configv1.FeatureGateName{"ChocobombVanilla"},
+		expectDisabled:  []configv1.FeatureGateName{"ChocobombBanana"},
+		expectKnownSize: 2,
+	}, {
+		name: "Unnamed status entries are skipped",
+		featureGate: &configv1.FeatureGate{
+			Status: configv1.FeatureGateStatus{
+				FeatureGates: []configv1.FeatureGateDetails{{
+					Version:  desiredVersion,
+					Enabled:  []configv1.FeatureGateAttributes{{Name: "ChocobombVanilla"}, {}},
+					Disabled: []configv1.FeatureGateAttributes{{}},
+				}},
+			},
+		},
+		expectEnabled:   []configv1.FeatureGateName{"ChocobombVanilla"},
+		expectKnownSize: 1,
+	}, {
+		name:           "Missing status falls back to the default featureSet",
+		featureGate:    &configv1.FeatureGate{},
+		expectEnabled:  []configv1.FeatureGateName{defaultEnabled},
+		expectDisabled: []configv1.FeatureGateName{defaultDisabled},
+	}, {
+		name:           "Missing status falls back to the featureSet of the given cluster profile",
+		featureGate:    &configv1.FeatureGate{},
+		clusterProfile: features.Hypershift,
+		expectEnabled:  []configv1.FeatureGateName{hypershiftEnabled},
+		expectDisabled: []configv1.FeatureGateName{hypershiftDisabled},
+	}, {
+		name: "Empty status for the desired version falls back to the featureSet",
+		featureGate: &configv1.FeatureGate{
+			Status: configv1.FeatureGateStatus{
+				FeatureGates: []configv1.FeatureGateDetails{{
+					Version:  desiredVersion,
+					Enabled:  []configv1.FeatureGateAttributes{{}},
+					Disabled: []configv1.FeatureGateAttributes{},
+				}},
+			},
+		},
+		expectEnabled:  []configv1.FeatureGateName{defaultEnabled},
+		expectDisabled: []configv1.FeatureGateName{defaultDisabled},
+	}, {
+		name: "Status for other versions falls back to the featureSet",
+		featureGate: &configv1.FeatureGate{
+			Status: configv1.FeatureGateStatus{
+				FeatureGates: []configv1.FeatureGateDetails{{
+					Version: "4.98.0",
+					Enabled: []configv1.FeatureGateAttributes{{Name: "ChocobombBanana"}},
+				}},
+			},
+		},
+		expectEnabled:  []configv1.FeatureGateName{defaultEnabled},
+		expectDisabled: []configv1.FeatureGateName{defaultDisabled},
+	}, {
+		name: "CustomNoUpgrade overrides are layered over the default featureSet",
+		featureGate: &configv1.FeatureGate{
+			Spec: configv1.FeatureGateSpec{
+				FeatureGateSelection: configv1.FeatureGateSelection{
+					FeatureSet: configv1.CustomNoUpgrade,
+					CustomNoUpgrade: &configv1.CustomFeatureGates{
+						Enabled:  []configv1.FeatureGateName{defaultDisabled, "ChocobombStrawberry"},
+						Disabled: []configv1.FeatureGateName{defaultEnabled},
+					},
+				},
+			},
+		},
+		expectEnabled:  []configv1.FeatureGateName{defaultDisabled, "ChocobombStrawberry"},
+		expectDisabled: []configv1.FeatureGateName{defaultEnabled},
+	}, {
+		name: "Unknown featureSet is rejected",
+		featureGate: &configv1.FeatureGate{
+			Spec: configv1.FeatureGateSpec{
+				FeatureGateSelection: configv1.FeatureGateSelection{
+					FeatureSet: "Chocobomb",
+				},
+			},
+		},
+		expectError: "unable to determine feature gates for version \"4.99.0\": no information found for FeatureSet=\"Chocobomb\" under ClusterProfile=\"include.release.openshift.io/self-managed-high-availability\"",
+	}}
+
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterProfile := tc.clusterProfile
+			if clusterProfile == "" {
+				clusterProfile = features.SelfManaged
+			}
+
+			gates, err := FeatureGatesFromObject(tc.featureGate, desiredVersion, clusterProfile)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Nil(t, gates)
+				return
+			}
+			assert.NoError(t, err)
+
+			for _, name := range tc.expectEnabled {
+				assert.True(t, gates.Enabled(name), "expected %q to be enabled", name)
+			}
+			for _, name := range tc.expectDisabled {
+				assert.False(t, gates.Enabled(name), "expected %q to be disabled", name)
+			}
+			if tc.expectKnownSize != 0 {
+				assert.Len(t, gates.KnownFeatures(), tc.expectKnownSize)
+			}
+		})
+	}
+}
+
+func TestBuildFeatureGateString(t *testing.T) {
+	assert.Equal(t, "", BuildFeatureGateString(nil, nil))
+	assert.Equal(t, "ChocobombBanana=false,ChocobombStrawberry=true,ChocobombVanilla=true",
+		BuildFeatureGateString([]string{"ChocobombVanilla", "ChocobombStrawberry"}, []string{"ChocobombBanana"}))
+}
+
+func TestGetEnabledDisabledFeaturesNilFeatureGate(t *testing.T) {
+	enabled, disabled := GetEnabledDisabledFeatures(nil, nil)
+	assert.Empty(t, enabled)
+	assert.Empty(t, disabled)
+}
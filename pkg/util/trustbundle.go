@@ -1,11 +1,13 @@
 package util
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
-
-	corev1 "k8s.io/api/core/v1"
+	"iter"
 )
 
 const (
@@ -13,48 +15,146 @@ const (
 	certPEMBlock = "CERTIFICATE"
 )
 
-// TrustBundleConfigMap validates that ConfigMap contains a
-// trust bundle named aa "caBundleKey" argument and that "caBundleKey"
-// contains one or more valid PEM encoded certificates, returning
-// a byte slice of "caBundleKey" contents upon success.
-func TrustBundleConfigMap(cfgMap *corev1.ConfigMap, caBundleKey string) ([]*x509.Certificate, []byte, error) {
-	if _, ok := cfgMap.Data[caBundleKey]; !ok {
-		return nil, nil, fmt.Errorf("ConfigMap %q is missing %q", cfgMap.Name, caBundleKey)
-	}
-	trustBundleData := []byte(cfgMap.Data[caBundleKey])
-	if len(trustBundleData) == 0 {
-		return nil, nil, fmt.Errorf("data key %q is empty from ConfigMap %q", caBundleKey, cfgMap.Name)
-	}
-	certBundle, err := CertificateData(trustBundleData)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed parsing certificate data from ConfigMap %q: %v", cfgMap.Name, err)
-	}
-
-	return certBundle, trustBundleData, nil
-}
-
 // CertificateData decodes certData, ensuring each PEM block is type
 // "CERTIFICATE" and the block can be parsed as an x509 certificate,
 // returning slice of parsed certificates
 func CertificateData(certData []byte) ([]*x509.Certificate, error) {
 	certBundle := []*x509.Certificate{}
-	for len(certData) != 0 {
-		var block *pem.Block
-		block, certData = pem.Decode(certData)
+	for cert, err := range Certificates(certData) {
+		if err != nil {
+			return nil, err
+		}
+		certBundle = append(certBundle, cert)
+	}
+
+	return certBundle, nil
+}
+
+// Certificates returns an iterator over the certificates of certData, checked like CertificateData does. Blocks are
+// decoded and parsed one at a time as the iteration goes, so large bundles are walked through without holding all
+// of their parsed certificates at once. The iteration stops after yielding the error of the first invalid block.
+func Certificates(certData []byte) iter.Seq2[*x509.Certificate, error] {
+	return func(yield func(*x509.Certificate, error) bool) {
+		rest := certData
+		for len(rest) != 0 {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				yield(nil, fmt.Errorf("failed to parse certificate PEM"))
+				return
+			}
+			if block.Type != certPEMBlock {
+				yield(nil, fmt.Errorf("invalid certificate PEM, must be of type %q", certPEMBlock))
+				return
+			}
+
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to parse certificate: %v", err))
+				return
+			}
+			if !yield(cert, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ValidateCertificateData checks certData like CertificateData does, without keeping the parsed certificates.
+func ValidateCertificateData(certData []byte) error {
+	for _, err := range Certificates(certData) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidCertificateData decodes certData block by block like CertificateData does, but skips the blocks which are not
+// valid certificates instead of failing on the first one. It returns the valid certificates encoded back in order,
+// along with an error naming each skipped block by its position in certData, starting at 1.
+func ValidCertificateData(certData []byte) ([]byte, []error) {
+	buf := &bytes.Buffer{}
+	var invalid []error
+	index := 0
+	for len(bytes.TrimSpace(certData)) != 0 {
+		block, rest := pem.Decode(certData)
+		skipped := certData
+		if block != nil {
+			skipped = certData[:len(certData)-len(rest)]
+		}
+		// pem.Decode silently skips the blocks it fails to decode, e.g. because of a corrupted base64 body,
+		// they are told apart by the begin markers preceding the one of the decoded block.
+		malformed := bytes.Count(skipped, []byte("-----BEGIN "))
+		if block != nil {
+			malformed--
+		} else if malformed == 0 {
+			// Trailing data which is not PEM at all.
+			malformed = 1
+		}
+		for ; malformed > 0; malformed-- {
+			index++
+			invalid = append(invalid, fmt.Errorf("block %d: failed to parse certificate PEM", index))
+		}
 		if block == nil {
-			return nil, fmt.Errorf("failed to parse certificate PEM")
+			break
 		}
+		certData = rest
+		index++
+
 		if block.Type != certPEMBlock {
-			return nil, fmt.Errorf("invalid certificate PEM, must be of type %q", certPEMBlock)
+			invalid = append(invalid, fmt.Errorf("block %d: invalid certificate PEM, must be of type %q", index, certPEMBlock))
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			invalid = append(invalid, fmt.Errorf("block %d: failed to parse certificate: %v", index, err))
+			continue
+		}
+		if err := pem.Encode(buf, &pem.Block{Type: certPEMBlock, Bytes: block.Bytes}); err != nil {
+			invalid = append(invalid, fmt.Errorf("block %d: failed to encode certificate: %v", index, err))
+		}
+	}
+
+	return buf.Bytes(), invalid
+}
 
+// DeduplicateCertificates decodes certData like CertificateData does and encodes its certificates back, dropping the
+// ones with the same SHA-256 fingerprint as a previous one. The order of the certificates is kept, so the result is
+// deterministic for a given input.
+func DeduplicateCertificates(certData []byte) ([]byte, error) {
+	seen := map[[sha256.Size]byte]bool{}
+	return FilterCertificates(certData, func(cert *x509.Certificate) bool {
+		fingerprint := sha256.Sum256(cert.Raw)
+		if seen[fingerprint] {
+			return false
 		}
+		seen[fingerprint] = true
+		return true
+	})
+}
 
-		cert, err := x509.ParseCertificate(block.Bytes)
+// FilterCertificates decodes certData like CertificateData does and encodes back, in order, the certificates
+// keep returns true for.
+func FilterCertificates(certData []byte, keep func(cert *x509.Certificate) bool) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.Grow(len(certData))
+	for cert, err := range Certificates(certData) {
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+			return nil, err
+		}
+		if !keep(cert) {
+			continue
+		}
+		if err := pem.Encode(buf, &pem.Block{Type: certPEMBlock, Bytes: cert.Raw}); err != nil {
+			return nil, fmt.Errorf("failed to encode certificate: %v", err)
 		}
-		certBundle = append(certBundle, cert)
 	}
 
-	return certBundle, nil
+	return buf.Bytes(), nil
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of cert, hex encoded.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(fingerprint[:])
 }